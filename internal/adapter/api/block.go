@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"mashu.example/internal/usecase/user/block_user"
+	"mashu.example/internal/usecase/user/mute_user"
+	"mashu.example/internal/usecase/user/unblock_user"
+	"mashu.example/internal/usecase/user/unmute_user"
+)
+
+func (h *restApiHandler) blockUser(ctx *gin.Context) {
+	blockedId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := block_user.NewBlockUserUseCaseReq(getAuthUserId(ctx), blockedId)
+	res := block_user.NewBlockUserUseCaseRes()
+	uc := block_user.NewBlockUserUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			block_user.ErrBlockSelf:      http.StatusBadRequest,
+			block_user.ErrAlreadyBlocked: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) unblockUser(ctx *gin.Context) {
+	blockedId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := unblock_user.NewUnblockUserUseCaseReq(getAuthUserId(ctx), blockedId)
+	res := unblock_user.NewUnblockUserUseCaseRes()
+	uc := unblock_user.NewUnblockUserUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			unblock_user.ErrNotBlocked: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) muteUser(ctx *gin.Context) {
+	mutedId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := mute_user.NewMuteUserUseCaseReq(getAuthUserId(ctx), mutedId)
+	res := mute_user.NewMuteUserUseCaseRes()
+	uc := mute_user.NewMuteUserUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			mute_user.ErrMuteSelf:     http.StatusBadRequest,
+			mute_user.ErrAlreadyMuted: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) unmuteUser(ctx *gin.Context) {
+	mutedId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := unmute_user.NewUnmuteUserUseCaseReq(getAuthUserId(ctx), mutedId)
+	res := unmute_user.NewUnmuteUserUseCaseRes()
+	uc := unmute_user.NewUnmuteUserUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			unmute_user.ErrNotMuted: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mashu.example/internal/adapter/presenter"
+	"mashu.example/internal/usecase/chat/search_messages"
+)
+
+const defaultSearchPageLimit = 20
+
+func registerChatApis(e *gin.Engine, h *restApiHandler) {
+	chat := e.Group("/chat", h.auth())
+	{
+		chat.GET("/messages/search", h.searchMessages)
+	}
+}
+
+func (h *restApiHandler) searchMessages(ctx *gin.Context) {
+	type searchMessagesQuery struct {
+		Keyword   string    `form:"q" binding:"required"`
+		PartnerId string    `form:"partnerId" binding:"omitempty,uuid"`
+		Since     time.Time `form:"since"`
+		Until     time.Time `form:"until"`
+		Offset    int       `form:"offset" binding:"min=0"`
+		Limit     int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	}
+	q := &searchMessagesQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultSearchPageLimit
+	}
+	partnerId := uuid.Nil
+	if q.PartnerId != "" {
+		partnerId = uuid.MustParse(q.PartnerId)
+	}
+
+	req := search_messages.NewSearchMessagesUseCaseReq(
+		getAuthUserId(ctx),
+		q.Keyword,
+		partnerId,
+		q.Since,
+		q.Until,
+		q.Offset,
+		q.Limit,
+	)
+	res := search_messages.NewSearchMessagesUseCaseRes()
+	uc := search_messages.NewSearchMessagesUseCase(h.chatRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			search_messages.ErrEmptyKeyword:     http.StatusBadRequest,
+			search_messages.ErrInvalidDateRange: http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewSearchMessagesPresenter(res).BuildViewModel())
+}
@@ -1,7 +1,110 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"mashu.example/internal/adapter/presenter"
+	add_comment "mashu.example/internal/usecase/comment/add_comment"
+	delete_comment "mashu.example/internal/usecase/comment/delete_comment"
+	get_comments "mashu.example/internal/usecase/comment/get_comments"
+)
+
+const (
+	defaultCommentPageLimit = 20
+	maxCommentPageLimit     = 100
+)
 
 func registerCommentApis(e *gin.Engine, h *restApiHandler) {
+	e.POST("/post/:id/comments", h.auth(), h.addComment)
+	e.GET("/post/:id/comments", h.auth(), h.getComments)
+	e.DELETE("/comments/:id", h.auth(), h.deleteComment)
+}
+
+func (h *restApiHandler) addComment(ctx *gin.Context) {
+	type addCommentPayload struct {
+		Content string `json:"content" binding:"required"`
+	}
+	postId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	p := &addCommentPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := add_comment.NewAddCommentUseCaseReq(getAuthUserId(ctx), postId, p.Content)
+	res := add_comment.NewAddCommentUseCaseRes()
+	uc := add_comment.NewAddCommentUseCase(h.userRepo, h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			add_comment.ErrAddCommentUnderPrivatePost:     http.StatusForbidden,
+			add_comment.ErrAddCommentUnderFollowrOnlyPost: http.StatusForbidden,
+			add_comment.ErrAddCommentBlocked:              http.StatusForbidden,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": res.CommentId})
+}
+
+func (h *restApiHandler) getComments(ctx *gin.Context) {
+	type getCommentsQuery struct {
+		Offset int `form:"offset" binding:"min=0"`
+		Limit  int `form:"limit" binding:"min=0"`
+	}
+	postId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	q := &getCommentsQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultCommentPageLimit
+	}
+	if q.Limit > maxCommentPageLimit {
+		q.Limit = maxCommentPageLimit
+	}
+	req := get_comments.NewGetCommentsUseCaseReq(postId, getAuthUserId(ctx), q.Offset, q.Limit)
+	res := get_comments.NewGetCommentsUseCaseRes()
+	uc := get_comments.NewGetCommentsUseCase(h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			get_comments.ErrNoPermissionToViewComments: http.StatusForbidden,
+		})
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		presenter.NewCommentListPresenter(res.Comments, res.Total, q.Offset, q.Limit).BuildViewModel(),
+	)
+}
+
+func (h *restApiHandler) deleteComment(ctx *gin.Context) {
+	commentId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := delete_comment.NewDeletePostUseCaseReq(getAuthUserId(ctx), commentId)
+	res := delete_comment.NewDeletePostUseCaseRes()
+	uc := delete_comment.NewDeletePoseUseCase(h.userRepo, h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			delete_comment.ErrNotOwnerOfComment: http.StatusForbidden,
+		})
+		return
+	}
 
+	ctx.Status(http.StatusNoContent)
 }
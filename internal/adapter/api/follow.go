@@ -0,0 +1,224 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mashu.example/internal/adapter/presenter"
+	"mashu.example/internal/usecase/user/bulk_handle_follow_requests"
+	"mashu.example/internal/usecase/user/cancel_follow_request"
+	"mashu.example/internal/usecase/user/change_privacy"
+	"mashu.example/internal/usecase/user/get_follower"
+	"mashu.example/internal/usecase/user/get_following"
+	"mashu.example/internal/usecase/user/handle_follow_request"
+	"mashu.example/internal/usecase/user/list_follow_requests"
+	"mashu.example/internal/usecase/user/remove_follower"
+	"mashu.example/internal/usecase/user/unfollow_user"
+)
+
+const (
+	defaultFollowPageLimit = 20
+	maxFollowPageLimit     = 100
+)
+
+type followPageQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" binding:"min=0"`
+}
+
+// bind the query of the follow page and clamp the limit
+func bindFollowPageQuery(ctx *gin.Context) (*followPageQuery, bool) {
+	q := &followPageQuery{}
+	if err := ctx.ShouldBindQuery(q); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return nil, false
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultFollowPageLimit
+	}
+	if q.Limit > maxFollowPageLimit {
+		q.Limit = maxFollowPageLimit
+	}
+
+	return q, true
+}
+
+func (h *restApiHandler) getFollowers(ctx *gin.Context) {
+	userId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	q, ok := bindFollowPageQuery(ctx)
+	if !ok {
+		return
+	}
+	req := get_follower.NewGetFollowerUseCaseReq(userId, q.Cursor, q.Limit)
+	res := get_follower.NewGetFollowerUseCaseRes()
+	uc := get_follower.NewGetFollowerUsecase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			get_follower.ErrInvalidCursor: http.StatusBadRequest,
+			get_follower.ErrInvalidLimit:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewFollowPagePresenter(res.Users, res.Total, res.NextCursor).BuildViewModel())
+}
+
+func (h *restApiHandler) getFollowings(ctx *gin.Context) {
+	userId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	q, ok := bindFollowPageQuery(ctx)
+	if !ok {
+		return
+	}
+	req := get_following.NewGetFollowingUseCaseReq(userId, q.Cursor, q.Limit)
+	res := get_following.NewGetFollowingUseCaseRes()
+	uc := get_following.NewGetFollowingUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			get_following.ErrInvalidCursor: http.StatusBadRequest,
+			get_following.ErrInvalidLimit:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewFollowPagePresenter(res.Users, res.Total, res.NextCursor).BuildViewModel())
+}
+
+func (h *restApiHandler) unfollowUser(ctx *gin.Context) {
+	followeeId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := unfollow_user.NewUnfollowUserUseCaseReq(getAuthUserId(ctx), followeeId)
+	res := unfollow_user.NewUnfollowUserUseCaseRes()
+	uc := unfollow_user.NewUnfollowUserUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			unfollow_user.ErrNotFollowing: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) cancelFollowRequest(ctx *gin.Context) {
+	followeeId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := cancel_follow_request.NewCancelFollowRequestUseCaseReq(getAuthUserId(ctx), followeeId)
+	res := cancel_follow_request.NewCancelFollowRequestUseCaseRes()
+	uc := cancel_follow_request.NewCancelFollowRequestUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			cancel_follow_request.ErrFollowRequestNotFound: http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) removeFollower(ctx *gin.Context) {
+	followerId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := remove_follower.NewRemoveFollowerUseCaseReq(getAuthUserId(ctx), followerId)
+	res := remove_follower.NewRemoveFollowerUseCaseRes()
+	uc := remove_follower.NewRemoveFollowerUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			remove_follower.ErrNotFollower: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) listFollowRequests(ctx *gin.Context) {
+	req := list_follow_requests.NewListFollowRequestsUseCaseReq(getAuthUserId(ctx))
+	res := list_follow_requests.NewListFollowRequestsUseCaseRes()
+	uc := list_follow_requests.NewListFollowRequestsUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, nil)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewFollowRequestsPresenter(res).BuildViewModel())
+}
+
+// approve or reject the pending follow requests, all of them if no user is given
+func (h *restApiHandler) handleFollowRequests(ctx *gin.Context) {
+	type handleFollowRequestsPayload struct {
+		Action  string      `json:"action" binding:"required,oneof=ACCEPT REJECT"`
+		UserIds []uuid.UUID `json:"userIds"`
+	}
+	p := &handleFollowRequestsPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseReq(
+		getAuthUserId(ctx),
+		p.UserIds,
+		handle_follow_request.HandleFollowRequestAction(p.Action),
+	)
+	res := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseRes()
+	uc := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			bulk_handle_follow_requests.ErrInvalidAction: http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"handled": res.Handled,
+		"skipped": res.Skipped,
+	})
+}
+
+func (h *restApiHandler) changePrivacy(ctx *gin.Context) {
+	type changePrivacyPayload struct {
+		Public *bool `json:"public" binding:"required"`
+	}
+	p := &changePrivacyPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := change_privacy.NewChangePrivacyUseCaseReq(getAuthUserId(ctx), *p.Public)
+	res := change_privacy.NewChangePrivacyUseCaseRes()
+	uc := change_privacy.NewChangePrivacyUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, nil)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"approvedFollowers": res.ApprovedFollowerIds})
+}
@@ -1,7 +1,290 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mashu.example/internal/adapter/presenter"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/group/add_admin"
+	"mashu.example/internal/usecase/group/create_group"
+	"mashu.example/internal/usecase/group/delete_admin"
+	"mashu.example/internal/usecase/group/get_group"
+	"mashu.example/internal/usecase/group/handle_invite_request"
+	"mashu.example/internal/usecase/group/handle_join_request"
+	"mashu.example/internal/usecase/group/invite_join_group"
+	"mashu.example/internal/usecase/group/join_group"
+)
 
 func registerGroupApis(e *gin.Engine, h *restApiHandler) {
+	group := e.Group("/groups", h.auth())
+	{
+		group.POST("", h.createGroup)
+		group.GET("/:id", h.getGroup)
+		group.GET("/:id/members", h.getGroupMembers)
+		group.POST("/:id/join-requests", h.joinGroup)
+		group.PUT("/:id/join-requests/:userId", h.handleJoinRequest)
+		group.POST("/:id/invitations", h.inviteJoinGroup)
+		group.PUT("/:id/invitations/:userId", h.handleInvitation)
+		group.POST("/:id/admins", h.addGroupAdmin)
+		group.DELETE("/:id/admins/:userId", h.deleteGroupAdmin)
+	}
+}
+
+func (h *restApiHandler) createGroup(ctx *gin.Context) {
+	type createGroupPayload struct {
+		Name       string `json:"name" binding:"required"`
+		Permission string `json:"permission" binding:"required,oneof=PUBLIC PRIVATE"`
+	}
+	p := &createGroupPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := create_group.NewCreateGroupUseCaseReq(
+		p.Name,
+		getAuthUserId(ctx),
+		entity_enums.GroupPrivacy(p.Permission),
+	)
+	res := create_group.NewCreateGroupUseCaseRes()
+	uc := create_group.NewCreateGroupUseCase(h.groupRepo, h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, nil)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": res.GroupId})
+}
+
+func (h *restApiHandler) getGroup(ctx *gin.Context) {
+	res, ok := h.executeGetGroup(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewGroupPresenter(res.Group).BuildViewModel())
+}
+
+func (h *restApiHandler) getGroupMembers(ctx *gin.Context) {
+	res, ok := h.executeGetGroup(ctx)
+	if !ok {
+		return
+	}
+
+	// the members are hidden from the viewer
+	if res.Group.Members == nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			newRestErrResponse("only the group member can view the members of the private group"),
+		)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"members": presenter.NewGroupMembersPresenter(res.Group.Members).BuildViewModel(),
+	})
+}
+
+func (h *restApiHandler) executeGetGroup(ctx *gin.Context) (*get_group.GetGroupUseCaseRes, bool) {
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return nil, false
+	}
+	req := get_group.NewGetGroupUseCaseReq(groupId, getAuthUserId(ctx))
+	res := get_group.NewGetGroupUseCaseRes()
+	uc := get_group.NewGetGroupUseCase(h.groupRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, nil)
+		return nil, false
+	}
+
+	return res, true
+}
+
+func (h *restApiHandler) joinGroup(ctx *gin.Context) {
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := join_group.NewJoinGroupUseCaseReq(getAuthUserId(ctx), groupId)
+	res := join_group.NewJoinGroupUseCaseRes()
+	uc := join_group.NewJoinGroupUseCase(h.userRepo, h.groupRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			join_group.ErrGroupIsPrivate:     http.StatusForbidden,
+			join_group.ErrIsAlreadyMember:    http.StatusConflict,
+			join_group.ErrIsAlreadyRequested: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (h *restApiHandler) handleJoinRequest(ctx *gin.Context) {
+	type handleJoinRequestPayload struct {
+		Action string `json:"action" binding:"required,oneof=ACCEPT REJECT"`
+	}
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	requesterId, ok := getUUIDParam(ctx, "userId")
+	if !ok {
+		return
+	}
+	p := &handleJoinRequestPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := handle_join_request.NewHandleJoinRequestUseCaseReq(
+		requesterId,
+		groupId,
+		handle_join_request.HandleJoinRequestAction(p.Action),
+		getAuthUserId(ctx),
+	)
+	res := handle_join_request.NewHandleJoinRequestUseCaseRes()
+	uc := handle_join_request.NewHandleJoinRequestUseCase(h.userRepo, h.groupRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			handle_join_request.ErrApproverHasNoPermission: http.StatusForbidden,
+			handle_join_request.ErrJoinRequestNotFound:     http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) inviteJoinGroup(ctx *gin.Context) {
+	type inviteJoinGroupPayload struct {
+		InviteeId uuid.UUID `json:"inviteeId" binding:"required"`
+	}
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	p := &inviteJoinGroupPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := invite_join_group.NewInviteJoinGroupUseCaseReq(p.InviteeId, groupId, getAuthUserId(ctx))
+	res := invite_join_group.NewInviteJoinGroupUseCaseRes()
+	uc := invite_join_group.NewInviteJoinGroupUseCase(h.userRepo, h.groupRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			invite_join_group.ErrInviterIsNotMember:      http.StatusForbidden,
+			invite_join_group.ErrInviteeIsAlreadyMember:  http.StatusConflict,
+			invite_join_group.ErrInviteeIsAlreadyInvited: http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (h *restApiHandler) handleInvitation(ctx *gin.Context) {
+	type handleInvitationPayload struct {
+		Action string `json:"action" binding:"required,oneof=ACCEPT REJECT"`
+	}
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	inviteeId, ok := getUUIDParam(ctx, "userId")
+	if !ok {
+		return
+	}
+	p := &handleInvitationPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
+		inviteeId,
+		groupId,
+		handle_invite_request.HandleInviteRequestAction(p.Action),
+		getAuthUserId(ctx),
+	)
+	res := handle_invite_request.NewHandleInviteRequestUseCaseRes()
+	uc := handle_invite_request.NewHandleInviteRequestUseCase(h.userRepo, h.groupRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			handle_invite_request.ErrApproverHasNoPermission: http.StatusForbidden,
+			handle_invite_request.ErrInvitationNotFound:      http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *restApiHandler) addGroupAdmin(ctx *gin.Context) {
+	type addGroupAdminPayload struct {
+		UserId uuid.UUID `json:"userId" binding:"required"`
+	}
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	p := &addGroupAdminPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := add_admin.NewAddAdminUseCaseReq(p.UserId, groupId, getAuthUserId(ctx))
+	res := add_admin.NewAddAdminUseCaseRes()
+	uc := add_admin.NewAddAdminUseCase(h.groupRepo, h.userRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			add_admin.ErrNotGroupOwnerOrAdmin: http.StatusForbidden,
+			add_admin.ErrNotGroupMember:       http.StatusBadRequest,
+			add_admin.ErrIsAlreadyAdmin:       http.StatusConflict,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (h *restApiHandler) deleteGroupAdmin(ctx *gin.Context) {
+	groupId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	userId, ok := getUUIDParam(ctx, "userId")
+	if !ok {
+		return
+	}
+	req := delete_admin.NewDeleteAdminUseCaseReq(userId, groupId, getAuthUserId(ctx))
+	res := delete_admin.NewDeleteAdminUseCaseRes()
+	uc := delete_admin.NewDeleteAdminUseCase(h.userRepo, h.groupRepo, &req, &res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			delete_admin.ErrNotGroupOwnerOrAdmin: http.StatusForbidden,
+			delete_admin.ErrAdminNotFound:        http.StatusNotFound,
+		})
+		return
+	}
 
+	ctx.Status(http.StatusNoContent)
 }
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/adapter/presenter"
+	"mashu.example/internal/adapter/utils"
+	"mashu.example/internal/usecase/chat/create_group_chat"
+	"mashu.example/internal/usecase/chat/load_group_messages"
+	"mashu.example/internal/usecase/chat/send_group_message"
+	"mashu.example/internal/usecase/repository"
+)
+
+func (h *websocketHandler) createGroupChat(client *utils.WebSocketClient, payload wsMsgPayload) {
+	type createGroupChatPayload struct {
+		GroupId string `json:"groupId" validate:"required,uuid"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &createGroupChatPayload{}
+	json.Unmarshal(payloadByte, p)
+	if err := validator.New().Struct(p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+
+	req := create_group_chat.NewCreateGroupChatUseCaseReq(client.UserId, uuid.MustParse(p.GroupId))
+	res := create_group_chat.NewCreateGroupChatUseCaseRes()
+	create_group_chat.NewCreateGroupChatUseCase(h.groupRepo, h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(res.Err, create_group_chat.ErrNotGroupMember):
+			code = http.StatusForbidden
+		case errors.Is(res.Err, create_group_chat.ErrGroupChatAlreadyExist):
+			code = http.StatusConflict
+		}
+		client.Send(newWsErrResponse(code, res.Err.Error()))
+		return
+	}
+
+	client.Send(newWsSuccessResponse(
+		http.StatusCreated,
+		fmt.Sprintf("group chat created, id: %s", res.GroupChatId.String()),
+	))
+}
+
+func (h *websocketHandler) sendGroupMessage(senderClient *utils.WebSocketClient, payload wsMsgPayload) {
+	type sendGroupMessagePayload struct {
+		GroupId string `json:"groupId" validate:"required,uuid"`
+		Content string `json:"content" validate:"required"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &sendGroupMessagePayload{}
+	json.Unmarshal(payloadByte, p)
+	if err := validator.New().Struct(p); err != nil {
+		senderClient.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	groupId := uuid.MustParse(p.GroupId)
+
+	req := send_group_message.NewSendGroupMessageUseCaseReq(senderClient.UserId, groupId, p.Content, time.Now())
+	res := send_group_message.NewSendGroupMessageUseCaseRes()
+	send_group_message.NewSendGroupMessageUseCase(h.groupRepo, h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(res.Err, send_group_message.ErrNotGroupMember):
+			code = http.StatusForbidden
+		case errors.Is(res.Err, send_group_message.ErrChatRoomNotExist):
+			code = http.StatusNotFound
+		}
+		senderClient.Send(newWsErrResponse(code, res.Err.Error()))
+		return
+	}
+
+	vm := presenter.NewSendGroupMessagePresenter(senderClient.UserId, groupId, p.Content, res).BuildViewModel()
+	h.publishToOnlineMembers(senderClient.UserId, res.MemberIds, &wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_GROUP_MSG,
+		Payload: wsMsgPayload{
+			"message":      vm,
+			"connectionId": senderClient.ID,
+		},
+	})
+}
+
+// push the message to the online members of the group, the offline members
+// load the messages from the history once they connect. the devices of the
+// sender always receive the message
+func (h *websocketHandler) publishToOnlineMembers(
+	senderId uuid.UUID,
+	memberIds []uuid.UUID,
+	response *wsResponseMessage,
+) {
+	for _, memberId := range memberIds {
+		if memberId != senderId {
+			presence, err := h.presence.Get(memberId)
+			if err != nil {
+				logrus.Error("failed to get the presence: ", err)
+				continue
+			}
+			if !presence.Online {
+				continue
+			}
+		}
+
+		if err := h.bus.Publish(memberId, response); err != nil {
+			logrus.Error("failed to publish the group message: ", err)
+		}
+	}
+}
+
+func (h *websocketHandler) loadGroupHistory(client *utils.WebSocketClient, payload wsMsgPayload) {
+	// payload validation, the latest messages are loaded by default
+	type loadGroupHistoryPayload struct {
+		GroupId string    `json:"groupId" validate:"required,uuid"`
+		Before  time.Time `json:"before"`
+		Limit   int       `json:"limit" validate:"omitempty,min=1,max=100"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &loadGroupHistoryPayload{}
+	if err := json.Unmarshal(payloadByte, p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if err := validator.New().Struct(p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if p.Limit == 0 {
+		p.Limit = defaultMessageHistoryLimit
+	}
+
+	req := load_group_messages.NewLoadGroupMessagesUseCaseReq(client.UserId, uuid.MustParse(p.GroupId), p.Before, p.Limit)
+	res := load_group_messages.NewLoadGroupMessagesUseCaseRes()
+	load_group_messages.NewLoadGroupMessagesUseCase(h.groupRepo, h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		code := http.StatusInternalServerError
+		var errGroupChatNotFound *repository.ErrGroupChatNotFound
+		switch {
+		case errors.Is(res.Err, load_group_messages.ErrNotGroupMember):
+			code = http.StatusForbidden
+		case errors.As(res.Err, &errGroupChatNotFound):
+			code = http.StatusNotFound
+		}
+		client.Send(newWsErrResponse(code, res.Err.Error()))
+		return
+	}
+
+	vm := presenter.NewGroupMessageHistoryPresenter(res).BuildViewModel()
+	client.Send(&wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_LOAD_GROUP_HISTORY,
+		Payload: wsMsgPayload{
+			"groupChatId": vm.GroupChatId,
+			"messages":    vm.Messages,
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg/jwt"
+)
+
+// keys of the authenticated user id and session id in gin context
+const (
+	authUserIdKey    = "authUserId"
+	authSessionIdKey = "authSessionId"
+)
+
+// middleware to authenticate the request by the bearer token in
+// `Authorization` header
+func newAuthMiddleware(jwtClient jwt.JWTClient, sessionRepo repository.SessionRepo) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authenticate(ctx, jwtClient, sessionRepo, getBearerToken(ctx))
+	}
+}
+
+// middleware to authenticate the websocket upgrade request
+//
+// browsers can't set headers for websocket connection, so the token can also
+// be passed by the `token` query parameter
+func newWebSocketAuthMiddleware(jwtClient jwt.JWTClient, sessionRepo repository.SessionRepo) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenString := getBearerToken(ctx)
+		if tokenString == "" {
+			tokenString = ctx.Query("token")
+		}
+		authenticate(ctx, jwtClient, sessionRepo, tokenString)
+	}
+}
+
+func authenticate(
+	ctx *gin.Context,
+	jwtClient jwt.JWTClient,
+	sessionRepo repository.SessionRepo,
+	tokenString string,
+) {
+	if tokenString == "" {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse("missing access token"))
+		return
+	}
+
+	token, err := jwtClient.VerifyToken(tokenString)
+	if err != nil || !token.Valid {
+		logrus.Info("invalid access token: ", err)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse("invalid or expired access token"))
+		return
+	}
+
+	userId, err := jwt.GetUserId(token)
+	if err != nil {
+		logrus.Info("invalid access token: ", err)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse("invalid access token"))
+		return
+	}
+	sessionId, err := jwt.GetSessionId(token)
+	if err != nil {
+		logrus.Info("invalid access token: ", err)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse("invalid access token"))
+		return
+	}
+
+	// the access token is no longer valid once its session is revoked
+	session, err := sessionRepo.GetSessionById(sessionId)
+	if err != nil || session.UserId != userId || !session.IsActive() {
+		logrus.Infof("session %s is not active", sessionId)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse("session is revoked or expired"))
+		return
+	}
+
+	ctx.Set(authUserIdKey, userId)
+	ctx.Set(authSessionIdKey, sessionId)
+	ctx.Next()
+}
+
+func getBearerToken(ctx *gin.Context) string {
+	header := ctx.GetHeader("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return ""
+	}
+
+	return strings.TrimPrefix(header, "Bearer ")
+}
+
+// get the id of authenticated user, only available behind the auth middleware
+func getAuthUserId(ctx *gin.Context) uuid.UUID {
+	return ctx.MustGet(authUserIdKey).(uuid.UUID)
+}
+
+// get the id of current login session, only available behind the auth
+// middleware
+func getAuthSessionId(ctx *gin.Context) uuid.UUID {
+	return ctx.MustGet(authSessionIdKey).(uuid.UUID)
+}
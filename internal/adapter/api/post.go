@@ -1,24 +1,164 @@
 package api
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"mashu.example/internal/adapter/presenter"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/post/create_post"
+	"mashu.example/internal/usecase/post/delete_post"
+	"mashu.example/internal/usecase/post/edit_post"
+	"mashu.example/internal/usecase/post/get_post"
+	"mashu.example/internal/usecase/post/get_user_posts"
+)
+
+var postPermissions = map[string]entity_enums.PostPermission{
+	"PUBLIC":        entity_enums.POST_PUBLIC,
+	"FOLLOWER_ONLY": entity_enums.POST_FOLLOWER_ONLY,
+	"PRIVATE":       entity_enums.POST_PRIVATE,
+}
 
 func registerPostApis(e *gin.Engine, h *restApiHandler) {
-	post := e.Group("/post")
+	post := e.Group("/post", h.auth())
 	{
 		post.POST("", h.createPost)
-		post.PUT("", h.editPost)
-		post.DELETE("", h.deletePost)
+		post.GET("/:id", h.getPost)
+		post.PUT("/:id", h.editPost)
+		post.DELETE("/:id", h.deletePost)
 	}
 }
 
+// parse the uuid in the path parameter, respond 400 if it is malformed
+func getUUIDParam(ctx *gin.Context, key string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(key))
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse("invalid "+key))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
 func (h *restApiHandler) createPost(ctx *gin.Context) {
-	ctx.JSON(201, "success create")
+	type createPostPayload struct {
+		Title      string    `json:"title" binding:"required"`
+		Content    string    `json:"content" binding:"required"`
+		GroupId    uuid.UUID `json:"groupId"`
+		Permission string    `json:"permission" binding:"required,oneof=PUBLIC FOLLOWER_ONLY PRIVATE"`
+	}
+	p := &createPostPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := create_post.NewCreatePostUseCaseReq(
+		p.Title,
+		p.Content,
+		getAuthUserId(ctx),
+		p.GroupId,
+		postPermissions[p.Permission],
+	)
+	res := create_post.NewCreatePostUseCaseRes()
+	uc := create_post.NewCreatePostUseCase(h.userRepo, h.postRepo, h.groupRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			create_post.ErrOwnerNotFound:         http.StatusNotFound,
+			create_post.ErrGroupNotFound:         http.StatusNotFound,
+			create_post.ErrInvalidPostPermission: http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"id": res.PostId})
+}
+
+func (h *restApiHandler) getPost(ctx *gin.Context) {
+	postId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := get_post.NewGetPostUseCaseReq(postId, getAuthUserId(ctx))
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(h.userRepo, h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			get_post.ErrNoPermissionToViewPost: http.StatusForbidden,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewPostPresenter(res.Post).BuildViewModel())
+}
+
+func (h *restApiHandler) getUserPosts(ctx *gin.Context) {
+	userId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := get_user_posts.NewGetUserPostsUseCaseReq(userId, getAuthUserId(ctx))
+	res := get_user_posts.NewGetUserPostsUseCaseRes()
+	uc := get_user_posts.NewGetUserPostsUseCase(h.userRepo, h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, nil)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewPostListPresenter(res.Posts).BuildViewModel())
 }
 
 func (h *restApiHandler) editPost(ctx *gin.Context) {
-	ctx.JSON(201, "success edit")
+	type editPostPayload struct {
+		Title   string `json:"title" binding:"required"`
+		Content string `json:"content" binding:"required"`
+	}
+	postId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	p := &editPostPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := edit_post.NewEditPostUseCaseReq(postId, getAuthUserId(ctx), p.Title, p.Content)
+	res := edit_post.NewEditPostUseCaseRes()
+	uc := edit_post.NewEditPostUseCase(h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			edit_post.ErrNotOwnerOfPost: http.StatusForbidden,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
 
 func (h *restApiHandler) deletePost(ctx *gin.Context) {
-	ctx.JSON(201, "success delete")
+	postId, ok := getUUIDParam(ctx, "id")
+	if !ok {
+		return
+	}
+	req := delete_post.NewDeletePostUseCaseReq(postId, getAuthUserId(ctx))
+	res := delete_post.NewDeletePostUseCaseRes()
+	uc := delete_post.NewDeletePoseUseCase(h.postRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			delete_post.ErrNotOwnerOfPost: http.StatusForbidden,
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/adapter/utils"
+)
+
+// min interval to relay the typing events of a user in a dm
+const typingThrottleInterval = 3 * time.Second
+
+func newPresencePayload(presence *utils.Presence) wsMsgPayload {
+	payload := wsMsgPayload{
+		"userId": presence.UserId,
+		"online": presence.Online,
+	}
+	if !presence.LastSeen.IsZero() {
+		payload["lastSeen"] = presence.LastSeen
+	}
+
+	return payload
+}
+
+// get the users sharing a dm with the user
+func (h *websocketHandler) getDMPartners(userId uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	dms, err := h.chatRepo.GetDMsByPartUserId(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// map the partner id to the dm id
+	partners := map[uuid.UUID]uuid.UUID{}
+	for _, dm := range dms {
+		if dm.Creator.ID == userId {
+			partners[dm.Receiver.ID] = dm.ID
+		} else {
+			partners[dm.Creator.ID] = dm.ID
+		}
+	}
+
+	return partners, nil
+}
+
+// push the presence of the user to the users sharing a dm with the user
+func (h *websocketHandler) publishPresence(presence *utils.Presence) {
+	partners, err := h.getDMPartners(presence.UserId)
+	if err != nil {
+		logrus.Error("failed to get the dm partners: ", err)
+		return
+	}
+
+	for partnerId := range partners {
+		if err := h.bus.Publish(partnerId, &wsResponseMessage{
+			Code:    http.StatusOK,
+			Type:    WS_RES_PRESENCE,
+			Payload: newPresencePayload(presence),
+		}); err != nil {
+			logrus.Error("failed to publish the presence: ", err)
+		}
+	}
+}
+
+// mark the connection online and keep it alive by the heartbeats, the
+// presence is pushed if the user was offline. the presence of the partners
+// is sent to the new connection
+func (h *websocketHandler) connectPresence(client *utils.WebSocketClient) {
+	ttl := h.hub.Config().PongWait
+
+	presence, err := h.presence.Get(client.UserId)
+	if err != nil {
+		logrus.Error("failed to get the presence: ", err)
+		return
+	}
+	wasOnline := presence.Online
+
+	if err := h.presence.Touch(client.UserId, client.ID, ttl); err != nil {
+		logrus.Error("failed to update the presence: ", err)
+		return
+	}
+	client.OnHeartbeat(func() {
+		if err := h.presence.Touch(client.UserId, client.ID, ttl); err != nil {
+			logrus.Error("failed to update the presence: ", err)
+		}
+	})
+
+	if !wasOnline {
+		h.publishPresence(&utils.Presence{UserId: client.UserId, Online: true, LastSeen: time.Now()})
+	}
+
+	partners, err := h.getDMPartners(client.UserId)
+	if err != nil {
+		logrus.Error("failed to get the dm partners: ", err)
+		return
+	}
+	for partnerId := range partners {
+		partnerPresence, err := h.presence.Get(partnerId)
+		if err != nil {
+			logrus.Error("failed to get the presence: ", err)
+			continue
+		}
+		client.Send(&wsResponseMessage{
+			Code:    http.StatusOK,
+			Type:    WS_RES_PRESENCE,
+			Payload: newPresencePayload(partnerPresence),
+		})
+	}
+}
+
+// mark the connection offline, the presence is pushed if it is the last
+// connection of the user
+func (h *websocketHandler) disconnectPresence(client *utils.WebSocketClient) {
+	if err := h.presence.Remove(client.UserId, client.ID); err != nil {
+		logrus.Error("failed to update the presence: ", err)
+		return
+	}
+
+	presence, err := h.presence.Get(client.UserId)
+	if err != nil {
+		logrus.Error("failed to get the presence: ", err)
+		return
+	}
+	if !presence.Online {
+		h.publishPresence(presence)
+	}
+}
+
+// relay the typing event to the partner of the dm, the events of the same
+// user and dm are throttled
+func (h *websocketHandler) typing(client *utils.WebSocketClient, payload wsMsgPayload) {
+	dmIdString, _ := payload["dmId"].(string)
+	dmId, err := uuid.Parse(dmIdString)
+	if err != nil {
+		client.Send(newWsErrResponse(http.StatusBadRequest, "invalid dm id"))
+		return
+	}
+
+	if !h.typingThrottler.Allow(fmt.Sprintf("%s:%s", client.UserId, dmId)) {
+		return
+	}
+
+	partners, err := h.getDMPartners(client.UserId)
+	if err != nil {
+		client.Send(newWsErrResponse(http.StatusInternalServerError, err.Error()))
+		return
+	}
+	var partnerId uuid.UUID
+	for id, partnerDMId := range partners {
+		if partnerDMId == dmId {
+			partnerId = id
+		}
+	}
+	if partnerId == uuid.Nil {
+		client.Send(newWsErrResponse(http.StatusNotFound, "no DM created"))
+		return
+	}
+
+	if err := h.bus.Publish(partnerId, &wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_TYPING,
+		Payload: wsMsgPayload{
+			"dmId":   dmId,
+			"userId": client.UserId,
+		},
+	}); err != nil {
+		logrus.Error("failed to publish the typing event: ", err)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"mashu.example/internal/adapter/presenter"
+	"mashu.example/internal/usecase/user/get_profile"
+	"mashu.example/internal/usecase/user/update_profile"
+)
+
+func (h *restApiHandler) getOwnProfile(ctx *gin.Context) {
+	req := get_profile.NewGetOwnProfileUseCaseReq(getAuthUserId(ctx))
+	h.viewProfile(ctx, req)
+}
+
+func (h *restApiHandler) getProfile(ctx *gin.Context) {
+	// the `id` param holds the username, see `registerUserApis`
+	req := get_profile.NewGetProfileUseCaseReq(getAuthUserId(ctx), ctx.Param("id"))
+	h.viewProfile(ctx, req)
+}
+
+func (h *restApiHandler) viewProfile(ctx *gin.Context, req *get_profile.GetProfileUseCaseReq) {
+	res := get_profile.NewGetProfileUseCaseRes()
+	uc := get_profile.NewGetProfileUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			get_profile.ErrProfileNotFound: http.StatusNotFound,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewProfilePresenter(res.Profile, res.IsOwner).BuildViewModel())
+}
+
+func (h *restApiHandler) updateProfile(ctx *gin.Context) {
+	// the omitted fields are not changed
+	type updateProfilePayload struct {
+		Username    *string `json:"username"`
+		DisplayName *string `json:"displayName"`
+		Email       *string `json:"email"`
+		Bio         *string `json:"bio"`
+		AvatarURL   *string `json:"avatarUrl"`
+		Location    *string `json:"location"`
+		Website     *string `json:"website"`
+		Birthday    *string `json:"birthday"`
+	}
+	p := &updateProfilePayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := update_profile.NewUpdateProfileUseCaseReq(getAuthUserId(ctx), update_profile.ProfileUpdate{
+		UserName:    p.Username,
+		DisplayName: p.DisplayName,
+		Email:       p.Email,
+		Bio:         p.Bio,
+		AvatarURL:   p.AvatarURL,
+		Location:    p.Location,
+		Website:     p.Website,
+		Birthday:    p.Birthday,
+	})
+	res := update_profile.NewUpdateProfileUseCaseRes()
+	uc := update_profile.NewUpdateProfileUseCase(h.userRepo, req, res)
+	uc.Execute()
+
+	if res.Err != nil {
+		abortWithUseCaseErr(ctx, res.Err, map[error]int{
+			update_profile.ErrInvalidUserName:  http.StatusBadRequest,
+			update_profile.ErrUserNameTaken:    http.StatusConflict,
+			update_profile.ErrEmptyDisplayName: http.StatusBadRequest,
+			update_profile.ErrInvalidEmail:     http.StatusBadRequest,
+			update_profile.ErrBioTooLong:       http.StatusBadRequest,
+			update_profile.ErrLocationTooLong:  http.StatusBadRequest,
+			update_profile.ErrInvalidAvatarURL: http.StatusBadRequest,
+			update_profile.ErrInvalidWebsite:   http.StatusBadRequest,
+			update_profile.ErrInvalidBirthday:  http.StatusBadRequest,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, presenter.NewProfilePresenter(res.Profile, true).BuildViewModel())
+}
@@ -1,37 +1,89 @@
 package api
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg/jwt"
 )
 
 func newRestErrResponse(message string) map[string]string {
 	return map[string]string{"error": message}
 }
 
+// abort the request with the status mapped from the error of a use case
+// `statuses` maps the sentinel errors of the use case to the http statuses,
+// missing resources are responded with 404 and any other error with 500
+func abortWithUseCaseErr(ctx *gin.Context, err error, statuses map[error]int) {
+	for target, status := range statuses {
+		if errors.Is(err, target) {
+			ctx.AbortWithStatusJSON(status, newRestErrResponse(err.Error()))
+			return
+		}
+	}
+
+	if isNotFoundErr(err) {
+		ctx.AbortWithStatusJSON(http.StatusNotFound, newRestErrResponse(err.Error()))
+		return
+	}
+
+	ctx.AbortWithStatusJSON(http.StatusInternalServerError, newRestErrResponse(err.Error()))
+}
+
+func isNotFoundErr(err error) bool {
+	var errPostNotFound *repository.ErrPostNotFound
+	var errUserNotFound *repository.ErrUserNotFound
+	var errCommentNotFound *repository.ErrCommentNotFound
+
+	return errors.Is(err, gorm.ErrRecordNotFound) ||
+		errors.As(err, &errPostNotFound) ||
+		errors.As(err, &errUserNotFound) ||
+		errors.As(err, &errCommentNotFound)
+}
+
 type restApiHandler struct {
-	userRepo  repository.UserRepo
-	postRepo  repository.PostRepo
-	groupRepo repository.GroupRepo
+	jwtClient jwt.JWTClient
+
+	userRepo    repository.UserRepo
+	postRepo    repository.PostRepo
+	groupRepo   repository.GroupRepo
+	chatRepo    repository.ChatRepo
+	sessionRepo repository.SessionRepo
 }
 
 func RegisterRestfulApis(
 	e *gin.Engine,
+	jwtClient jwt.JWTClient,
 	userRepo repository.UserRepo,
 	postRepo repository.PostRepo,
 	groupRepo repository.GroupRepo,
+	chatRepo repository.ChatRepo,
+	sessionRepo repository.SessionRepo,
 ) {
-	h := newRestApiHandler(userRepo, postRepo, groupRepo)
+	h := newRestApiHandler(jwtClient, userRepo, postRepo, groupRepo, chatRepo, sessionRepo)
 
+	registerChatApis(e, h)
+	registerCommentApis(e, h)
 	registerGroupApis(e, h)
 	registerPostApis(e, h)
 	registerUserApis(e, h)
 }
 
 func newRestApiHandler(
+	jwtClient jwt.JWTClient,
 	userRepo repository.UserRepo,
 	postRepo repository.PostRepo,
 	groupRepo repository.GroupRepo,
+	chatRepo repository.ChatRepo,
+	sessionRepo repository.SessionRepo,
 ) *restApiHandler {
-	return &restApiHandler{userRepo, postRepo, groupRepo}
+	return &restApiHandler{jwtClient, userRepo, postRepo, groupRepo, chatRepo, sessionRepo}
+}
+
+// middleware to authenticate the request, see `newAuthMiddleware`
+func (h *restApiHandler) auth() gin.HandlerFunc {
+	return newAuthMiddleware(h.jwtClient, h.sessionRepo)
 }
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"mashu.example/internal/usecase/user/login"
+	"mashu.example/internal/usecase/user/logout"
+	"mashu.example/internal/usecase/user/refresh_token"
 	"mashu.example/internal/usecase/user/register"
 )
 
@@ -13,6 +16,26 @@ func registerUserApis(e *gin.Engine, h *restApiHandler) {
 	{
 		user.POST("/register", h.register)
 		user.POST("/login", h.login)
+		user.POST("/token/refresh", h.refreshToken)
+		user.POST("/logout", h.auth(), h.logout)
+		user.POST("/logout/all", h.auth(), h.logoutAllDevices)
+		user.GET("/me", h.auth(), h.getOwnProfile)
+		user.PATCH("/me", h.auth(), h.updateProfile)
+		user.PUT("/privacy", h.auth(), h.changePrivacy)
+		user.GET("/follow-requests", h.auth(), h.listFollowRequests)
+		user.POST("/follow-requests", h.auth(), h.handleFollowRequests)
+		// GET /user/:username, gin needs the wildcard to be named as the sibling routes
+		user.GET("/:id", h.auth(), h.getProfile)
+		user.GET("/:id/posts", h.auth(), h.getUserPosts)
+		user.GET("/:id/followers", h.auth(), h.getFollowers)
+		user.GET("/:id/followings", h.auth(), h.getFollowings)
+		user.DELETE("/:id/follow", h.auth(), h.unfollowUser)
+		user.DELETE("/:id/follow-request", h.auth(), h.cancelFollowRequest)
+		user.DELETE("/:id/follower", h.auth(), h.removeFollower)
+		user.POST("/:id/block", h.auth(), h.blockUser)
+		user.DELETE("/:id/block", h.auth(), h.unblockUser)
+		user.POST("/:id/mute", h.auth(), h.muteUser)
+		user.DELETE("/:id/mute", h.auth(), h.unmuteUser)
 	}
 }
 
@@ -21,18 +44,23 @@ func (h *restApiHandler) register(ctx *gin.Context) {
 		Username    string `json:"username" binding:"required"`
 		DisplayName string `json:"displayName" binding:"required"`
 		Email       string `json:"email" binding:"email"`
+		Password    string `json:"password" binding:"required,min=8"`
 	}
 	p := &registerPayload{}
 	if err := ctx.ShouldBindJSON(p); err != nil {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
 		return
 	}
-	req := register.NewRegisterUseCaseReq(p.Username, p.DisplayName, p.Email)
+	req := register.NewRegisterUseCaseReq(p.Username, p.DisplayName, p.Email, p.Password)
 	res := register.NewRegisterUseCaseRes()
 	uc := register.NewRegisterUseCase(h.userRepo, req, res)
 
 	uc.Execute()
 
+	if errors.Is(res.Err, register.ErrPasswordTooShort) {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(res.Err.Error()))
+		return
+	}
 	if res.Err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, newRestErrResponse(res.Err.Error()))
 		return
@@ -44,15 +72,74 @@ func (h *restApiHandler) register(ctx *gin.Context) {
 func (h *restApiHandler) login(ctx *gin.Context) {
 	type loginPayload struct {
 		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required"`
 	}
 	p := &loginPayload{}
 	if err := ctx.ShouldBindJSON(p); err != nil {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
 		return
 	}
-	req := login.NewLoginUseCaseReq(p.Username)
+	req := login.NewLoginUseCaseReq(p.Username, p.Password)
 	res := login.NewLoginUseCaseRes()
-	uc := login.NewLoginUseCase(h.userRepo, req, res)
+	uc := login.NewLoginUseCase(h.userRepo, h.sessionRepo, h.jwtClient, req, res)
+	uc.Execute()
+
+	if errors.Is(res.Err, login.ErrInvalidCredentials) {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse(res.Err.Error()))
+		return
+	}
+	if res.Err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, newRestErrResponse(res.Err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  res.AccessToken,
+		"refreshToken": res.RefreshToken,
+	})
+}
+
+func (h *restApiHandler) refreshToken(ctx *gin.Context) {
+	type refreshTokenPayload struct {
+		RefreshToken string `json:"refreshToken" binding:"required"`
+	}
+	p := &refreshTokenPayload{}
+	if err := ctx.ShouldBindJSON(p); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, newRestErrResponse(err.Error()))
+		return
+	}
+	req := refresh_token.NewRefreshTokenUseCaseReq(p.RefreshToken)
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(h.sessionRepo, h.jwtClient, req, res)
+	uc.Execute()
+
+	if errors.Is(res.Err, refresh_token.ErrInvalidRefreshToken) {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, newRestErrResponse(res.Err.Error()))
+		return
+	}
+	if res.Err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, newRestErrResponse(res.Err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"accessToken":  res.AccessToken,
+		"refreshToken": res.RefreshToken,
+	})
+}
+
+func (h *restApiHandler) logout(ctx *gin.Context) {
+	h.revokeSessions(ctx, false)
+}
+
+func (h *restApiHandler) logoutAllDevices(ctx *gin.Context) {
+	h.revokeSessions(ctx, true)
+}
+
+func (h *restApiHandler) revokeSessions(ctx *gin.Context, allDevices bool) {
+	req := logout.NewLogoutUseCaseReq(getAuthUserId(ctx), getAuthSessionId(ctx), allDevices)
+	res := logout.NewLogoutUseCaseRes()
+	uc := logout.NewLogoutUseCase(h.sessionRepo, req, res)
 	uc.Execute()
 
 	if res.Err != nil {
@@ -60,5 +147,5 @@ func (h *restApiHandler) login(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, res)
+	ctx.Status(http.StatusNoContent)
 }
@@ -2,8 +2,10 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -11,31 +13,70 @@ import (
 	"github.com/sirupsen/logrus"
 	"mashu.example/internal/adapter/presenter"
 	"mashu.example/internal/adapter/utils"
+	entity_enums "mashu.example/internal/entity/enums"
 	"mashu.example/internal/usecase/chat/create_direct_message"
+	"mashu.example/internal/usecase/chat/delete_message"
+	"mashu.example/internal/usecase/chat/edit_message"
 	"mashu.example/internal/usecase/chat/load_message_history"
+	"mashu.example/internal/usecase/chat/mark_message"
+	"mashu.example/internal/usecase/chat/react_message"
+	"mashu.example/internal/usecase/chat/search_messages"
+	"mashu.example/internal/usecase/chat/send_message"
+	"mashu.example/internal/usecase/chat/sync_messages"
 	"mashu.example/internal/usecase/repository"
 	"mashu.example/pkg"
+	"mashu.example/pkg/jwt"
 )
 
 type wsRequestType string
 type wsResponseType string
 type wsMsgPayload map[string]interface{}
-type wsMessageHandler func(uuid.UUID, wsMsgPayload)
+type wsMessageHandler func(*utils.WebSocketClient, wsMsgPayload)
 
 const (
-	WS_REQ_CREATE_DM    wsRequestType = "CREATE_DM"
-	WS_REQ_SEND_MSG     wsRequestType = "SEND_MSG"
-	WS_REQ_LOAD_HISTORY wsRequestType = "LOAD_HISTORY"
+	WS_REQ_CREATE_DM       wsRequestType = "CREATE_DM"
+	WS_REQ_SEND_MSG        wsRequestType = "SEND_MSG"
+	WS_REQ_LOAD_HISTORY    wsRequestType = "LOAD_HISTORY"
+	WS_REQ_SYNC            wsRequestType = "SYNC"
+	WS_REQ_MARK_DELIVERED  wsRequestType = "MARK_DELIVERED"
+	WS_REQ_MARK_READ       wsRequestType = "MARK_READ"
+	WS_REQ_TYPING          wsRequestType = "TYPING"
+	WS_REQ_EDIT_MSG        wsRequestType = "EDIT_MSG"
+	WS_REQ_DELETE_MSG      wsRequestType = "DELETE_MSG"
+	WS_REQ_ADD_REACTION    wsRequestType = "ADD_REACTION"
+	WS_REQ_REMOVE_REACTION wsRequestType = "REMOVE_REACTION"
+	WS_REQ_SEARCH_MESSAGES wsRequestType = "SEARCH_MESSAGES"
+
+	WS_REQ_CREATE_GROUP_CHAT  wsRequestType = "CREATE_GROUP_CHAT"
+	WS_REQ_SEND_GROUP_MSG     wsRequestType = "SEND_GROUP_MSG"
+	WS_REQ_LOAD_GROUP_HISTORY wsRequestType = "LOAD_GROUP_HISTORY"
 )
 
 const (
-	WS_RES_SEND_MSG     wsResponseType = "SEND_MSG"
-	WS_RES_LOAD_HISTORY wsResponseType = "LOAD_HISTORY"
+	WS_RES_CONNECTED        wsResponseType = "CONNECTED"
+	WS_RES_SEND_MSG         wsResponseType = "SEND_MSG"
+	WS_RES_LOAD_HISTORY     wsResponseType = "LOAD_HISTORY"
+	WS_RES_SYNC             wsResponseType = "SYNC"
+	WS_RES_DELIVERY_RECEIPT wsResponseType = "DELIVERY_RECEIPT"
+	WS_RES_READ_RECEIPT     wsResponseType = "READ_RECEIPT"
+	WS_RES_PRESENCE         wsResponseType = "PRESENCE"
+	WS_RES_TYPING           wsResponseType = "TYPING"
+	WS_RES_MSG_UPDATED      wsResponseType = "MSG_UPDATED"
+	WS_RES_MSG_DELETED      wsResponseType = "MSG_DELETED"
+	WS_RES_REACTION_ADDED   wsResponseType = "REACTION_ADDED"
+	WS_RES_REACTION_REMOVED wsResponseType = "REACTION_REMOVED"
+	WS_RES_SEARCH_MESSAGES  wsResponseType = "SEARCH_MESSAGES"
+
+	WS_RES_GROUP_MSG          wsResponseType = "GROUP_MSG"
+	WS_RES_LOAD_GROUP_HISTORY wsResponseType = "LOAD_GROUP_HISTORY"
 
 	WS_RES_SUCCESS wsResponseType = "SUCCESS"
 	WS_RES_ERR     wsResponseType = "ERROR"
 )
 
+// max number of messages loaded for each dm if the limit is not given
+const defaultMessageHistoryLimit = 50
+
 // struct for websocket request message
 type wsRequestMessage struct {
 	Type    wsRequestType `json:"type"`
@@ -60,79 +101,83 @@ func newWsErrResponse(errCode int, message string) *wsResponseMessage {
 }
 
 type websocketHandler struct {
-	clients         map[uuid.UUID]*utils.WebSocketClient
+	hub             *utils.WebSocketHub
+	bus             utils.MessageBus
+	presence        utils.PresenceStore
+	typingThrottler *utils.Throttler
 	wsMsgHandlerMap map[wsRequestType]wsMessageHandler
 
-	userRepo repository.UserRepo
-	chatRepo repository.ChatRepo
+	userRepo  repository.UserRepo
+	groupRepo repository.GroupRepo
+	chatRepo  repository.ChatRepo
 }
 
 func RegisterWebsocketApi(
 	e *gin.Engine,
+	jwtClient jwt.JWTClient,
 	userRepo repository.UserRepo,
+	groupRepo repository.GroupRepo,
 	chatRepo repository.ChatRepo,
+	sessionRepo repository.SessionRepo,
+	bus utils.MessageBus,
+	presence utils.PresenceStore,
 ) {
-	h := newWebSocketHandler(userRepo, chatRepo)
+	h := newWebSocketHandler(userRepo, groupRepo, chatRepo, bus, presence)
 
-	e.GET("/websocket", h.handleConnection)
+	e.GET("/websocket", newWebSocketAuthMiddleware(jwtClient, sessionRepo), h.handleConnection)
 }
 
 func (h *websocketHandler) handleConnection(c *gin.Context) {
-	userId := c.Query("userId")
-	if userId == "" {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"error": "empty userId",
-		})
-		return
-	}
+	clientId := getAuthUserId(c)
 
 	upgrader := pkg.NewWebSocketUpgrader()
 
 	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		fmt.Println(err)
-		logrus.Panic("failed to construct websocket connection")
+		logrus.Panic("failed to construct websocket connection: ", err)
 		return
 	}
 
-	defer ws.Close()
-
-	// save ws client into memory
-	clientId := uuid.MustParse(userId)
-	newClient := utils.NewWebSocketClient(clientId, ws)
-	fmt.Println("new client:", newClient)
-
-	h.clients[clientId] = newClient
+	// register the client, its messages are written by the writer goroutine
+	newClient := utils.NewWebSocketClient(h.hub, clientId, ws)
+	h.hub.Register(newClient)
+	go newClient.WritePump()
 
-	// send message to connector
-	message := fmt.Sprintf("user %s connected", newClient.UserId)
-	fmt.Println(message)
-	ws.WriteJSON(message)
+	// send the connection id to the connector, a user can connect from multiple devices
+	logrus.Infof("user %s connected, connection id: %s", newClient.UserId, newClient.ID)
+	newClient.Send(&wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_CONNECTED,
+		Payload: wsMsgPayload{
+			"userId":       newClient.UserId,
+			"connectionId": newClient.ID,
+		},
+	})
+	h.connectPresence(newClient)
 
-	// infinite loop to handle incoming connection
-	for {
+	// handle incoming messages until the connection is closed
+	newClient.ReadPump(func(data []byte) {
 		var req wsRequestMessage
-		err := ws.ReadJSON(&req)
-		if err != nil {
-			msg := fmt.Sprintf("client %s disconnected", clientId)
-			logrus.Info(msg)
-			delete(h.clients, clientId)
-			break
+		if err := json.Unmarshal(data, &req); err != nil {
+			newClient.Send(newWsErrResponse(http.StatusBadRequest, "invalid message"))
+			return
 		}
 		logrus.Info("websocket req message", req)
 
 		if handler, ok := h.wsMsgHandlerMap[req.Type]; ok {
-			handler(clientId, req.Payload)
+			handler(newClient, req.Payload)
 		} else {
 			response := "invalid command!"
-			ws.WriteJSON(response)
+			newClient.Send(response)
 		}
-	}
+	})
+	h.disconnectPresence(newClient)
+	logrus.Infof("user %s disconnected, connection id: %s", clientId, newClient.ID)
 }
 
-func (h *websocketHandler) createDM(userId uuid.UUID, payload wsMsgPayload) {
+func (h *websocketHandler) createDM(client *utils.WebSocketClient, payload wsMsgPayload) {
 	logrus.Info("start create DM")
-	client := h.clients[userId]
+	userId := client.UserId
 
 	// payload validation
 	type createDMPayload struct {
@@ -143,7 +188,7 @@ func (h *websocketHandler) createDM(userId uuid.UUID, payload wsMsgPayload) {
 	json.Unmarshal(payloadByte, p)
 	err := validator.New().Struct(p)
 	if err != nil {
-		client.Conn.WriteJSON(newWsErrResponse(
+		client.Send(newWsErrResponse(
 			http.StatusBadRequest,
 			fmt.Sprintf("failed to parse payload: %s", err),
 		))
@@ -151,7 +196,11 @@ func (h *websocketHandler) createDM(userId uuid.UUID, payload wsMsgPayload) {
 	}
 
 	senderId := userId
-	receiverId := uuid.MustParse(payload["targetUserId"].(string))
+	receiverId, err := uuid.Parse(p.TargetUserId)
+	if err != nil {
+		client.Send(newWsErrResponse(http.StatusBadRequest, "invalid user id"))
+		return
+	}
 	req := create_direct_message.NewCreateDirectMessageUseCaseReq(
 		senderId,
 		receiverId,
@@ -166,14 +215,15 @@ func (h *websocketHandler) createDM(userId uuid.UUID, payload wsMsgPayload) {
 	)
 	uc.Execute()
 	if res.Err != nil {
-		client.Conn.WriteJSON(newWsErrResponse(
-			http.StatusConflict,
-			res.Err.Error(),
-		))
+		code := http.StatusConflict
+		if errors.Is(res.Err, create_direct_message.ErrBlockedUser) {
+			code = http.StatusForbidden
+		}
+		client.Send(newWsErrResponse(code, res.Err.Error()))
 		return
 	}
 
-	client.Conn.WriteJSON(newWsSuccessResponse(
+	client.Send(newWsSuccessResponse(
 		http.StatusCreated,
 		fmt.Sprintf("dm created, id: %s", res.DirectMessageId.String()),
 	))
@@ -181,8 +231,8 @@ func (h *websocketHandler) createDM(userId uuid.UUID, payload wsMsgPayload) {
 	logrus.Info("end of creating DM")
 }
 
-func (h *websocketHandler) sendMessage(userId uuid.UUID, payload wsMsgPayload) {
-	senderClient := h.clients[userId]
+func (h *websocketHandler) sendMessage(senderClient *utils.WebSocketClient, payload wsMsgPayload) {
+	userId := senderClient.UserId
 
 	// payload validation
 	type sendMessagePayload struct {
@@ -194,67 +244,103 @@ func (h *websocketHandler) sendMessage(userId uuid.UUID, payload wsMsgPayload) {
 	json.Unmarshal(payloadByte, p)
 	err := validator.New().Struct(p)
 	if err != nil {
-		senderClient.Conn.WriteJSON(newWsErrResponse(
+		senderClient.Send(newWsErrResponse(
 			http.StatusBadRequest,
 			fmt.Sprintf("failed to parse payload: %s", err)),
 		)
 		return
 	}
 
-	// get DM and add message
+	// add message to the DM
 	senderId := userId
 	receiverId, err := uuid.Parse(p.TargetUserId)
 	if err != nil {
-		senderClient.Conn.WriteJSON(newWsErrResponse(http.StatusBadRequest, "invalid user id"))
+		senderClient.Send(newWsErrResponse(http.StatusBadRequest, "invalid user id"))
 		return
 	}
-	dm, err := h.chatRepo.GetDMByUserId(senderId, receiverId)
-	if err != nil {
-		senderClient.Conn.WriteJSON(newWsErrResponse(http.StatusNotFound, "no DM created"))
+
+	req := send_message.NewSendMessageUseCaseReq(senderId, receiverId, p.Content, time.Now())
+	res := send_message.NewSendMessageUseCaseRes()
+	send_message.NewSendMessageUseCase(h.userRepo, h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		code := http.StatusInternalServerError
+		var errUserNotFound *repository.ErrUserNotFound
+		if errors.Is(res.Err, send_message.ErrChatRoomNotExist) || errors.As(res.Err, &errUserNotFound) {
+			code = http.StatusNotFound
+		} else if errors.Is(res.Err, send_message.ErrBlockedUser) {
+			code = http.StatusForbidden
+		}
+		senderClient.Send(newWsErrResponse(code, res.Err.Error()))
 		return
 	}
-	dm.AddMessage(senderId, p.Content)
 
-	// send message
-	senderClient.Conn.WriteJSON(&wsResponseMessage{
+	// send the created message to every device of the sender and the receiver
+	// through the bus, the devices may be connected to other instances. the
+	// connection id tells the devices which one the message is sent from
+	vm := presenter.NewSendMessagePresenter(senderId, receiverId, p.Content, res).BuildViewModel()
+	response := &wsResponseMessage{
 		Code: http.StatusOK,
 		Type: WS_RES_SEND_MSG,
 		Payload: wsMsgPayload{
-			"message": p.Content,
+			"message":      vm,
+			"connectionId": senderClient.ID,
 		},
-	})
-	if receiverClient, ok := h.clients[receiverId]; ok {
-		receiverClient.Conn.WriteJSON(&wsResponseMessage{
-			Code: http.StatusOK,
-			Type: WS_RES_SEND_MSG,
-			Payload: wsMsgPayload{
-				"message": p.Content,
-			},
-		})
+	}
+	if err := h.bus.Publish(senderId, response); err != nil {
+		senderClient.Send(newWsErrResponse(http.StatusInternalServerError, "failed to send message"))
+		return
+	}
+	if receiverId != senderId {
+		if err := h.bus.Publish(receiverId, response); err != nil {
+			senderClient.Send(newWsErrResponse(http.StatusInternalServerError, "failed to send message"))
+		}
 	}
 }
 
-func (h *websocketHandler) loadMessageHistory(userId uuid.UUID, payload wsMsgPayload) {
-	fmt.Println("start load history")
-	userClient := h.clients[userId]
+func (h *websocketHandler) loadMessageHistory(userClient *utils.WebSocketClient, payload wsMsgPayload) {
+	userId := userClient.UserId
+
+	// payload validation, the latest messages are loaded by default
+	type loadMessageHistoryPayload struct {
+		Before time.Time `json:"before"`
+		Limit  int       `json:"limit" validate:"omitempty,min=1,max=100"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &loadMessageHistoryPayload{}
+	if err := json.Unmarshal(payloadByte, p); err != nil {
+		userClient.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if err := validator.New().Struct(p); err != nil {
+		userClient.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if p.Limit == 0 {
+		p.Limit = defaultMessageHistoryLimit
+	}
 
-	req := load_message_history.NewLoadMessageHistoryUseCaseReq(userId)
+	req := load_message_history.NewLoadMessageHistoryUseCaseReq(userId, p.Before, p.Limit)
 	res := load_message_history.NewLoadMessageHistoryUseCaseRes()
 	uc := load_message_history.NewLoadMessageHistoryUseCase(h.userRepo, h.chatRepo, req, res)
 
 	uc.Execute()
 	if res.Err != nil {
-		userClient.Conn.WriteJSON(newWsErrResponse(
+		userClient.Send(newWsErrResponse(
 			http.StatusInternalServerError,
 			res.Err.Error(),
 		))
 		return
 	}
 
-	p := presenter.NewMessageHistoryPresenter(res)
-	vm := p.BuildViewModel()
+	vm := presenter.NewMessageHistoryPresenter(res).BuildViewModel()
 
-	userClient.Conn.WriteJSON(&wsResponseMessage{
+	userClient.Send(&wsResponseMessage{
 		Code: http.StatusOK,
 		Type: WS_RES_LOAD_HISTORY,
 		Payload: wsMsgPayload{
@@ -263,20 +349,372 @@ func (h *websocketHandler) loadMessageHistory(userId uuid.UUID, payload wsMsgPay
 	})
 }
 
+func (h *websocketHandler) searchMessages(client *utils.WebSocketClient, payload wsMsgPayload) {
+	type searchMessagesPayload struct {
+		Keyword   string    `json:"keyword" validate:"required"`
+		PartnerId string    `json:"partnerId" validate:"omitempty,uuid"`
+		Since     time.Time `json:"since"`
+		Until     time.Time `json:"until"`
+		Offset    int       `json:"offset" validate:"min=0"`
+		Limit     int       `json:"limit" validate:"omitempty,min=1,max=100"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &searchMessagesPayload{}
+	if err := json.Unmarshal(payloadByte, p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if err := validator.New().Struct(p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	if p.Limit == 0 {
+		p.Limit = defaultSearchPageLimit
+	}
+	partnerId := uuid.Nil
+	if p.PartnerId != "" {
+		partnerId = uuid.MustParse(p.PartnerId)
+	}
+
+	req := search_messages.NewSearchMessagesUseCaseReq(
+		client.UserId,
+		p.Keyword,
+		partnerId,
+		p.Since,
+		p.Until,
+		p.Offset,
+		p.Limit,
+	)
+	res := search_messages.NewSearchMessagesUseCaseRes()
+	search_messages.NewSearchMessagesUseCase(h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(res.Err, search_messages.ErrEmptyKeyword) || errors.Is(res.Err, search_messages.ErrInvalidDateRange) {
+			code = http.StatusBadRequest
+		}
+		client.Send(newWsErrResponse(code, res.Err.Error()))
+		return
+	}
+
+	vm := presenter.NewSearchMessagesPresenter(res).BuildViewModel()
+	client.Send(&wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_SEARCH_MESSAGES,
+		Payload: wsMsgPayload{
+			"results":    vm.Results,
+			"nextOffset": vm.NextOffset,
+		},
+	})
+}
+
+// get the messages missed since the cursor, the client passes the cursor of
+// the last sync to acknowledge the messages it received
+func (h *websocketHandler) syncMessages(client *utils.WebSocketClient, payload wsMsgPayload) {
+	type syncPayload struct {
+		Cursor time.Time `json:"cursor"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &syncPayload{}
+	if err := json.Unmarshal(payloadByte, p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+
+	req := sync_messages.NewSyncMessagesUseCaseReq(client.UserId, p.Cursor)
+	res := sync_messages.NewSyncMessagesUseCaseRes()
+	sync_messages.NewSyncMessagesUseCase(h.userRepo, h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		client.Send(newWsErrResponse(http.StatusInternalServerError, res.Err.Error()))
+		return
+	}
+
+	vm := presenter.NewSyncMessagesPresenter(client.UserId, res).BuildViewModel()
+	client.Send(&wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_SYNC,
+		Payload: wsMsgPayload{
+			"messages": vm.Messages,
+			"cursor":   vm.Cursor,
+		},
+	})
+}
+
+// create the handler to mark the messages up to the given one, the receipt is
+// pushed to the other participant of the dm
+func (h *websocketHandler) markMessage(
+	status entity_enums.MessageStatus,
+	receiptType wsResponseType,
+) wsMessageHandler {
+	return func(client *utils.WebSocketClient, payload wsMsgPayload) {
+		type markMessagePayload struct {
+			DMId      string `json:"dmId" validate:"required,uuid"`
+			MessageId string `json:"messageId" validate:"required,uuid"`
+		}
+		payloadByte, _ := json.Marshal(payload)
+		p := &markMessagePayload{}
+		json.Unmarshal(payloadByte, p)
+		if err := validator.New().Struct(p); err != nil {
+			client.Send(newWsErrResponse(
+				http.StatusBadRequest,
+				fmt.Sprintf("failed to parse payload: %s", err)),
+			)
+			return
+		}
+		dmId, messageId := uuid.MustParse(p.DMId), uuid.MustParse(p.MessageId)
+
+		req := mark_message.NewMarkMessageUseCaseReq(client.UserId, dmId, messageId, status)
+		res := mark_message.NewMarkMessageUseCaseRes()
+		mark_message.NewMarkMessageUseCase(h.chatRepo, req, res).Execute()
+		if res.Err != nil {
+			code := http.StatusInternalServerError
+			var errDMNotFound *repository.ErrDMNotFound
+			var errMessageNotFound *repository.ErrMessageNotFound
+			switch {
+			case errors.Is(res.Err, mark_message.ErrNotParticipant):
+				code = http.StatusForbidden
+			case errors.As(res.Err, &errDMNotFound), errors.As(res.Err, &errMessageNotFound):
+				code = http.StatusNotFound
+			}
+			client.Send(newWsErrResponse(code, res.Err.Error()))
+			return
+		}
+
+		if err := h.bus.Publish(res.PartnerId, &wsResponseMessage{
+			Code: http.StatusOK,
+			Type: receiptType,
+			Payload: wsMsgPayload{
+				"dmId":             dmId,
+				"userId":           client.UserId,
+				"messageId":        messageId,
+				"messageTimestamp": res.MessageTimestamp,
+			},
+		}); err != nil {
+			logrus.Error("failed to publish the receipt: ", err)
+		}
+		client.Send(newWsSuccessResponse(http.StatusOK, "message marked"))
+	}
+}
+
+func (h *websocketHandler) editMessage(client *utils.WebSocketClient, payload wsMsgPayload) {
+	type editMessagePayload struct {
+		DMId      string `json:"dmId" validate:"required,uuid"`
+		MessageId string `json:"messageId" validate:"required,uuid"`
+		Content   string `json:"content" validate:"required"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &editMessagePayload{}
+	json.Unmarshal(payloadByte, p)
+	if err := validator.New().Struct(p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	dmId, messageId := uuid.MustParse(p.DMId), uuid.MustParse(p.MessageId)
+
+	req := edit_message.NewEditMessageUseCaseReq(client.UserId, dmId, messageId, p.Content)
+	res := edit_message.NewEditMessageUseCaseRes()
+	edit_message.NewEditMessageUseCase(h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		client.Send(newWsErrResponse(
+			messageUpdateErrCode(res.Err, edit_message.ErrNotMessageOwner, edit_message.ErrMessageDeleted),
+			res.Err.Error(),
+		))
+		return
+	}
+
+	h.publishMessageUpdate(client, res.PartnerId, &wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_MSG_UPDATED,
+		Payload: wsMsgPayload{
+			"dmId":         dmId,
+			"messageId":    messageId,
+			"content":      res.Content,
+			"editedAt":     res.EditedAt,
+			"connectionId": client.ID,
+		},
+	})
+}
+
+func (h *websocketHandler) deleteMessage(client *utils.WebSocketClient, payload wsMsgPayload) {
+	type deleteMessagePayload struct {
+		DMId      string `json:"dmId" validate:"required,uuid"`
+		MessageId string `json:"messageId" validate:"required,uuid"`
+	}
+	payloadByte, _ := json.Marshal(payload)
+	p := &deleteMessagePayload{}
+	json.Unmarshal(payloadByte, p)
+	if err := validator.New().Struct(p); err != nil {
+		client.Send(newWsErrResponse(
+			http.StatusBadRequest,
+			fmt.Sprintf("failed to parse payload: %s", err)),
+		)
+		return
+	}
+	dmId, messageId := uuid.MustParse(p.DMId), uuid.MustParse(p.MessageId)
+
+	req := delete_message.NewDeleteMessageUseCaseReq(client.UserId, dmId, messageId)
+	res := delete_message.NewDeleteMessageUseCaseRes()
+	delete_message.NewDeleteMessageUseCase(h.chatRepo, req, res).Execute()
+	if res.Err != nil {
+		client.Send(newWsErrResponse(
+			messageUpdateErrCode(res.Err, delete_message.ErrNotMessageOwner, delete_message.ErrMessageDeleted),
+			res.Err.Error(),
+		))
+		return
+	}
+
+	h.publishMessageUpdate(client, res.PartnerId, &wsResponseMessage{
+		Code: http.StatusOK,
+		Type: WS_RES_MSG_DELETED,
+		Payload: wsMsgPayload{
+			"dmId":         dmId,
+			"messageId":    messageId,
+			"deletedAt":    res.DeletedAt,
+			"connectionId": client.ID,
+		},
+	})
+}
+
+// create the handler to add or remove the emoji reaction of the user, the
+// change is pushed to the other participant and the other devices of the user
+func (h *websocketHandler) reactMessage(add bool, eventType wsResponseType) wsMessageHandler {
+	return func(client *utils.WebSocketClient, payload wsMsgPayload) {
+		type reactMessagePayload struct {
+			DMId      string `json:"dmId" validate:"required,uuid"`
+			MessageId string `json:"messageId" validate:"required,uuid"`
+			Emoji     string `json:"emoji" validate:"required"`
+		}
+		payloadByte, _ := json.Marshal(payload)
+		p := &reactMessagePayload{}
+		json.Unmarshal(payloadByte, p)
+		if err := validator.New().Struct(p); err != nil {
+			client.Send(newWsErrResponse(
+				http.StatusBadRequest,
+				fmt.Sprintf("failed to parse payload: %s", err)),
+			)
+			return
+		}
+		dmId, messageId := uuid.MustParse(p.DMId), uuid.MustParse(p.MessageId)
+
+		req := react_message.NewReactMessageUseCaseReq(client.UserId, dmId, messageId, p.Emoji, add)
+		res := react_message.NewReactMessageUseCaseRes()
+		react_message.NewReactMessageUseCase(h.chatRepo, req, res).Execute()
+		if res.Err != nil {
+			code := http.StatusInternalServerError
+			var errDMNotFound *repository.ErrDMNotFound
+			var errMessageNotFound *repository.ErrMessageNotFound
+			switch {
+			case errors.Is(res.Err, react_message.ErrInvalidEmoji):
+				code = http.StatusBadRequest
+			case errors.Is(res.Err, react_message.ErrNotParticipant):
+				code = http.StatusForbidden
+			case errors.Is(res.Err, react_message.ErrMessageDeleted):
+				code = http.StatusConflict
+			case errors.As(res.Err, &errDMNotFound), errors.As(res.Err, &errMessageNotFound):
+				code = http.StatusNotFound
+			}
+			client.Send(newWsErrResponse(code, res.Err.Error()))
+			return
+		}
+
+		h.publishMessageUpdate(client, res.PartnerId, &wsResponseMessage{
+			Code: http.StatusOK,
+			Type: eventType,
+			Payload: wsMsgPayload{
+				"dmId":         dmId,
+				"messageId":    messageId,
+				"userId":       client.UserId,
+				"emoji":        p.Emoji,
+				"connectionId": client.ID,
+			},
+		})
+	}
+}
+
+// push the change of the message to the other participant and the other
+// devices of the user
+func (h *websocketHandler) publishMessageUpdate(
+	client *utils.WebSocketClient,
+	partnerId uuid.UUID,
+	response *wsResponseMessage,
+) {
+	if err := h.bus.Publish(client.UserId, response); err != nil {
+		client.Send(newWsErrResponse(http.StatusInternalServerError, "failed to publish the message update"))
+		return
+	}
+	if partnerId != client.UserId {
+		if err := h.bus.Publish(partnerId, response); err != nil {
+			client.Send(newWsErrResponse(http.StatusInternalServerError, "failed to publish the message update"))
+		}
+	}
+}
+
+func messageUpdateErrCode(err error, errNotOwner error, errDeleted error) int {
+	var errDMNotFound *repository.ErrDMNotFound
+	var errMessageNotFound *repository.ErrMessageNotFound
+	switch {
+	case errors.Is(err, errNotOwner):
+		return http.StatusForbidden
+	case errors.Is(err, errDeleted):
+		return http.StatusConflict
+	case errors.As(err, &errDMNotFound), errors.As(err, &errMessageNotFound):
+		return http.StatusNotFound
+	}
+
+	return http.StatusInternalServerError
+}
+
 func newWebSocketHandler(
 	userRepo repository.UserRepo,
+	groupRepo repository.GroupRepo,
 	chatRepo repository.ChatRepo,
+	bus utils.MessageBus,
+	presence utils.PresenceStore,
 ) *websocketHandler {
 	h := &websocketHandler{
-		clients:         map[uuid.UUID]*utils.WebSocketClient{},
+		hub:             utils.NewWebSocketHub(utils.NewDefaultWebSocketConfig()),
+		bus:             bus,
+		presence:        presence,
+		typingThrottler: utils.NewThrottler(typingThrottleInterval),
 		wsMsgHandlerMap: map[wsRequestType]wsMessageHandler{},
 		userRepo:        userRepo,
+		groupRepo:       groupRepo,
 		chatRepo:        chatRepo,
 	}
 
 	h.wsMsgHandlerMap[WS_REQ_CREATE_DM] = h.createDM
 	h.wsMsgHandlerMap[WS_REQ_SEND_MSG] = h.sendMessage
 	h.wsMsgHandlerMap[WS_REQ_LOAD_HISTORY] = h.loadMessageHistory
+	h.wsMsgHandlerMap[WS_REQ_SYNC] = h.syncMessages
+	h.wsMsgHandlerMap[WS_REQ_MARK_DELIVERED] = h.markMessage(entity_enums.MESSAGE_DELIVERED, WS_RES_DELIVERY_RECEIPT)
+	h.wsMsgHandlerMap[WS_REQ_MARK_READ] = h.markMessage(entity_enums.MESSAGE_READ, WS_RES_READ_RECEIPT)
+	h.wsMsgHandlerMap[WS_REQ_TYPING] = h.typing
+	h.wsMsgHandlerMap[WS_REQ_EDIT_MSG] = h.editMessage
+	h.wsMsgHandlerMap[WS_REQ_DELETE_MSG] = h.deleteMessage
+	h.wsMsgHandlerMap[WS_REQ_ADD_REACTION] = h.reactMessage(true, WS_RES_REACTION_ADDED)
+	h.wsMsgHandlerMap[WS_REQ_REMOVE_REACTION] = h.reactMessage(false, WS_RES_REACTION_REMOVED)
+	h.wsMsgHandlerMap[WS_REQ_SEARCH_MESSAGES] = h.searchMessages
+	h.wsMsgHandlerMap[WS_REQ_CREATE_GROUP_CHAT] = h.createGroupChat
+	h.wsMsgHandlerMap[WS_REQ_SEND_GROUP_MSG] = h.sendGroupMessage
+	h.wsMsgHandlerMap[WS_REQ_LOAD_GROUP_HISTORY] = h.loadGroupHistory
+
+	go h.hub.Run()
+
+	// deliver the messages published by any instance to the local sockets
+	if err := h.bus.Subscribe(h.hub.SendDataToUser); err != nil {
+		logrus.Panic("failed to subscribe the message bus: ", err)
+	}
 
 	return h
 }
@@ -11,6 +11,7 @@ import (
 	"mashu.example/config"
 	"mashu.example/internal/usecase/repository"
 	"mashu.example/pkg"
+	"mashu.example/pkg/jwt"
 )
 
 var (
@@ -27,6 +28,8 @@ func NewDiscordBot(
 	userRepo repository.UserRepo,
 	postRepo repository.PostRepo,
 	groupRepo repository.GroupRepo,
+	sessionRepo repository.SessionRepo,
+	jwtClient jwt.JWTClient,
 	dcRedis *redis.Client,
 ) (*DiscordBot, error) {
 
@@ -41,6 +44,8 @@ func NewDiscordBot(
 			userRepo:        userRepo,
 			postRepo:        postRepo,
 			groupRepo:       groupRepo,
+			sessionRepo:     sessionRepo,
+			jwtClient:       jwtClient,
 			dcRedis:         dcRedis,
 			botSess:         botSess,
 			cmdHandlerMap:   map[string]commandHandler{},
@@ -59,6 +64,12 @@ func (b *DiscordBot) RegisterDiscordBotCommandHandler() {
 	b.handler.cmdHandlerMap["login"] = b.handler.login
 	b.handler.cmdHandlerMap["logout"] = b.handler.logout
 	b.handler.cmdHandlerMap["createPost"] = b.handler.createPost
+	b.handler.cmdHandlerMap["unfollow"] = b.handler.unfollowUser
+	b.handler.cmdHandlerMap["cancelFollowRequest"] = b.handler.cancelFollowRequest
+	b.handler.cmdHandlerMap["removeFollower"] = b.handler.removeFollower
+	b.handler.cmdHandlerMap["followRequests"] = b.handler.listFollowRequests
+	b.handler.cmdHandlerMap["approveFollowRequests"] = b.handler.approveFollowRequests
+	b.handler.cmdHandlerMap["rejectFollowRequests"] = b.handler.rejectFollowRequests
 
 	b.handler.replyHandlerMap["register"] = b.handler.handleRegisterReply
 	b.handler.replyHandlerMap["login"] = b.handler.handleLoginReply
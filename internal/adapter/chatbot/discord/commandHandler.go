@@ -2,6 +2,7 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/user/bulk_handle_follow_requests"
+	"mashu.example/internal/usecase/user/cancel_follow_request"
+	"mashu.example/internal/usecase/user/handle_follow_request"
+	"mashu.example/internal/usecase/user/list_follow_requests"
+	"mashu.example/internal/usecase/user/logout"
+	"mashu.example/internal/usecase/user/remove_follower"
+	"mashu.example/internal/usecase/user/unfollow_user"
+	"mashu.example/pkg/jwt"
 )
 
 // type of handler function for the command sent from user
@@ -33,10 +42,12 @@ type commandHandler func(cmd string, params []string, channelId string, dcUserId
 type replyHandler func(activeSessKey, channelId, dcUserId string, data map[string]string, reply string, s *discordgo.Session)
 
 type botMessageHandler struct {
-	userRepo  repository.UserRepo
-	postRepo  repository.PostRepo
-	groupRepo repository.GroupRepo
-	dcRedis   *redis.Client
+	userRepo    repository.UserRepo
+	postRepo    repository.PostRepo
+	groupRepo   repository.GroupRepo
+	sessionRepo repository.SessionRepo
+	jwtClient   jwt.JWTClient
+	dcRedis     *redis.Client
 
 	botSess *discordgo.Session
 
@@ -115,10 +126,27 @@ func (h *botMessageHandler) login(cmd string, params []string, channelId string,
 }
 
 func (h *botMessageHandler) logout(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
-	if _, _, isLogin := h.checkIsLogin(dcUserId, channelId, s, true); !isLogin {
+	userId, _, isLogin := h.checkIsLogin(dcUserId, channelId, s, true)
+	if !isLogin {
 		return
 	}
 
+	// revoke the login session
+	sessionId, err := h.dcRedis.HGet(
+		context.Background(),
+		h.getRedisLoginSessKey(dcUserId),
+		"sessionId",
+	).Result()
+	if err == nil {
+		req := logout.NewLogoutUseCaseReq(userId, uuid.MustParse(sessionId), false)
+		res := logout.NewLogoutUseCaseRes()
+		uc := logout.NewLogoutUseCase(h.sessionRepo, req, res)
+		uc.Execute()
+		if res.Err != nil {
+			logrus.Error("failed to run logout usecase: ", res.Err)
+		}
+	}
+
 	if _, err := h.dcRedis.Del(
 		context.Background(),
 		h.getRedisLoginSessKey(dcUserId),
@@ -171,6 +199,184 @@ func (h *botMessageHandler) followUser(cmd string, params []string, channelId st
 	// }
 }
 
+func (h *botMessageHandler) unfollowUser(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	h.handleFollowRelationCmd(params, channelId, dcUserId, s, func(userId, targetId uuid.UUID) (string, error) {
+		req := unfollow_user.NewUnfollowUserUseCaseReq(userId, targetId)
+		res := unfollow_user.NewUnfollowUserUseCaseRes()
+		unfollow_user.NewUnfollowUserUseCase(h.userRepo, req, res).Execute()
+
+		if errors.Is(res.Err, unfollow_user.ErrNotFollowing) {
+			return "你沒有追蹤這個使用者", nil
+		}
+		return "已取消追蹤", res.Err
+	})
+}
+
+func (h *botMessageHandler) cancelFollowRequest(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	h.handleFollowRelationCmd(params, channelId, dcUserId, s, func(userId, targetId uuid.UUID) (string, error) {
+		req := cancel_follow_request.NewCancelFollowRequestUseCaseReq(userId, targetId)
+		res := cancel_follow_request.NewCancelFollowRequestUseCaseRes()
+		cancel_follow_request.NewCancelFollowRequestUseCase(h.userRepo, req, res).Execute()
+
+		if errors.Is(res.Err, cancel_follow_request.ErrFollowRequestNotFound) {
+			return "你沒有向這個使用者送出追蹤請求", nil
+		}
+		return "已收回追蹤請求", res.Err
+	})
+}
+
+func (h *botMessageHandler) removeFollower(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	h.handleFollowRelationCmd(params, channelId, dcUserId, s, func(userId, targetId uuid.UUID) (string, error) {
+		req := remove_follower.NewRemoveFollowerUseCaseReq(userId, targetId)
+		res := remove_follower.NewRemoveFollowerUseCaseRes()
+		remove_follower.NewRemoveFollowerUseCase(h.userRepo, req, res).Execute()
+
+		if errors.Is(res.Err, remove_follower.ErrNotFollower) {
+			return "這個使用者沒有追蹤你", nil
+		}
+		return "已移除粉絲", res.Err
+	})
+}
+
+// run the use case changing the follow relation between the logged in user and
+// the user whose username is given as the first parameter, then close the thread
+//
+// - runUseCase: returns the reply sent to the user and the unexpected error
+func (h *botMessageHandler) handleFollowRelationCmd(
+	params []string,
+	channelId string,
+	dcUserId string,
+	s *discordgo.Session,
+	runUseCase func(userId, targetId uuid.UUID) (string, error),
+) {
+	defer s.ChannelEditComplex(channelId, &discordgo.ChannelEdit{
+		Archived: true,
+		Locked:   true,
+	})
+
+	userId, _, isLogin := h.checkIsLogin(dcUserId, channelId, s, true)
+	if !isLogin {
+		return
+	}
+
+	if len(params) == 0 || params[0] == "" {
+		s.ChannelMessageSend(channelId, "請在指令後面加上使用者的帳號")
+		return
+	}
+
+	target, err := h.userRepo.GetUserByUserName(params[0])
+	if err != nil {
+		logrus.Error(err)
+		s.ChannelMessageSend(channelId, "找不到這個使用者")
+		return
+	}
+
+	reply, err := runUseCase(userId, target.ID)
+	if err != nil {
+		logrus.Error(err)
+		s.ChannelMessageSend(channelId, "好像有哪裡出錯ㄌ")
+		return
+	}
+
+	s.ChannelMessageSend(channelId, reply)
+}
+
+func (h *botMessageHandler) listFollowRequests(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	defer s.ChannelEditComplex(channelId, &discordgo.ChannelEdit{
+		Archived: true,
+		Locked:   true,
+	})
+
+	userId, _, isLogin := h.checkIsLogin(dcUserId, channelId, s, true)
+	if !isLogin {
+		return
+	}
+
+	req := list_follow_requests.NewListFollowRequestsUseCaseReq(userId)
+	res := list_follow_requests.NewListFollowRequestsUseCaseRes()
+	list_follow_requests.NewListFollowRequestsUseCase(h.userRepo, req, res).Execute()
+	if res.Err != nil {
+		logrus.Error("failed to run list follow requests usecase: ", res.Err)
+		s.ChannelMessageSend(channelId, "好像有哪裡出錯ㄌ")
+		return
+	}
+
+	formatUserNames := func(dtos []list_follow_requests.FollowRequestDTO) string {
+		if len(dtos) == 0 {
+			return "沒有"
+		}
+		names := []string{}
+		for _, dto := range dtos {
+			names = append(names, fmt.Sprintf("%s (%s)", dto.UserName, dto.DisplayName))
+		}
+		return strings.Join(names, "\n")
+	}
+
+	s.ChannelMessageSendEmbed(channelId, &discordgo.MessageEmbed{
+		Title: "追蹤請求",
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "收到的請求", Value: formatUserNames(res.Incoming)},
+			{Name: "送出的請求", Value: formatUserNames(res.Outgoing)},
+		},
+	})
+}
+
+func (h *botMessageHandler) approveFollowRequests(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	h.handleFollowRequestsCmd(params, channelId, dcUserId, s, handle_follow_request.ACCEPT_FOLLOW_REQUEST)
+}
+
+func (h *botMessageHandler) rejectFollowRequests(cmd string, params []string, channelId string, dcUserId string, s *discordgo.Session, e *discordgo.MessageCreate) {
+	h.handleFollowRequestsCmd(params, channelId, dcUserId, s, handle_follow_request.REJECT_FOLLOW_REQUEST)
+}
+
+// handle the follow requests from the users whose usernames are given as the
+// parameters, all the pending requests are handled if no username is given
+func (h *botMessageHandler) handleFollowRequestsCmd(
+	params []string,
+	channelId string,
+	dcUserId string,
+	s *discordgo.Session,
+	action handle_follow_request.HandleFollowRequestAction,
+) {
+	defer s.ChannelEditComplex(channelId, &discordgo.ChannelEdit{
+		Archived: true,
+		Locked:   true,
+	})
+
+	userId, _, isLogin := h.checkIsLogin(dcUserId, channelId, s, true)
+	if !isLogin {
+		return
+	}
+
+	followerIds := []uuid.UUID{}
+	for _, username := range params {
+		if username == "" {
+			continue
+		}
+		follower, err := h.userRepo.GetUserByUserName(username)
+		if err != nil {
+			s.ChannelMessageSend(channelId, fmt.Sprintf("找不到使用者 %s", username))
+			return
+		}
+		followerIds = append(followerIds, follower.ID)
+	}
+
+	req := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseReq(userId, followerIds, action)
+	res := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseRes()
+	bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCase(h.userRepo, req, res).Execute()
+	if res.Err != nil {
+		logrus.Error("failed to run bulk handle follow requests usecase: ", res.Err)
+		s.ChannelMessageSend(channelId, "好像有哪裡出錯ㄌ")
+		return
+	}
+
+	reply := fmt.Sprintf("已處理 %d 個追蹤請求", len(res.Handled))
+	if len(res.Skipped) != 0 {
+		reply += fmt.Sprintf(", 有 %d 個使用者沒有送出追蹤請求", len(res.Skipped))
+	}
+	s.ChannelMessageSend(channelId, reply)
+}
+
 func (h *botMessageHandler) getRedisCmdSessKey(dcUserId, channelId, cmd string) string {
 	return fmt.Sprintf("sess:user:%s:%s:%s", dcUserId, channelId, cmd)
 }
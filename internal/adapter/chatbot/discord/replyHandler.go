@@ -2,6 +2,7 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -67,8 +68,8 @@ func (h *botMessageHandler) handleRegisterReply(
 ) {
 	stage := len(data)
 	ctx := context.Background()
-	stageKeys := []string{"username", "displayName", "email"}
-	stageMessages := []string{"", "請輸入使用者名稱", "請輸入email"}
+	stageKeys := []string{"username", "displayName", "email", "password"}
+	stageMessages := []string{"", "請輸入使用者名稱", "請輸入email", "請輸入密碼"}
 
 	// save reply
 	if _, err := h.dcRedis.HSet(ctx, activeSessKey, map[string]string{stageKeys[stage-1]: reply}).Result(); err != nil {
@@ -103,10 +104,15 @@ func (h *botMessageHandler) handleRegisterReply(
 		completeData["username"],
 		completeData["displayName"],
 		completeData["email"],
+		completeData["password"],
 	)
 	res := register.NewRegisterUseCaseRes()
 	uc := register.NewRegisterUseCase(h.userRepo, req, res)
 	uc.Execute()
+	if errors.Is(res.Err, register.ErrPasswordTooShort) {
+		s.ChannelMessageSend(channelId, "註冊失敗, 密碼至少要8個字元")
+		return
+	}
 	if res.Err != nil {
 		logrus.Error("failed to run register usecase: ", res.Err)
 		s.ChannelMessageSend(channelId, "註冊失敗, 好像有哪裡出錯ㄌ")
@@ -130,8 +136,8 @@ func (h *botMessageHandler) handleLoginReply(
 ) {
 	stage := len(data)
 	ctx := context.Background()
-	stageKeys := []string{"username"}
-	stageMessages := []string{""}
+	stageKeys := []string{"username", "password"}
+	stageMessages := []string{"", "請輸入密碼"}
 
 	// save reply
 	if _, err := h.dcRedis.HSet(ctx, activeSessKey, map[string]string{stageKeys[stage-1]: reply}).Result(); err != nil {
@@ -162,11 +168,19 @@ func (h *botMessageHandler) handleLoginReply(
 		return
 	}
 
-	req := login.NewLoginUseCaseReq(completeData["username"])
+	req := login.NewLoginUseCaseReq(completeData["username"], completeData["password"])
 	res := login.NewLoginUseCaseRes()
-	uc := login.NewLoginUseCase(h.userRepo, req, res)
+	uc := login.NewLoginUseCase(h.userRepo, h.sessionRepo, h.jwtClient, req, res)
 	uc.Execute()
 
+	if errors.Is(res.Err, login.ErrInvalidCredentials) {
+		s.ChannelMessageSend(channelId, "登入失敗, 帳號或密碼錯誤")
+		s.ChannelEditComplex(channelId, &discordgo.ChannelEdit{
+			Archived: true,
+			Locked:   true,
+		})
+		return
+	}
 	if res.Err != nil {
 		logrus.Error("failed to run login usecase: ", res.Err)
 		s.ChannelMessageSend(channelId, "登入失敗, 好像有哪裡出錯ㄌ")
@@ -182,7 +196,11 @@ func (h *botMessageHandler) handleLoginReply(
 	if _, err := h.dcRedis.HSet(
 		ctx,
 		h.getRedisLoginSessKey(dcUserId),
-		map[string]interface{}{"userId": user.ID.String(), "username": completeData["username"]},
+		map[string]interface{}{
+			"userId":    user.ID.String(),
+			"username":  completeData["username"],
+			"sessionId": res.SessionId.String(),
+		},
 	).Result(); err != nil {
 		logrus.Error("failed to save login status: ", err)
 		s.ChannelMessageSend(channelId, "登入失敗, 好像有哪裡出錯ㄌ")
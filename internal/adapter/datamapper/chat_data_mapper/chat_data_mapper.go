@@ -0,0 +1,275 @@
+package chat_data_mapper
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/adapter/datamapper/user_data_mapper"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+)
+
+type DirectMessageDataMapper struct {
+	ID uuid.UUID `gorm:"primaryKey;column:id"`
+
+	CreatorId  uuid.UUID                        `gorm:"column:creator_id;index"`
+	Creator    *user_data_mapper.UserDataMapper `gorm:"foreignKey:CreatorId"`
+	ReceiverId uuid.UUID                        `gorm:"column:receiver_id;index"`
+	Receiver   *user_data_mapper.UserDataMapper `gorm:"foreignKey:ReceiverId"`
+
+	Messages  []*MessageDataMapper `gorm:"foreignKey:DMId"`
+	CreatedAt time.Time            `gorm:"column:created_at"`
+}
+
+func (DirectMessageDataMapper) TableName() string {
+	return "direct_messages"
+}
+
+func (dm DirectMessageDataMapper) ToDirectMessage() *chat.DirectMessage {
+	messages := []*chat.Message{}
+	for _, message := range dm.Messages {
+		messages = append(messages, message.ToMessage())
+	}
+
+	return &chat.DirectMessage{
+		ID:        dm.ID,
+		Creator:   dm.Creator.ToUser(),
+		Receiver:  dm.Receiver.ToUser(),
+		Messages:  messages,
+		CreatedAt: dm.CreatedAt,
+	}
+}
+
+// the creator and receiver are referenced by id only, the users are not saved along
+// with the direct message
+func NewDirectMessageDataMapper(dm *chat.DirectMessage) *DirectMessageDataMapper {
+	messages := []*MessageDataMapper{}
+	for _, message := range dm.Messages {
+		messages = append(messages, NewMessageDataMapper(dm.ID, message))
+	}
+
+	return &DirectMessageDataMapper{
+		ID:         dm.ID,
+		CreatorId:  dm.Creator.ID,
+		ReceiverId: dm.Receiver.ID,
+		Messages:   messages,
+		CreatedAt:  dm.CreatedAt,
+	}
+}
+
+type MessageDataMapper struct {
+	ID        uuid.UUID  `gorm:"primaryKey;column:id"`
+	DMId      uuid.UUID  `gorm:"column:dm_id;index:idx_messages_dm_timestamp,priority:1"`
+	OwnerId   uuid.UUID  `gorm:"column:owner_id"`
+	Content   string     `gorm:"column:content"`
+	Timestamp time.Time  `gorm:"column:timestamp;index:idx_messages_dm_timestamp,priority:2"`
+	EditedAt  *time.Time `gorm:"column:edited_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at"`
+
+	// the reactions are saved by `AddReaction` and `RemoveReaction` only
+	Reactions []*ReactionDataMapper `gorm:"foreignKey:MessageId"`
+}
+
+func (MessageDataMapper) TableName() string {
+	return "messages"
+}
+
+func (m MessageDataMapper) ToMessage() *chat.Message {
+	message := chat.NewMessageWithTime(m.ID, m.OwnerId, m.Content, m.Timestamp)
+	if m.EditedAt != nil {
+		message.EditedAt = *m.EditedAt
+	}
+	if m.DeletedAt != nil {
+		message.DeletedAt = *m.DeletedAt
+	}
+	for _, reaction := range m.Reactions {
+		message.AddReaction(reaction.UserId, reaction.Emoji)
+	}
+
+	return message
+}
+
+func NewMessageDataMapper(dmId uuid.UUID, message *chat.Message) *MessageDataMapper {
+	messageData := &MessageDataMapper{
+		ID:        message.ID,
+		DMId:      dmId,
+		OwnerId:   message.OwnerId,
+		Content:   message.Content,
+		Timestamp: message.Timestamp,
+	}
+	if message.IsEdited() {
+		messageData.EditedAt = &message.EditedAt
+	}
+	if message.IsDeleted() {
+		messageData.DeletedAt = &message.DeletedAt
+	}
+
+	return messageData
+}
+
+type ReactionDataMapper struct {
+	MessageId uuid.UUID `gorm:"primaryKey;column:message_id"`
+	UserId    uuid.UUID `gorm:"primaryKey;column:user_id"`
+	Emoji     string    `gorm:"primaryKey;column:emoji"`
+	DMId      uuid.UUID `gorm:"column:dm_id"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (ReactionDataMapper) TableName() string {
+	return "reactions"
+}
+
+type UnreadCountDataMapper struct {
+	UserId uuid.UUID `gorm:"primaryKey;column:user_id"`
+	DMId   uuid.UUID `gorm:"primaryKey;column:dm_id"`
+	Count  int       `gorm:"column:unread_count"`
+}
+
+func (UnreadCountDataMapper) TableName() string {
+	return "unread_counts"
+}
+
+// the content of the pending message is kept in the messages table
+type PendingMessageDataMapper struct {
+	UserId    uuid.UUID          `gorm:"primaryKey;column:user_id;index:idx_pending_messages_user_timestamp,priority:1"`
+	MessageId uuid.UUID          `gorm:"primaryKey;column:message_id"`
+	Message   *MessageDataMapper `gorm:"foreignKey:MessageId"`
+	DMId      uuid.UUID          `gorm:"column:dm_id"`
+	Timestamp time.Time          `gorm:"column:timestamp;index:idx_pending_messages_user_timestamp,priority:2"`
+}
+
+func (PendingMessageDataMapper) TableName() string {
+	return "pending_messages"
+}
+
+func (pm PendingMessageDataMapper) ToPendingMessage() *chat.PendingMessage {
+	return chat.NewPendingMessage(pm.DMId, pm.Message.ToMessage())
+}
+
+func NewPendingMessageDataMapper(userId uuid.UUID, pending *chat.PendingMessage) *PendingMessageDataMapper {
+	return &PendingMessageDataMapper{
+		UserId:    userId,
+		MessageId: pending.Message.ID,
+		DMId:      pending.DMId,
+		Timestamp: pending.Message.Timestamp,
+	}
+}
+
+// the latest receipt of each participant and status
+type ReceiptDataMapper struct {
+	DMId             uuid.UUID                  `gorm:"primaryKey;column:dm_id"`
+	UserId           uuid.UUID                  `gorm:"primaryKey;column:user_id"`
+	Status           entity_enums.MessageStatus `gorm:"primaryKey;column:status"`
+	MessageId        uuid.UUID                  `gorm:"column:message_id"`
+	MessageTimestamp time.Time                  `gorm:"column:message_timestamp"`
+	CreatedAt        time.Time                  `gorm:"column:created_at"`
+}
+
+func (ReceiptDataMapper) TableName() string {
+	return "receipts"
+}
+
+func (r ReceiptDataMapper) ToReceipt() *chat.Receipt {
+	return &chat.Receipt{
+		DMId:             r.DMId,
+		UserId:           r.UserId,
+		MessageId:        r.MessageId,
+		MessageTimestamp: r.MessageTimestamp,
+		Status:           r.Status,
+		CreatedAt:        r.CreatedAt,
+	}
+}
+
+func NewReceiptDataMapper(receipt *chat.Receipt) *ReceiptDataMapper {
+	return &ReceiptDataMapper{
+		DMId:             receipt.DMId,
+		UserId:           receipt.UserId,
+		Status:           receipt.Status,
+		MessageId:        receipt.MessageId,
+		MessageTimestamp: receipt.MessageTimestamp,
+		CreatedAt:        receipt.CreatedAt,
+	}
+}
+
+type GroupChatDataMapper struct {
+	ID        uuid.UUID                 `gorm:"primaryKey;column:id"`
+	GroupId   uuid.UUID                 `gorm:"column:group_id;uniqueIndex"`
+	Messages  []*GroupMessageDataMapper `gorm:"foreignKey:GroupChatId"`
+	CreatedAt time.Time                 `gorm:"column:created_at"`
+}
+
+func (GroupChatDataMapper) TableName() string {
+	return "group_chats"
+}
+
+func (gc GroupChatDataMapper) ToGroupChat() *chat.GroupChat {
+	messages := []*chat.Message{}
+	for _, message := range gc.Messages {
+		messages = append(messages, message.ToMessage())
+	}
+
+	return &chat.GroupChat{
+		ID:        gc.ID,
+		GroupId:   gc.GroupId,
+		Messages:  messages,
+		CreatedAt: gc.CreatedAt,
+	}
+}
+
+func NewGroupChatDataMapper(groupChat *chat.GroupChat) *GroupChatDataMapper {
+	messages := []*GroupMessageDataMapper{}
+	for _, message := range groupChat.Messages {
+		messages = append(messages, NewGroupMessageDataMapper(groupChat.ID, message))
+	}
+
+	return &GroupChatDataMapper{
+		ID:        groupChat.ID,
+		GroupId:   groupChat.GroupId,
+		Messages:  messages,
+		CreatedAt: groupChat.CreatedAt,
+	}
+}
+
+type GroupMessageDataMapper struct {
+	ID          uuid.UUID  `gorm:"primaryKey;column:id"`
+	GroupChatId uuid.UUID  `gorm:"column:group_chat_id;index:idx_group_messages_chat_timestamp,priority:1"`
+	OwnerId     uuid.UUID  `gorm:"column:owner_id"`
+	Content     string     `gorm:"column:content"`
+	Timestamp   time.Time  `gorm:"column:timestamp;index:idx_group_messages_chat_timestamp,priority:2"`
+	EditedAt    *time.Time `gorm:"column:edited_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at"`
+}
+
+func (GroupMessageDataMapper) TableName() string {
+	return "group_messages"
+}
+
+func (m GroupMessageDataMapper) ToMessage() *chat.Message {
+	message := chat.NewMessageWithTime(m.ID, m.OwnerId, m.Content, m.Timestamp)
+	if m.EditedAt != nil {
+		message.EditedAt = *m.EditedAt
+	}
+	if m.DeletedAt != nil {
+		message.DeletedAt = *m.DeletedAt
+	}
+
+	return message
+}
+
+func NewGroupMessageDataMapper(groupChatId uuid.UUID, message *chat.Message) *GroupMessageDataMapper {
+	messageData := &GroupMessageDataMapper{
+		ID:          message.ID,
+		GroupChatId: groupChatId,
+		OwnerId:     message.OwnerId,
+		Content:     message.Content,
+		Timestamp:   message.Timestamp,
+	}
+	if message.IsEdited() {
+		messageData.EditedAt = &message.EditedAt
+	}
+	if message.IsDeleted() {
+		messageData.DeletedAt = &message.DeletedAt
+	}
+
+	return messageData
+}
@@ -11,14 +11,14 @@ import (
 
 type GroupDataMapper struct {
 	ID   uuid.UUID `gorm:"primaryKey"`
-	Name string    `gorm:"column:name"`
+	Name string    `gorm:"column:name;unique"`
 
 	OwnerId uuid.UUID
-	Owner   *user_data_mapper.UserDataMapper `gorm:"foreighKey:OwnerId"`
+	Owner   *user_data_mapper.UserDataMapper `gorm:"foreignKey:OwnerId"`
 
 	Permission entity_enums.GroupPrivacy `gorm:"column:permission"`
-	Admins     []*user_data_mapper.UserDataMapper
-	Members    []JoinDataMapper
+	Admins     []*GroupAdminDataMapper   `gorm:"foreignKey:GroupId"`
+	Joins      []*JoinDataMapper         `gorm:"foreignKey:GroupId"` // members, join requests and invitations
 	CreatedAt  time.Time
 }
 
@@ -27,46 +27,136 @@ func (GroupDataMapper) TableName() string {
 }
 
 func (g GroupDataMapper) ToGroup() *entity.Group {
-	return &entity.Group{
-		ID:         g.ID,
-		Name:       g.Name,
-		Owner:      g.Owner.ToUser(),
-		Permission: g.Permission,
-		CreatedAt:  g.CreatedAt,
+	group := &entity.Group{
+		ID:             g.ID,
+		Name:           g.Name,
+		Owner:          g.Owner.ToUser(),
+		Permission:     g.Permission,
+		CreatedAt:      g.CreatedAt,
+		Admins:         []*entity.GroupAdmin{},
+		Members:        []*entity.GroupMember{},
+		JoinRequests:   []*entity.JoinRequest{},
+		InviteRequests: []*entity.InviteRequest{},
 	}
+
+	for _, admin := range g.Admins {
+		group.Admins = append(group.Admins, &entity.GroupAdmin{
+			UserId:     admin.UserId,
+			PromotedBy: admin.PromotedBy,
+			PromotedAt: admin.PromotedAt,
+		})
+	}
+
+	for _, join := range g.Joins {
+		switch join.Status {
+		case JOINING:
+			group.Members = append(group.Members, &entity.GroupMember{
+				UserId:     join.UserId,
+				InvitedBy:  join.InvitedBy,
+				ApprovedBy: join.ApprovedBy,
+				JoinAt:     join.CreatedAt,
+			})
+		case REQUESTED:
+			group.JoinRequests = append(group.JoinRequests, &entity.JoinRequest{
+				Requester:   join.UserId,
+				RequestedAt: join.CreatedAt,
+			})
+		case INVITED:
+			group.InviteRequests = append(group.InviteRequests, &entity.InviteRequest{
+				Invitee:   join.UserId,
+				Inviter:   join.InvitedBy,
+				InvitedAt: join.CreatedAt,
+			})
+		}
+	}
+
+	return group
 }
 
 func NewGroupDataMapper(group *entity.Group) *GroupDataMapper {
+	admins := []*GroupAdminDataMapper{}
+	for _, admin := range group.Admins {
+		admins = append(admins, NewGroupAdminDataMapper(group.ID, admin))
+	}
+
+	joins := []*JoinDataMapper{}
+	for _, member := range group.Members {
+		joins = append(joins, &JoinDataMapper{
+			GroupId:    group.ID,
+			UserId:     member.UserId,
+			Status:     JOINING,
+			InvitedBy:  member.InvitedBy,
+			ApprovedBy: member.ApprovedBy,
+			CreatedAt:  member.JoinAt,
+		})
+	}
+	for _, joinRequest := range group.JoinRequests {
+		joins = append(joins, &JoinDataMapper{
+			GroupId:   group.ID,
+			UserId:    joinRequest.Requester,
+			Status:    REQUESTED,
+			CreatedAt: joinRequest.RequestedAt,
+		})
+	}
+	for _, invitation := range group.InviteRequests {
+		joins = append(joins, &JoinDataMapper{
+			GroupId:   group.ID,
+			UserId:    invitation.Invitee,
+			Status:    INVITED,
+			InvitedBy: invitation.Inviter,
+			CreatedAt: invitation.InvitedAt,
+		})
+	}
+
 	return &GroupDataMapper{
 		ID:         group.ID,
 		OwnerId:    group.Owner.ID,
+		Owner:      user_data_mapper.NewUserDataMapper(group.Owner),
 		Name:       group.Name,
 		Permission: group.Permission,
+		Admins:     admins,
+		Joins:      joins,
 		CreatedAt:  group.CreatedAt,
 	}
 }
 
+type GroupAdminDataMapper struct {
+	GroupId    uuid.UUID `gorm:"primaryKey;column:group_id"`
+	UserId     uuid.UUID `gorm:"primaryKey;column:user_id"`
+	PromotedBy uuid.UUID `gorm:"column:promoted_by"`
+	PromotedAt time.Time `gorm:"column:promoted_at"`
+}
+
+func (GroupAdminDataMapper) TableName() string {
+	return "group_admins"
+}
+
+func NewGroupAdminDataMapper(groupId uuid.UUID, admin *entity.GroupAdmin) *GroupAdminDataMapper {
+	return &GroupAdminDataMapper{groupId, admin.UserId, admin.PromotedBy, admin.PromotedAt}
+}
+
 type JoinStatus string
 
+// REQUESTED - the user sent the join request
+// INVITED - the user is invited by the group member
+// JOINING - the user is a member of the group
 const (
 	REQUESTED JoinStatus = "REQUESTED"
-	JOINONG   JoinStatus = "JOINING"
+	INVITED   JoinStatus = "INVITED"
+	JOINING   JoinStatus = "JOINING"
 )
 
+// the user may be invited while the join request is pending, so the status is
+// a part of the primary key
 type JoinDataMapper struct {
-	Group  uuid.UUID
-	User   uuid.UUID
-	Status JoinStatus
+	GroupId    uuid.UUID  `gorm:"primaryKey;column:group_id"`
+	UserId     uuid.UUID  `gorm:"primaryKey;column:user_id"`
+	Status     JoinStatus `gorm:"primaryKey;column:status"`
+	InvitedBy  uuid.UUID  `gorm:"column:invited_by"`  // uuid.Nil if not invited
+	ApprovedBy uuid.UUID  `gorm:"column:approved_by"` // uuid.Nil if not joining yet
+	CreatedAt  time.Time  `gorm:"column:created_at"`
 }
 
 func (JoinDataMapper) TableName() string {
 	return "joins"
 }
-
-func NewJoinDataMapper(
-	groupId uuid.UUID,
-	userId uuid.UUID,
-	status JoinStatus,
-) *JoinDataMapper {
-	return &JoinDataMapper{groupId, userId, status}
-}
@@ -3,6 +3,7 @@ package post_data_mapper
 import (
 	"time"
 
+	"mashu.example/internal/adapter/datamapper/group_data_mapper"
 	"mashu.example/internal/adapter/datamapper/user_data_mapper"
 	entity_enums "mashu.example/internal/entity/enums"
 
@@ -27,11 +28,16 @@ func (CommentDataMapper) TableName() string {
 	return "comments"
 }
 
-func (c CommentDataMapper) ToComment() *entity.Comment {
+// post is the post which the comment belongs to, nil to convert from the preloaded `Post`
+func (c CommentDataMapper) ToComment(post *entity.Post) *entity.Comment {
+	if post == nil && c.Post != nil {
+		post = c.Post.ToPost()
+	}
+
 	return &entity.Comment{
 		ID:        c.ID,
 		Owner:     c.Owner.ToUser(),
-		Post:      c.Post.ToPost(),
+		Post:      post,
 		Content:   c.Content,
 		CreatedAt: c.CreatedAt,
 	}
@@ -41,6 +47,7 @@ func NewCommentDataMapper(comment *entity.Comment) *CommentDataMapper {
 	return &CommentDataMapper{
 		ID:        comment.ID,
 		OwnerId:   comment.Owner.ID,
+		Owner:     user_data_mapper.NewUserDataMapper(comment.Owner),
 		PostId:    comment.Post.ID,
 		Content:   comment.Content,
 		CreatedAt: comment.CreatedAt,
@@ -56,8 +63,13 @@ type PostDataMapper struct {
 	OwnerId uuid.UUID
 	Owner   *user_data_mapper.UserDataMapper `gorm:"foreignKey:OwnerId"`
 
-	Comments []*CommentDataMapper `gorm:"foreignKey:PostId"`
-	CreateAt time.Time            `gorm:"column:created_at"`
+	// nil if not belonging to any group, the group is only loaded by preloading
+	GroupId *uuid.UUID                         `gorm:"column:group_id;index"`
+	Group   *group_data_mapper.GroupDataMapper `gorm:"foreignKey:GroupId"`
+
+	Comments  []*CommentDataMapper `gorm:"foreignKey:PostId"`
+	CreateAt  time.Time            `gorm:"column:created_at"`
+	UpdatedAt time.Time            `gorm:"column:updated_at"`
 }
 
 func (PostDataMapper) TableName() string {
@@ -65,13 +77,20 @@ func (PostDataMapper) TableName() string {
 }
 
 func (p PostDataMapper) ToPost() *entity.Post {
-	return &entity.Post{
-		ID:         p.ID,
-		Title:      p.Title,
-		Content:    p.Content,
-		Owner:      p.Owner.ToUser(),
-		Permission: p.Permission,
+	// the group of the post is readonly, so it can only be set by the constructor
+	var group *entity.Group = nil
+	if p.Group != nil {
+		group = p.Group.ToGroup()
+	}
+	post := entity.NewPost(p.ID, p.Title, p.Content, p.Owner.ToUser(), group, p.Permission)
+	post.CreatedAt = p.CreateAt
+	post.UpdatedAt = p.UpdatedAt
+
+	for _, comment := range p.Comments {
+		post.Comments = append(post.Comments, comment.ToComment(post))
 	}
+
+	return post
 }
 
 func NewPostDataMapper(post *entity.Post) *PostDataMapper {
@@ -80,6 +99,11 @@ func NewPostDataMapper(post *entity.Post) *PostDataMapper {
 		comments = append(comments, NewCommentDataMapper(comment))
 	}
 
+	var groupId *uuid.UUID = nil
+	if post.Group() != nil {
+		groupId = &post.Group().ID
+	}
+
 	return &PostDataMapper{
 		ID:         post.ID,
 		Title:      post.Title,
@@ -87,7 +111,9 @@ func NewPostDataMapper(post *entity.Post) *PostDataMapper {
 		Permission: post.Permission,
 		OwnerId:    post.Owner.ID,
 		Owner:      user_data_mapper.NewUserDataMapper(post.Owner),
+		GroupId:    groupId,
 		Comments:   comments,
 		CreateAt:   post.CreatedAt,
+		UpdatedAt:  post.UpdatedAt,
 	}
 }
@@ -0,0 +1,43 @@
+package session_data_mapper
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/entity"
+)
+
+type SessionDataMapper struct {
+	ID               uuid.UUID  `gorm:"primaryKey;column:id"`
+	UserId           uuid.UUID  `gorm:"column:user_id;index"`
+	RefreshTokenHash string     `gorm:"column:refresh_token_hash;uniqueIndex"`
+	ExpiredAt        time.Time  `gorm:"column:expired_at"`
+	RevokedAt        *time.Time `gorm:"column:revoked_at"`
+	CreatedAt        time.Time  `gorm:"column:created_at"`
+}
+
+func (SessionDataMapper) TableName() string {
+	return "sessions"
+}
+
+func (s SessionDataMapper) ToSession() *entity.Session {
+	return &entity.Session{
+		ID:               s.ID,
+		UserId:           s.UserId,
+		RefreshTokenHash: s.RefreshTokenHash,
+		ExpiredAt:        s.ExpiredAt,
+		RevokedAt:        s.RevokedAt,
+		CreatedAt:        s.CreatedAt,
+	}
+}
+
+func NewSessionDataMapper(session *entity.Session) *SessionDataMapper {
+	return &SessionDataMapper{
+		ID:               session.ID,
+		UserId:           session.UserId,
+		RefreshTokenHash: session.RefreshTokenHash,
+		ExpiredAt:        session.ExpiredAt,
+		RevokedAt:        session.RevokedAt,
+		CreatedAt:        session.CreatedAt,
+	}
+}
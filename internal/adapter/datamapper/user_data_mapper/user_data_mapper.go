@@ -1,6 +1,8 @@
 package user_data_mapper
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"mashu.example/internal/entity"
 )
@@ -10,8 +12,16 @@ type UserDataMapper struct {
 	UserName    string             `gorm:"column:name;unique" json:"username"`
 	DisplayName string             `gorm:"column:display_name" json:"displayName"`
 	Email       string             `gorm:"column:email" json:"email"`
+	Password    string             `gorm:"column:password" json:"-"`
 	Public      bool               `gorm:"column:public" json:"public"`
+	Bio         string             `gorm:"column:bio" json:"bio"`
+	AvatarURL   string             `gorm:"column:avatar_url" json:"avatarUrl"`
+	Location    string             `gorm:"column:location" json:"location"`
+	Website     string             `gorm:"column:website" json:"website"`
+	Birthday    *time.Time         `gorm:"column:birthday" json:"birthday"`
 	Follows     []FollowDataMapper `gorm:"foreignKey:user_id,follower_id;references:id,id" json:"-"`
+	Blocks      []BlockDataMapper  `gorm:"-" json:"-"`
+	Mutes       []MuteDataMapper   `gorm:"-" json:"-"`
 }
 
 func (UserDataMapper) TableName() string {
@@ -35,20 +45,53 @@ func (u UserDataMapper) ToUser() *entity.User {
 		}
 	}
 
+	blockedUsers := []uuid.UUID{}
+	for _, block := range u.Blocks {
+		blockedUsers = append(blockedUsers, block.Blocked)
+	}
+
+	mutedUsers := []uuid.UUID{}
+	for _, mute := range u.Mutes {
+		mutedUsers = append(mutedUsers, mute.Muted)
+	}
+
 	return &entity.User{
 		ID:             u.ID,
 		UserName:       u.UserName,
 		DisplayName:    u.DisplayName,
 		Email:          u.Email,
+		Password:       u.Password,
 		Public:         u.Public,
+		Bio:            u.Bio,
+		AvatarURL:      u.AvatarURL,
+		Location:       u.Location,
+		Website:        u.Website,
+		Birthday:       u.Birthday,
 		FollowRequests: followReqs,
 		Followers:      followers,
 		Followings:     followings,
+		BlockedUsers:   blockedUsers,
+		MutedUsers:     mutedUsers,
 	}
 }
 
 func NewUserDataMapper(user *entity.User) *UserDataMapper {
-	return &UserDataMapper{user.ID, user.UserName, user.DisplayName, user.Email, user.Public, []FollowDataMapper{}}
+	return &UserDataMapper{
+		ID:          user.ID,
+		UserName:    user.UserName,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Password:    user.Password,
+		Public:      user.Public,
+		Bio:         user.Bio,
+		AvatarURL:   user.AvatarURL,
+		Location:    user.Location,
+		Website:     user.Website,
+		Birthday:    user.Birthday,
+		Follows:     []FollowDataMapper{},
+		Blocks:      []BlockDataMapper{},
+		Mutes:       []MuteDataMapper{},
+	}
 }
 
 type FollowStatus string
@@ -59,9 +102,10 @@ const (
 )
 
 type FollowDataMapper struct {
-	User     uuid.UUID `gorm:"primaryKey;column:user_id;"`
-	Follower uuid.UUID `gorm:"primaryKey;column:follower_id;"`
-	Status   FollowStatus
+	User      uuid.UUID `gorm:"primaryKey;column:user_id;"`
+	Follower  uuid.UUID `gorm:"primaryKey;column:follower_id;"`
+	Status    FollowStatus
+	CreatedAt time.Time `gorm:"column:created_at;index"` // the time of following or requesting
 }
 
 func (FollowDataMapper) TableName() string {
@@ -73,5 +117,31 @@ func NewFollowDataMapper(
 	followerId uuid.UUID,
 	status FollowStatus,
 ) *FollowDataMapper {
-	return &FollowDataMapper{userId, followerId, status}
+	return &FollowDataMapper{User: userId, Follower: followerId, Status: status}
+}
+
+type BlockDataMapper struct {
+	User    uuid.UUID `gorm:"primaryKey;column:user_id;"`
+	Blocked uuid.UUID `gorm:"primaryKey;column:blocked_id;"`
+}
+
+func (BlockDataMapper) TableName() string {
+	return "blocks"
+}
+
+func NewBlockDataMapper(userId, blockedId uuid.UUID) *BlockDataMapper {
+	return &BlockDataMapper{userId, blockedId}
+}
+
+type MuteDataMapper struct {
+	User  uuid.UUID `gorm:"primaryKey;column:user_id;"`
+	Muted uuid.UUID `gorm:"primaryKey;column:muted_id;"`
+}
+
+func (MuteDataMapper) TableName() string {
+	return "mutes"
+}
+
+func NewMuteDataMapper(userId, mutedId uuid.UUID) *MuteDataMapper {
+	return &MuteDataMapper{userId, mutedId}
 }
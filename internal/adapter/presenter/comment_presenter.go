@@ -0,0 +1,62 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/usecase/types"
+)
+
+type CommentListPresenter struct {
+	comments []*types.CommentInfo
+	total    int64
+	offset   int
+	limit    int
+}
+
+type CommentViewModel struct {
+	ID        uuid.UUID `json:"id"`
+	PostId    uuid.UUID `json:"postId"`
+	OwnerId   uuid.UUID `json:"ownerId"`
+	OwnerName string    `json:"ownerName"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CommentListViewModel struct {
+	Comments []CommentViewModel `json:"comments"`
+	Total    int64              `json:"total"`
+	Offset   int                `json:"offset"`
+	Limit    int                `json:"limit"`
+}
+
+func (clp *CommentListPresenter) BuildViewModel() CommentListViewModel {
+	clvm := CommentListViewModel{
+		Comments: []CommentViewModel{},
+		Total:    clp.total,
+		Offset:   clp.offset,
+		Limit:    clp.limit,
+	}
+	for _, comment := range clp.comments {
+		clvm.Comments = append(clvm.Comments, CommentViewModel{
+			ID:        comment.ID,
+			PostId:    comment.PostId,
+			OwnerId:   comment.OwnerId,
+			OwnerName: comment.OwnerName,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+
+	return clvm
+}
+
+// constructor of comment list presenter
+func NewCommentListPresenter(
+	comments []*types.CommentInfo,
+	total int64,
+	offset int,
+	limit int,
+) Presenter[CommentListViewModel] {
+	return &CommentListPresenter{comments, total, offset, limit}
+}
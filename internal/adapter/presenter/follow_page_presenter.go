@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/usecase/types"
+)
+
+type FollowPagePresenter struct {
+	users      []*types.FollowingInfo
+	total      int64
+	nextCursor string
+}
+
+// the email is not exposed to the other users
+type FollowUserViewModel struct {
+	ID          uuid.UUID `json:"id"`
+	UserName    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	Public      bool      `json:"public"`
+	FollowedAt  time.Time `json:"followedAt"`
+}
+
+type FollowPageViewModel struct {
+	Users []FollowUserViewModel `json:"users"`
+	Total int64                 `json:"total"`
+	// cursor of the next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func (fpp *FollowPagePresenter) BuildViewModel() FollowPageViewModel {
+	fpvm := FollowPageViewModel{
+		Users:      []FollowUserViewModel{},
+		Total:      fpp.total,
+		NextCursor: fpp.nextCursor,
+	}
+	for _, user := range fpp.users {
+		fpvm.Users = append(fpvm.Users, FollowUserViewModel{
+			ID:          user.ID,
+			UserName:    user.UserName,
+			DisplayName: user.DisplayName,
+			Public:      user.Public,
+			FollowedAt:  user.FollowedAt,
+		})
+	}
+
+	return fpvm
+}
+
+// constructor of the presenter for a page of followers or following users
+func NewFollowPagePresenter(users []*types.FollowingInfo, total int64, nextCursor string) Presenter[FollowPageViewModel] {
+	return &FollowPagePresenter{users, total, nextCursor}
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/user/list_follow_requests"
+)
+
+type FollowRequestsPresenter struct {
+	res *uc.ListFollowRequestsUseCaseRes
+}
+
+type FollowRequestViewModel struct {
+	UserId      uuid.UUID `json:"userId"`
+	UserName    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	Public      bool      `json:"public"`
+}
+
+type FollowRequestsViewModel struct {
+	Incoming []FollowRequestViewModel `json:"incoming"`
+	Outgoing []FollowRequestViewModel `json:"outgoing"`
+}
+
+func (frp *FollowRequestsPresenter) BuildViewModel() FollowRequestsViewModel {
+	return FollowRequestsViewModel{
+		Incoming: newFollowRequestViewModels(frp.res.Incoming),
+		Outgoing: newFollowRequestViewModels(frp.res.Outgoing),
+	}
+}
+
+func newFollowRequestViewModels(dtos []uc.FollowRequestDTO) []FollowRequestViewModel {
+	vms := []FollowRequestViewModel{}
+	for _, dto := range dtos {
+		vms = append(vms, FollowRequestViewModel{
+			UserId:      dto.UserId,
+			UserName:    dto.UserName,
+			DisplayName: dto.DisplayName,
+			Public:      dto.Public,
+		})
+	}
+
+	return vms
+}
+
+// constructor of follow requests presenter
+func NewFollowRequestsPresenter(res *uc.ListFollowRequestsUseCaseRes) Presenter[FollowRequestsViewModel] {
+	return &FollowRequestsPresenter{res}
+}
@@ -0,0 +1,111 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/types"
+)
+
+type GroupPresenter struct {
+	group *types.GroupInfo
+}
+
+type GroupMemberViewModel struct {
+	UserId     uuid.UUID  `json:"userId"`
+	InvitedBy  *uuid.UUID `json:"invitedBy"` // null if the member sent the join request
+	ApprovedBy uuid.UUID  `json:"approvedBy"`
+	JoinAt     time.Time  `json:"joinAt"`
+}
+
+type GroupAdminViewModel struct {
+	UserId     uuid.UUID `json:"userId"`
+	PromotedBy uuid.UUID `json:"promotedBy"`
+	PromotedAt time.Time `json:"promotedAt"`
+}
+
+type GroupInvitationViewModel struct {
+	Invitee uuid.UUID `json:"invitee"`
+	Inviter uuid.UUID `json:"inviter"`
+}
+
+// the fields which the viewer is not allowed to see are omitted
+type GroupViewModel struct {
+	ID           uuid.UUID                  `json:"id"`
+	Name         string                     `json:"name"`
+	OwnerId      uuid.UUID                  `json:"ownerId"`
+	OwnerName    string                     `json:"ownerName"`
+	Permission   entity_enums.GroupPrivacy  `json:"permission"`
+	CreatedAt    time.Time                  `json:"createdAt"`
+	Members      []GroupMemberViewModel     `json:"members,omitempty"`
+	Admins       []GroupAdminViewModel      `json:"admins,omitempty"`
+	JoinRequests []uuid.UUID                `json:"joinRequests,omitempty"`
+	Invitations  []GroupInvitationViewModel `json:"invitations,omitempty"`
+}
+
+func (gp *GroupPresenter) BuildViewModel() GroupViewModel {
+	gvm := GroupViewModel{
+		ID:           gp.group.ID,
+		Name:         gp.group.Name,
+		OwnerId:      gp.group.OwnerId,
+		OwnerName:    gp.group.OwnerName,
+		Permission:   gp.group.Permission,
+		CreatedAt:    gp.group.CreatedAt,
+		JoinRequests: gp.group.JoinRequests,
+	}
+
+	if gp.group.Members != nil {
+		gvm.Members = NewGroupMembersPresenter(gp.group.Members).BuildViewModel()
+	}
+
+	for _, admin := range gp.group.Admins {
+		gvm.Admins = append(gvm.Admins, GroupAdminViewModel{
+			UserId:     admin.UserId,
+			PromotedBy: admin.PromotedBy,
+			PromotedAt: admin.PromotedAt,
+		})
+	}
+
+	for _, invitation := range gp.group.Invitations {
+		gvm.Invitations = append(gvm.Invitations, GroupInvitationViewModel{
+			Invitee: invitation.Invitee,
+			Inviter: invitation.Inviter,
+		})
+	}
+
+	return gvm
+}
+
+// constructor of group presenter
+func NewGroupPresenter(group *types.GroupInfo) Presenter[GroupViewModel] {
+	return &GroupPresenter{group}
+}
+
+type GroupMembersPresenter struct {
+	members []*types.GroupMemberInfo
+}
+
+func (gmp *GroupMembersPresenter) BuildViewModel() []GroupMemberViewModel {
+	members := []GroupMemberViewModel{}
+	for _, member := range gmp.members {
+		var invitedBy *uuid.UUID = nil
+		if member.InvitedBy != uuid.Nil {
+			invitedBy = &member.InvitedBy
+		}
+
+		members = append(members, GroupMemberViewModel{
+			UserId:     member.UserId,
+			InvitedBy:  invitedBy,
+			ApprovedBy: member.ApprovedBy,
+			JoinAt:     member.JoinAt,
+		})
+	}
+
+	return members
+}
+
+// constructor of group members presenter
+func NewGroupMembersPresenter(members []*types.GroupMemberInfo) Presenter[[]GroupMemberViewModel] {
+	return &GroupMembersPresenter{members}
+}
@@ -0,0 +1,44 @@
+package presenter
+
+import (
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/chat/load_group_messages"
+)
+
+type GroupMessageHistoryPresenter struct {
+	res *uc.LoadGroupMessagesUseCaseRes
+}
+
+type GroupMessageHistoryViewModel struct {
+	GroupChatId uuid.UUID
+	// the items share the shape of the direct messages, without the status
+	// since the group messages have no receipts
+	Messages []MessageHistoryItemViewModel
+}
+
+func (gmhp *GroupMessageHistoryPresenter) BuildViewModel() GroupMessageHistoryViewModel {
+	gmhvm := GroupMessageHistoryViewModel{
+		GroupChatId: gmhp.res.GroupChatId,
+		Messages:    []MessageHistoryItemViewModel{},
+	}
+	for _, message := range gmhp.res.Messages {
+		gmhvm.Messages = append(gmhvm.Messages, MessageHistoryItemViewModel{
+			ID:        message.ID,
+			OwnerId:   message.OwnerId,
+			Content:   message.Content,
+			Timestamp: message.Timestamp,
+			EditedAt:  optionalTime(message.EditedAt),
+			DeletedAt: optionalTime(message.DeletedAt),
+			Reactions: buildReactionSummary(message.Reactions),
+		})
+	}
+
+	return gmhvm
+}
+
+// constructor of group message history presenter
+func NewGroupMessageHistoryPresenter(
+	res *uc.LoadGroupMessagesUseCaseRes,
+) Presenter[GroupMessageHistoryViewModel] {
+	return &GroupMessageHistoryPresenter{res}
+}
@@ -1,18 +1,106 @@
 package presenter
 
-import uc "mashu.example/internal/usecase/chat/load_message_history"
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	entity_enums "mashu.example/internal/entity/enums"
+	uc "mashu.example/internal/usecase/chat/load_message_history"
+)
+
+var messageStatuses = map[entity_enums.MessageStatus]string{
+	entity_enums.MESSAGE_SENT:      "SENT",
+	entity_enums.MESSAGE_DELIVERED: "DELIVERED",
+	entity_enums.MESSAGE_READ:      "READ",
+}
 
 type MessageHistoryPresenter struct {
 	res *uc.LoadMessageHistoryUseCaseRes
 }
 
+type MessageHistoryItemViewModel struct {
+	ID        uuid.UUID
+	OwnerId   uuid.UUID
+	Content   string
+	Timestamp time.Time
+	EditedAt  *time.Time
+	DeletedAt *time.Time
+	Status    string
+	Reactions []ReactionSummaryViewModel
+}
+
+type ReactionSummaryViewModel struct {
+	Emoji   string
+	Count   int
+	UserIds []uuid.UUID
+}
+
+// summarize the reactions by emoji, the emojis are sorted by the count and
+// then by the emoji itself
+func buildReactionSummary(reactions map[uuid.UUID][]string) []ReactionSummaryViewModel {
+	userIdsByEmoji := map[string][]uuid.UUID{}
+	for userId, emojis := range reactions {
+		for _, emoji := range emojis {
+			userIdsByEmoji[emoji] = append(userIdsByEmoji[emoji], userId)
+		}
+	}
+
+	summary := []ReactionSummaryViewModel{}
+	for emoji, userIds := range userIdsByEmoji {
+		sort.Slice(userIds, func(i, j int) bool {
+			return userIds[i].String() < userIds[j].String()
+		})
+		summary = append(summary, ReactionSummaryViewModel{
+			Emoji:   emoji,
+			Count:   len(userIds),
+			UserIds: userIds,
+		})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Count != summary[j].Count {
+			return summary[i].Count > summary[j].Count
+		}
+		return summary[i].Emoji < summary[j].Emoji
+	})
+
+	return summary
+}
+
+// nil for the zero time
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
 type MessageHistoryViewModel struct {
-	Messages map[string][]uc.MessageDTO
+	Messages     map[string][]MessageHistoryItemViewModel
+	UnreadCounts map[string]int
 }
 
 func (mhp *MessageHistoryPresenter) BuildViewModel() MessageHistoryViewModel {
 	mhvm := MessageHistoryViewModel{}
-	mhvm.Messages = mhp.res.MessageMap
+	mhvm.Messages = map[string][]MessageHistoryItemViewModel{}
+	for dmId, messages := range mhp.res.MessageMap {
+		items := []MessageHistoryItemViewModel{}
+		for _, message := range messages {
+			items = append(items, MessageHistoryItemViewModel{
+				ID:        message.ID,
+				OwnerId:   message.OwnerId,
+				Content:   message.Content,
+				Timestamp: message.Timestamp,
+				EditedAt:  optionalTime(message.EditedAt),
+				DeletedAt: optionalTime(message.DeletedAt),
+				Status:    messageStatuses[message.Status],
+				Reactions: buildReactionSummary(message.Reactions),
+			})
+		}
+		mhvm.Messages[dmId] = items
+	}
+	mhvm.UnreadCounts = mhp.res.UnreadCounts
 
 	return mhvm
 }
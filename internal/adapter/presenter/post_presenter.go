@@ -0,0 +1,77 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/types"
+)
+
+var postPermissionNames = map[entity_enums.PostPermission]string{
+	entity_enums.POST_PUBLIC:        "PUBLIC",
+	entity_enums.POST_FOLLOWER_ONLY: "FOLLOWER_ONLY",
+	entity_enums.POST_PRIVATE:       "PRIVATE",
+}
+
+type PostPresenter struct {
+	post *types.PostInfo
+}
+
+type PostViewModel struct {
+	ID         uuid.UUID  `json:"id"`
+	Title      string     `json:"title"`
+	Content    string     `json:"content"`
+	OwnerId    uuid.UUID  `json:"ownerId"`
+	OwnerName  string     `json:"ownerName"`
+	GroupId    *uuid.UUID `json:"groupId"` // null if not belonging to any group
+	Permission string     `json:"permission"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+func (pp *PostPresenter) BuildViewModel() PostViewModel {
+	var groupId *uuid.UUID = nil
+	if pp.post.GroupId != uuid.Nil {
+		groupId = &pp.post.GroupId
+	}
+
+	return PostViewModel{
+		ID:         pp.post.ID,
+		Title:      pp.post.Title,
+		Content:    pp.post.Content,
+		OwnerId:    pp.post.OwnerId,
+		OwnerName:  pp.post.OwnerName,
+		GroupId:    groupId,
+		Permission: postPermissionNames[pp.post.Permission],
+		CreatedAt:  pp.post.CreatedAt,
+		UpdatedAt:  pp.post.UpdatedAt,
+	}
+}
+
+// constructor of post presenter
+func NewPostPresenter(post *types.PostInfo) Presenter[PostViewModel] {
+	return &PostPresenter{post}
+}
+
+type PostListPresenter struct {
+	posts []*types.PostInfo
+}
+
+type PostListViewModel struct {
+	Posts []PostViewModel `json:"posts"`
+}
+
+func (plp *PostListPresenter) BuildViewModel() PostListViewModel {
+	plvm := PostListViewModel{Posts: []PostViewModel{}}
+	for _, post := range plp.posts {
+		plvm.Posts = append(plvm.Posts, NewPostPresenter(post).BuildViewModel())
+	}
+
+	return plvm
+}
+
+// constructor of post list presenter
+func NewPostListPresenter(posts []*types.PostInfo) Presenter[PostListViewModel] {
+	return &PostListPresenter{posts}
+}
@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"github.com/google/uuid"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/types"
+)
+
+type ProfilePresenter struct {
+	profile *types.ProfileInfo
+	isOwner bool
+}
+
+type ProfileViewModel struct {
+	ID          uuid.UUID `json:"id"`
+	UserName    string    `json:"username"`
+	DisplayName string    `json:"displayName"`
+	// the email is only shown to the owner of the profile
+	Email          string `json:"email,omitempty"`
+	Public         bool   `json:"public"`
+	Bio            string `json:"bio"`
+	AvatarURL      string `json:"avatarUrl"`
+	Location       string `json:"location"`
+	Website        string `json:"website"`
+	Birthday       string `json:"birthday,omitempty"` // formatted as `entity.BirthdayLayout`
+	FollowerCount  int    `json:"followerCount"`
+	FollowingCount int    `json:"followingCount"`
+}
+
+func (pp *ProfilePresenter) BuildViewModel() ProfileViewModel {
+	pvm := ProfileViewModel{
+		ID:             pp.profile.ID,
+		UserName:       pp.profile.UserName,
+		DisplayName:    pp.profile.DisplayName,
+		Public:         pp.profile.Public,
+		Bio:            pp.profile.Bio,
+		AvatarURL:      pp.profile.AvatarURL,
+		Location:       pp.profile.Location,
+		Website:        pp.profile.Website,
+		FollowerCount:  pp.profile.FollowerCount,
+		FollowingCount: pp.profile.FollowingCount,
+	}
+	if pp.isOwner {
+		pvm.Email = pp.profile.Email
+	}
+	if pp.profile.Birthday != nil {
+		pvm.Birthday = pp.profile.Birthday.Format(entity.BirthdayLayout)
+	}
+
+	return pvm
+}
+
+// constructor of profile presenter, the email is hidden unless the viewer owns the profile
+func NewProfilePresenter(profile *types.ProfileInfo, isOwner bool) Presenter[ProfileViewModel] {
+	return &ProfilePresenter{profile, isOwner}
+}
@@ -0,0 +1,56 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/chat/search_messages"
+)
+
+type SearchMessagesPresenter struct {
+	res *uc.SearchMessagesUseCaseRes
+}
+
+type SearchResultViewModel struct {
+	DMId      uuid.UUID  `json:"dmId"`
+	MessageId uuid.UUID  `json:"messageId"`
+	OwnerId   uuid.UUID  `json:"ownerId"`
+	Snippet   string     `json:"snippet"`
+	Timestamp time.Time  `json:"timestamp"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+}
+
+type SearchMessagesViewModel struct {
+	Results []SearchResultViewModel `json:"results"`
+	// offset of the next page, it is omitted on the last page
+	NextOffset *int `json:"nextOffset,omitempty"`
+}
+
+func (smp *SearchMessagesPresenter) BuildViewModel() SearchMessagesViewModel {
+	smvm := SearchMessagesViewModel{Results: []SearchResultViewModel{}}
+	for _, result := range smp.res.Results {
+		item := SearchResultViewModel{
+			DMId:      result.DMId,
+			MessageId: result.MessageId,
+			OwnerId:   result.OwnerId,
+			Snippet:   result.Snippet,
+			Timestamp: result.Timestamp,
+		}
+		if !result.EditedAt.IsZero() {
+			editedAt := result.EditedAt
+			item.EditedAt = &editedAt
+		}
+		smvm.Results = append(smvm.Results, item)
+	}
+	if smp.res.NextOffset != -1 {
+		nextOffset := smp.res.NextOffset
+		smvm.NextOffset = &nextOffset
+	}
+
+	return smvm
+}
+
+// constructor of search messages presenter
+func NewSearchMessagesPresenter(res *uc.SearchMessagesUseCaseRes) Presenter[SearchMessagesViewModel] {
+	return &SearchMessagesPresenter{res}
+}
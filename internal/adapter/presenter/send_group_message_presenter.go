@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/chat/send_group_message"
+)
+
+type SendGroupMessagePresenter struct {
+	senderId uuid.UUID
+	groupId  uuid.UUID
+	content  string
+	res      *uc.SendGroupMessageUseCaseRes
+}
+
+type GroupMessageViewModel struct {
+	ID          uuid.UUID `json:"id"`
+	GroupChatId uuid.UUID `json:"groupChatId"`
+	GroupId     uuid.UUID `json:"groupId"`
+	SenderId    uuid.UUID `json:"senderId"`
+	Content     string    `json:"content"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+func (sgmp *SendGroupMessagePresenter) BuildViewModel() GroupMessageViewModel {
+	return GroupMessageViewModel{
+		ID:          sgmp.res.MessageId,
+		GroupChatId: sgmp.res.GroupChatId,
+		GroupId:     sgmp.groupId,
+		SenderId:    sgmp.senderId,
+		Content:     sgmp.content,
+		Timestamp:   sgmp.res.Timestamp,
+	}
+}
+
+// constructor of send group message presenter
+func NewSendGroupMessagePresenter(
+	senderId uuid.UUID,
+	groupId uuid.UUID,
+	content string,
+	res *uc.SendGroupMessageUseCaseRes,
+) Presenter[GroupMessageViewModel] {
+	return &SendGroupMessagePresenter{senderId, groupId, content, res}
+}
@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/chat/send_message"
+)
+
+type SendMessagePresenter struct {
+	senderId   uuid.UUID
+	receiverId uuid.UUID
+	content    string
+	res        *uc.SendMessageUseCaseRes
+}
+
+type MessageViewModel struct {
+	ID         uuid.UUID `json:"id"`
+	DMId       uuid.UUID `json:"dmId"`
+	SenderId   uuid.UUID `json:"senderId"`
+	ReceiverId uuid.UUID `json:"receiverId"`
+	Content    string    `json:"content"`
+	Timestamp  time.Time `json:"timestamp"`
+	// the message may be edited or deleted before it is synced
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+func (smp *SendMessagePresenter) BuildViewModel() MessageViewModel {
+	return MessageViewModel{
+		ID:         smp.res.MessageId,
+		DMId:       smp.res.DirectMessageId,
+		SenderId:   smp.senderId,
+		ReceiverId: smp.receiverId,
+		Content:    smp.content,
+		Timestamp:  smp.res.Timestamp,
+	}
+}
+
+// constructor of send message presenter
+func NewSendMessagePresenter(
+	senderId uuid.UUID,
+	receiverId uuid.UUID,
+	content string,
+	res *uc.SendMessageUseCaseRes,
+) Presenter[MessageViewModel] {
+	return &SendMessagePresenter{senderId, receiverId, content, res}
+}
@@ -0,0 +1,54 @@
+package presenter
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	uc "mashu.example/internal/usecase/chat/sync_messages"
+)
+
+type SyncMessagesPresenter struct {
+	userId uuid.UUID
+	res    *uc.SyncMessagesUseCaseRes
+}
+
+type SyncMessagesViewModel struct {
+	Messages []MessageViewModel `json:"messages"`
+	Cursor   time.Time          `json:"cursor"`
+}
+
+func (smp *SyncMessagesPresenter) BuildViewModel() SyncMessagesViewModel {
+	smvm := SyncMessagesViewModel{
+		Messages: []MessageViewModel{},
+		Cursor:   smp.res.Cursor,
+	}
+	for _, message := range smp.res.Messages {
+		mvm := MessageViewModel{
+			ID:         message.ID,
+			DMId:       message.DMId,
+			SenderId:   message.OwnerId,
+			ReceiverId: smp.userId,
+			Content:    message.Content,
+			Timestamp:  message.Timestamp,
+		}
+		if !message.EditedAt.IsZero() {
+			editedAt := message.EditedAt
+			mvm.EditedAt = &editedAt
+		}
+		if !message.DeletedAt.IsZero() {
+			deletedAt := message.DeletedAt
+			mvm.DeletedAt = &deletedAt
+		}
+		smvm.Messages = append(smvm.Messages, mvm)
+	}
+
+	return smvm
+}
+
+// constructor of sync messages presenter
+func NewSyncMessagesPresenter(
+	userId uuid.UUID,
+	res *uc.SyncMessagesUseCaseRes,
+) Presenter[SyncMessagesViewModel] {
+	return &SyncMessagesPresenter{userId, res}
+}
@@ -0,0 +1,457 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"mashu.example/internal/adapter/datamapper/chat_data_mapper"
+	chat "mashu.example/internal/entity/chat"
+	"mashu.example/internal/usecase/repository"
+)
+
+type chatRepo struct {
+	db *gorm.DB
+	// whether the contents are indexed by fts5, the sqlite built without
+	// fts5 falls back to scan the contents with `LIKE`
+	fullTextSearch bool
+}
+
+// preload the reactions of the messages in the reacted order
+func orderReactions(db *gorm.DB) *gorm.DB {
+	return db.Order("reactions.created_at")
+}
+
+func (cr *chatRepo) GetDirectMessage(dmId uuid.UUID) (*chat.DirectMessage, error) {
+	dmData := chat_data_mapper.DirectMessageDataMapper{}
+	if err := cr.db.
+		Preload("Creator").
+		Preload("Receiver").
+		Where("direct_messages.id = ?", dmId).
+		First(&dmData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrDMNotFound{DMId: dmId}
+		}
+		return nil, err
+	}
+
+	return dmData.ToDirectMessage(), nil
+}
+
+func (cr *chatRepo) GetDMByUserId(userA uuid.UUID, userB uuid.UUID) (*chat.DirectMessage, error) {
+	dmData := chat_data_mapper.DirectMessageDataMapper{}
+	if err := cr.db.
+		Preload("Creator").
+		Preload("Receiver").
+		Where(cr.db.
+			Where("direct_messages.creator_id = ? AND direct_messages.receiver_id = ?", userA, userB).
+			Or("direct_messages.creator_id = ? AND direct_messages.receiver_id = ?", userB, userA)).
+		First(&dmData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrDMNotFound{}
+		}
+		return nil, err
+	}
+
+	return dmData.ToDirectMessage(), nil
+}
+
+func (cr *chatRepo) GetDMsByPartUserId(userId uuid.UUID) ([]*chat.DirectMessage, error) {
+	dmDataMappers := []*chat_data_mapper.DirectMessageDataMapper{}
+	if err := cr.db.
+		Preload("Creator").
+		Preload("Receiver").
+		Where("direct_messages.creator_id = ? OR direct_messages.receiver_id = ?", userId, userId).
+		Order("direct_messages.created_at").
+		Find(&dmDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	dms := []*chat.DirectMessage{}
+	for _, dm := range dmDataMappers {
+		dms = append(dms, dm.ToDirectMessage())
+	}
+
+	return dms, nil
+}
+
+func (cr *chatRepo) SaveDirectMessage(dm *chat.DirectMessage) error {
+	dmDataMapper := chat_data_mapper.NewDirectMessageDataMapper(dm)
+
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Omit(clause.Associations).
+			Save(dmDataMapper).Error; err != nil {
+			return err
+		}
+
+		if len(dmDataMapper.Messages) == 0 {
+			return nil
+		}
+
+		// the saved messages may be edited or deleted since they are loaded
+		return tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(dmDataMapper.Messages).Error
+	})
+}
+
+func (cr *chatRepo) AddMessage(dmId uuid.UUID, message *chat.Message) error {
+	var count int64
+	if err := cr.db.
+		Model(&chat_data_mapper.DirectMessageDataMapper{}).
+		Where("direct_messages.id = ?", dmId).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	return cr.db.Create(chat_data_mapper.NewMessageDataMapper(dmId, message)).Error
+}
+
+func (cr *chatRepo) ListMessages(
+	dmId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	var count int64
+	if err := cr.db.
+		Model(&chat_data_mapper.DirectMessageDataMapper{}).
+		Where("direct_messages.id = ?", dmId).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+	if limit <= 0 {
+		return []*chat.Message{}, nil
+	}
+
+	query := cr.db.Preload("Reactions", orderReactions).Where("messages.dm_id = ?", dmId)
+	if !before.IsZero() {
+		query = query.Where("messages.timestamp < ?", before)
+	}
+
+	messageDataMappers := []*chat_data_mapper.MessageDataMapper{}
+	if err := query.
+		Order("messages.timestamp desc").
+		Limit(limit).
+		Find(&messageDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	// the latest messages are queried first, reverse them to the sent order
+	messages := make([]*chat.Message, len(messageDataMappers))
+	for i, message := range messageDataMappers {
+		messages[len(messageDataMappers)-1-i] = message.ToMessage()
+	}
+
+	return messages, nil
+}
+
+func (cr *chatRepo) GetMessage(dmId uuid.UUID, messageId uuid.UUID) (*chat.Message, error) {
+	messageData := chat_data_mapper.MessageDataMapper{}
+	if err := cr.db.
+		Preload("Reactions", orderReactions).
+		Where("messages.dm_id = ? AND messages.id = ?", dmId, messageId).
+		First(&messageData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrMessageNotFound{MessageId: messageId}
+		}
+		return nil, err
+	}
+
+	return messageData.ToMessage(), nil
+}
+
+func (cr *chatRepo) UpdateMessage(dmId uuid.UUID, message *chat.Message) error {
+	messageData := chat_data_mapper.NewMessageDataMapper(dmId, message)
+
+	result := cr.db.
+		Model(messageData).
+		Where("messages.dm_id = ?", dmId).
+		Select("content", "edited_at", "deleted_at").
+		Updates(messageData)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &repository.ErrMessageNotFound{MessageId: message.ID}
+	}
+
+	return nil
+}
+
+func (cr *chatRepo) AddReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	if err := cr.checkMessageExists(dmId, messageId); err != nil {
+		return err
+	}
+
+	return cr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&chat_data_mapper.ReactionDataMapper{
+			MessageId: messageId,
+			UserId:    userId,
+			Emoji:     emoji,
+			DMId:      dmId,
+			CreatedAt: time.Now(),
+		}).Error
+}
+
+func (cr *chatRepo) RemoveReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	if err := cr.checkMessageExists(dmId, messageId); err != nil {
+		return err
+	}
+
+	return cr.db.
+		Where(
+			"reactions.message_id = ? AND reactions.user_id = ? AND reactions.emoji = ?",
+			messageId,
+			userId,
+			emoji,
+		).
+		Delete(&chat_data_mapper.ReactionDataMapper{}).Error
+}
+
+func (cr *chatRepo) checkMessageExists(dmId uuid.UUID, messageId uuid.UUID) error {
+	var count int64
+	if err := cr.db.
+		Model(&chat_data_mapper.MessageDataMapper{}).
+		Where("messages.dm_id = ? AND messages.id = ?", dmId, messageId).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return &repository.ErrMessageNotFound{MessageId: messageId}
+	}
+
+	return nil
+}
+
+func (cr *chatRepo) GetGroupChatByGroupId(groupId uuid.UUID) (*chat.GroupChat, error) {
+	groupChatData := chat_data_mapper.GroupChatDataMapper{}
+	if err := cr.db.
+		Where("group_chats.group_id = ?", groupId).
+		First(&groupChatData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrGroupChatNotFound{GroupId: groupId}
+		}
+		return nil, err
+	}
+
+	return groupChatData.ToGroupChat(), nil
+}
+
+func (cr *chatRepo) SaveGroupChat(groupChat *chat.GroupChat) error {
+	groupChatData := chat_data_mapper.NewGroupChatDataMapper(groupChat)
+
+	return cr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Omit(clause.Associations).
+			Save(groupChatData).Error; err != nil {
+			return err
+		}
+
+		if len(groupChatData.Messages) == 0 {
+			return nil
+		}
+
+		// the saved messages may be edited or deleted since they are loaded
+		return tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(groupChatData.Messages).Error
+	})
+}
+
+func (cr *chatRepo) AddGroupMessage(groupChatId uuid.UUID, message *chat.Message) error {
+	var count int64
+	if err := cr.db.
+		Model(&chat_data_mapper.GroupChatDataMapper{}).
+		Where("group_chats.id = ?", groupChatId).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return &repository.ErrGroupChatNotFound{}
+	}
+
+	return cr.db.Create(chat_data_mapper.NewGroupMessageDataMapper(groupChatId, message)).Error
+}
+
+func (cr *chatRepo) ListGroupMessages(
+	groupChatId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	if limit <= 0 {
+		return []*chat.Message{}, nil
+	}
+
+	query := cr.db.Where("group_messages.group_chat_id = ?", groupChatId)
+	if !before.IsZero() {
+		query = query.Where("group_messages.timestamp < ?", before)
+	}
+
+	messageDataMappers := []*chat_data_mapper.GroupMessageDataMapper{}
+	if err := query.
+		Order("group_messages.timestamp desc").
+		Limit(limit).
+		Find(&messageDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	// the latest messages are queried first, reverse them to the sent order
+	messages := make([]*chat.Message, len(messageDataMappers))
+	for i, message := range messageDataMappers {
+		messages[len(messageDataMappers)-1-i] = message.ToMessage()
+	}
+
+	return messages, nil
+}
+
+func (cr *chatRepo) IncrUnreadCount(userId uuid.UUID, dmId uuid.UUID) error {
+	return cr.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "dm_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"unread_count": gorm.Expr("unread_counts.unread_count + 1"),
+			}),
+		}).
+		Create(&chat_data_mapper.UnreadCountDataMapper{UserId: userId, DMId: dmId, Count: 1}).Error
+}
+
+func (cr *chatRepo) GetUnreadCounts(userId uuid.UUID) (map[uuid.UUID]int, error) {
+	unreadCountDataMappers := []*chat_data_mapper.UnreadCountDataMapper{}
+	if err := cr.db.
+		Where("unread_counts.user_id = ? AND unread_counts.unread_count > 0", userId).
+		Find(&unreadCountDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	counts := map[uuid.UUID]int{}
+	for _, unreadCount := range unreadCountDataMappers {
+		counts[unreadCount.DMId] = unreadCount.Count
+	}
+
+	return counts, nil
+}
+
+func (cr *chatRepo) RecountUnreadCount(userId uuid.UUID, dmId uuid.UUID, readUntil time.Time) error {
+	var count int64
+	if err := cr.db.
+		Model(&chat_data_mapper.MessageDataMapper{}).
+		Where("messages.dm_id = ? AND messages.owner_id <> ?", dmId, userId).
+		Where("messages.timestamp > ? AND messages.deleted_at IS NULL", readUntil).
+		Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return cr.db.
+			Where("unread_counts.user_id = ? AND unread_counts.dm_id = ?", userId, dmId).
+			Delete(&chat_data_mapper.UnreadCountDataMapper{}).Error
+	}
+
+	return cr.db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "dm_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"unread_count"}),
+		}).
+		Create(&chat_data_mapper.UnreadCountDataMapper{UserId: userId, DMId: dmId, Count: int(count)}).Error
+}
+
+func (cr *chatRepo) SaveReceipt(receipt *chat.Receipt) error {
+	return cr.db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "dm_id"}, {Name: "user_id"}, {Name: "status"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"message_id",
+				"message_timestamp",
+				"created_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "excluded.message_timestamp > receipts.message_timestamp"},
+			}},
+		}).
+		Create(chat_data_mapper.NewReceiptDataMapper(receipt)).Error
+}
+
+func (cr *chatRepo) GetReceipts(dmId uuid.UUID) ([]*chat.Receipt, error) {
+	receiptDataMappers := []*chat_data_mapper.ReceiptDataMapper{}
+	if err := cr.db.
+		Where("receipts.dm_id = ?", dmId).
+		Find(&receiptDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	receipts := []*chat.Receipt{}
+	for _, receipt := range receiptDataMappers {
+		receipts = append(receipts, receipt.ToReceipt())
+	}
+
+	return receipts, nil
+}
+
+func (cr *chatRepo) AddPendingMessage(userId uuid.UUID, pending *chat.PendingMessage) error {
+	return cr.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(chat_data_mapper.NewPendingMessageDataMapper(userId, pending)).Error
+}
+
+func (cr *chatRepo) GetPendingMessages(userId uuid.UUID, since time.Time) ([]*chat.PendingMessage, error) {
+	query := cr.db.Where("pending_messages.user_id = ?", userId)
+	if !since.IsZero() {
+		query = query.Where("pending_messages.timestamp > ?", since)
+	}
+
+	pendingDataMappers := []*chat_data_mapper.PendingMessageDataMapper{}
+	if err := query.
+		Preload("Message").
+		Order("pending_messages.timestamp").
+		Find(&pendingDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	pendingMessages := []*chat.PendingMessage{}
+	for _, pending := range pendingDataMappers {
+		pendingMessages = append(pendingMessages, pending.ToPendingMessage())
+	}
+
+	return pendingMessages, nil
+}
+
+func (cr *chatRepo) RemovePendingMessages(userId uuid.UUID, until time.Time) error {
+	return cr.db.
+		Where("pending_messages.user_id = ? AND pending_messages.timestamp <= ?", userId, until).
+		Delete(&chat_data_mapper.PendingMessageDataMapper{}).Error
+}
+
+func NewChatRepository(db *gorm.DB) repository.ChatRepo {
+	db.AutoMigrate(
+		&chat_data_mapper.DirectMessageDataMapper{},
+		&chat_data_mapper.MessageDataMapper{},
+		&chat_data_mapper.ReactionDataMapper{},
+		&chat_data_mapper.UnreadCountDataMapper{},
+		&chat_data_mapper.PendingMessageDataMapper{},
+		&chat_data_mapper.ReceiptDataMapper{},
+		&chat_data_mapper.GroupChatDataMapper{},
+		&chat_data_mapper.GroupMessageDataMapper{},
+	)
+
+	return &chatRepo{db, setupMessageSearch(db)}
+}
@@ -0,0 +1,809 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/chat/edit_message"
+	"mashu.example/internal/usecase/chat/load_message_history"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg"
+)
+
+// the chat repositories share the database of the user repository
+type newChatRepoFunc func(db *gorm.DB, userRepo repository.UserRepo) repository.ChatRepo
+
+func chatRepoImpls(t *testing.T) map[string]newChatRepoFunc {
+	return map[string]newChatRepoFunc{
+		"gorm": func(db *gorm.DB, _ repository.UserRepo) repository.ChatRepo {
+			return adapter_repository.NewChatRepository(db)
+		},
+		"memory": func(*gorm.DB, repository.UserRepo) repository.ChatRepo {
+			return adapter_repository.NewMemChatRepository()
+		},
+		"redis": func(_ *gorm.DB, userRepo repository.UserRepo) repository.ChatRepo {
+			server := miniredis.RunT(t)
+			client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			return adapter_repository.NewRedisChatRepository(client, userRepo)
+		},
+	}
+}
+
+// create a user repository with the users `alice`, `bob` and `carol`
+func setupChatUsers(t *testing.T) (*gorm.DB, repository.UserRepo, []*entity.User) {
+	db := pkg.NewMemoryGormClient()
+	userRepo := adapter_repository.NewUserRepository(db)
+
+	users := []*entity.User{
+		entity.NewUser(uuid.New(), "alice", "Alice", "alice@email.com", true),
+		entity.NewUser(uuid.New(), "bob", "Bob", "bob@email.com", true),
+		entity.NewUser(uuid.New(), "carol", "Carol", "carol@email.com", true),
+	}
+	for _, user := range users {
+		assert.Nil(t, userRepo.Save(user))
+	}
+
+	return db, userRepo, users
+}
+
+func TestSaveAndGetDirectMessage(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			result, err := chatRepo.GetDirectMessage(dm.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, dm.ID, result.ID)
+			assert.Equal(t, alice.ID, result.Creator.ID)
+			assert.Equal(t, "alice", result.Creator.UserName)
+			assert.Equal(t, bob.ID, result.Receiver.ID)
+			assert.Empty(t, result.Messages)
+			assert.WithinDuration(t, dm.CreatedAt, result.CreatedAt, time.Millisecond)
+
+			_, err = chatRepo.GetDirectMessage(uuid.New())
+			assert.IsType(t, &repository.ErrDMNotFound{}, err)
+		})
+	}
+}
+
+func TestSaveDirectMessageWithMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			now := time.Now()
+			dm.Messages = append(dm.Messages,
+				chat.NewMessageWithTime(uuid.New(), alice.ID, "hi", now),
+				chat.NewMessageWithTime(uuid.New(), bob.ID, "hello", now.Add(time.Second)),
+			)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			// save again with a new message
+			dm.Messages = append(dm.Messages, chat.NewMessageWithTime(uuid.New(), alice.ID, "bye", now.Add(2*time.Second)))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			// the messages are loaded by `ListMessages` only
+			result, err := chatRepo.GetDirectMessage(dm.ID)
+			assert.Nil(t, err)
+			assert.Empty(t, result.Messages)
+
+			messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 10)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 3)
+			for i, message := range messages {
+				assert.Equal(t, dm.Messages[i].ID, message.ID)
+				assert.Equal(t, dm.Messages[i].OwnerId, message.OwnerId)
+				assert.Equal(t, dm.Messages[i].Content, message.Content)
+				assert.WithinDuration(t, dm.Messages[i].Timestamp, message.Timestamp, time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestGetDMByUserId(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob, carol := users[0], users[1], users[2]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			dm.AddMessage(alice.ID, "hi")
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			// the order of the users does not matter
+			result, err := chatRepo.GetDMByUserId(alice.ID, bob.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, dm.ID, result.ID)
+			assert.Equal(t, alice.ID, result.Creator.ID)
+			assert.Equal(t, bob.ID, result.Receiver.ID)
+			// the history is not loaded along with the dm
+			assert.Empty(t, result.Messages)
+
+			result, err = chatRepo.GetDMByUserId(bob.ID, alice.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, dm.ID, result.ID)
+
+			_, err = chatRepo.GetDMByUserId(alice.ID, carol.ID)
+			assert.IsType(t, &repository.ErrDMNotFound{}, err)
+		})
+	}
+}
+
+func TestGetDMsByPartUserId(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob, carol := users[0], users[1], users[2]
+
+			dmWithBob := chat.NewDirectMessage(uuid.New(), alice, bob)
+			dmWithCarol := chat.NewDirectMessage(uuid.New(), carol, alice)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dmWithBob))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dmWithCarol))
+
+			dms, err := chatRepo.GetDMsByPartUserId(alice.ID)
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, []uuid.UUID{dmWithBob.ID, dmWithCarol.ID}, []uuid.UUID{dms[0].ID, dms[1].ID})
+
+			dms, err = chatRepo.GetDMsByPartUserId(bob.ID)
+			assert.Nil(t, err)
+			assert.Len(t, dms, 1)
+			assert.Equal(t, dmWithBob.ID, dms[0].ID)
+
+			dms, err = chatRepo.GetDMsByPartUserId(uuid.New())
+			assert.Nil(t, err)
+			assert.Empty(t, dms)
+		})
+	}
+}
+
+func TestListMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			now := time.Now()
+			for i := 0; i < 5; i++ {
+				dm.Messages = append(dm.Messages, chat.NewMessageWithTime(
+					uuid.New(),
+					alice.ID,
+					fmt.Sprintf("message %d", i),
+					now.Add(time.Duration(i)*time.Second),
+				))
+			}
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			// the latest page
+			messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 2)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, dm.Messages[3].ID, messages[0].ID)
+			assert.Equal(t, dm.Messages[4].ID, messages[1].ID)
+
+			// the page before the oldest message of the latest page
+			messages, err = chatRepo.ListMessages(dm.ID, messages[0].Timestamp, 2)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, dm.Messages[1].ID, messages[0].ID)
+			assert.Equal(t, dm.Messages[2].ID, messages[1].ID)
+
+			// the last page is not full
+			messages, err = chatRepo.ListMessages(dm.ID, messages[0].Timestamp, 2)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 1)
+			assert.Equal(t, dm.Messages[0].ID, messages[0].ID)
+
+			messages, err = chatRepo.ListMessages(dm.ID, messages[0].Timestamp, 2)
+			assert.Nil(t, err)
+			assert.Empty(t, messages)
+
+			_, err = chatRepo.ListMessages(uuid.New(), time.Time{}, 2)
+			assert.IsType(t, &repository.ErrDMNotFound{}, err)
+		})
+	}
+}
+
+func TestGetDMsByPartUserIdWithoutMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			dm.Messages = append(dm.Messages, chat.NewMessage(uuid.New(), alice.ID, "hi"))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			dms, err := chatRepo.GetDMsByPartUserId(alice.ID)
+			assert.Nil(t, err)
+			assert.Len(t, dms, 1)
+			assert.Equal(t, bob.ID, dms[0].Receiver.ID)
+			assert.Empty(t, dms[0].Messages)
+		})
+	}
+}
+
+func TestUnreadCounts(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob, carol := users[0], users[1], users[2]
+
+			dmWithBob := chat.NewDirectMessage(uuid.New(), alice, bob)
+			dmWithCarol := chat.NewDirectMessage(uuid.New(), alice, carol)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dmWithBob))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dmWithCarol))
+
+			counts, err := chatRepo.GetUnreadCounts(alice.ID)
+			assert.Nil(t, err)
+			assert.Empty(t, counts)
+
+			assert.Nil(t, chatRepo.IncrUnreadCount(alice.ID, dmWithBob.ID))
+			assert.Nil(t, chatRepo.IncrUnreadCount(alice.ID, dmWithBob.ID))
+			assert.Nil(t, chatRepo.IncrUnreadCount(alice.ID, dmWithCarol.ID))
+			assert.Nil(t, chatRepo.IncrUnreadCount(bob.ID, dmWithBob.ID))
+
+			counts, err = chatRepo.GetUnreadCounts(alice.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, map[uuid.UUID]int{dmWithBob.ID: 2, dmWithCarol.ID: 1}, counts)
+
+			counts, err = chatRepo.GetUnreadCounts(bob.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, map[uuid.UUID]int{dmWithBob.ID: 1}, counts)
+		})
+	}
+}
+
+func TestPendingMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			now := time.Now()
+			for i := 0; i < 3; i++ {
+				dm.AddMessageWithTime(alice.ID, fmt.Sprintf("message %d", i), now.Add(time.Duration(i)*time.Second))
+			}
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+			// queued in reverse order
+			for i := len(dm.Messages) - 1; i >= 0; i-- {
+				assert.Nil(t, chatRepo.AddPendingMessage(bob.ID, chat.NewPendingMessage(dm.ID, dm.Messages[i])))
+			}
+
+			pendingMessages, err := chatRepo.GetPendingMessages(bob.ID, time.Time{})
+			assert.Nil(t, err)
+			assert.Len(t, pendingMessages, 3)
+			for i, pending := range pendingMessages {
+				assert.Equal(t, dm.ID, pending.DMId)
+				assert.Equal(t, dm.Messages[i].ID, pending.Message.ID)
+				assert.Equal(t, dm.Messages[i].Content, pending.Message.Content)
+				assert.Equal(t, alice.ID, pending.Message.OwnerId)
+			}
+
+			pendingMessages, err = chatRepo.GetPendingMessages(bob.ID, dm.Messages[0].Timestamp)
+			assert.Nil(t, err)
+			assert.Len(t, pendingMessages, 2)
+			assert.Equal(t, dm.Messages[1].ID, pendingMessages[0].Message.ID)
+
+			assert.Nil(t, chatRepo.RemovePendingMessages(bob.ID, dm.Messages[1].Timestamp))
+			pendingMessages, err = chatRepo.GetPendingMessages(bob.ID, time.Time{})
+			assert.Nil(t, err)
+			assert.Len(t, pendingMessages, 1)
+			assert.Equal(t, dm.Messages[2].ID, pendingMessages[0].Message.ID)
+
+			pendingMessages, err = chatRepo.GetPendingMessages(alice.ID, time.Time{})
+			assert.Nil(t, err)
+			assert.Empty(t, pendingMessages)
+		})
+	}
+}
+
+func TestPendingMessagesFollowUpdates(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			now := time.Now()
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+			edited := chat.NewMessageWithTime(uuid.New(), alice.ID, "hi", now)
+			deleted := chat.NewMessageWithTime(uuid.New(), alice.ID, "oops", now.Add(time.Second))
+			for _, message := range []*chat.Message{edited, deleted} {
+				assert.Nil(t, chatRepo.AddMessage(dm.ID, message))
+				assert.Nil(t, chatRepo.AddPendingMessage(bob.ID, chat.NewPendingMessage(dm.ID, message)))
+			}
+
+			// the messages are changed after they are queued for bob
+			editedCopy := chat.NewMessageWithTime(edited.ID, alice.ID, "hi", now)
+			editedCopy.Edit("hello")
+			assert.Nil(t, chatRepo.UpdateMessage(dm.ID, editedCopy))
+			deletedCopy := chat.NewMessageWithTime(deleted.ID, alice.ID, "oops", now.Add(time.Second))
+			deletedCopy.Delete()
+			assert.Nil(t, chatRepo.UpdateMessage(dm.ID, deletedCopy))
+
+			pendingMessages, err := chatRepo.GetPendingMessages(bob.ID, time.Time{})
+			assert.Nil(t, err)
+			assert.Len(t, pendingMessages, 2)
+			assert.Equal(t, "hello", pendingMessages[0].Message.Content)
+			assert.True(t, pendingMessages[0].Message.IsEdited())
+			assert.Empty(t, pendingMessages[1].Message.Content)
+			assert.True(t, pendingMessages[1].Message.IsDeleted())
+		})
+	}
+}
+
+func TestGetMessage(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			message := dm.AddMessage(alice.ID, "hi")
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			result, err := chatRepo.GetMessage(dm.ID, message.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, message.ID, result.ID)
+			assert.Equal(t, "hi", result.Content)
+
+			_, err = chatRepo.GetMessage(dm.ID, uuid.New())
+			assert.IsType(t, &repository.ErrMessageNotFound{}, err)
+		})
+	}
+}
+
+func TestUpdateMessage(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			// sent at different times to keep the order of the history
+			now := time.Now()
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			edited := dm.AddMessageWithTime(alice.ID, "hi", now)
+			deleted := dm.AddMessageWithTime(alice.ID, "oops", now.Add(time.Second))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			edited.Edit("hello")
+			assert.Nil(t, chatRepo.UpdateMessage(dm.ID, edited))
+			deleted.Delete()
+			assert.Nil(t, chatRepo.UpdateMessage(dm.ID, deleted))
+
+			result, err := chatRepo.GetMessage(dm.ID, edited.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, "hello", result.Content)
+			assert.True(t, result.EditedAt.Equal(edited.EditedAt))
+			assert.False(t, result.IsDeleted())
+
+			// the deleted message is kept in the history as a tombstone
+			messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 10)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, deleted.ID, messages[1].ID)
+			assert.Empty(t, messages[1].Content)
+			assert.True(t, messages[1].DeletedAt.Equal(deleted.DeletedAt))
+
+			err = chatRepo.UpdateMessage(dm.ID, chat.NewMessage(uuid.New(), alice.ID, "hi"))
+			assert.IsType(t, &repository.ErrMessageNotFound{}, err)
+		})
+	}
+}
+
+func TestAddMessageKeepsSavedMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			now := time.Now()
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			message := dm.AddMessageWithTime(alice.ID, "oops", now)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			// the message is deleted after the dm is loaded by a sender
+			staleDM := chat.NewDirectMessage(dm.ID, alice, bob)
+			staleDM.Messages = append(staleDM.Messages, chat.NewMessageWithTime(message.ID, alice.ID, "oops", now))
+			deleted := chat.NewMessageWithTime(message.ID, alice.ID, "oops", now)
+			deleted.Delete()
+			assert.Nil(t, chatRepo.UpdateMessage(dm.ID, deleted))
+
+			sent := staleDM.AddMessageWithTime(bob.ID, "what?", now.Add(time.Second))
+			assert.Nil(t, chatRepo.AddMessage(dm.ID, sent))
+			assert.Nil(t, chatRepo.SaveDirectMessage(staleDM))
+
+			messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 10)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.True(t, messages[0].IsDeleted())
+			assert.Empty(t, messages[0].Content)
+			assert.Equal(t, "what?", messages[1].Content)
+
+			err = chatRepo.AddMessage(uuid.New(), chat.NewMessage(uuid.New(), alice.ID, "hi"))
+			assert.IsType(t, &repository.ErrDMNotFound{}, err)
+		})
+	}
+}
+
+func TestRecountUnreadCount(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			now := time.Now().Truncate(time.Millisecond)
+			first := chat.NewMessageWithTime(uuid.New(), alice.ID, "first", now.Add(-3*time.Second))
+			reply := chat.NewMessageWithTime(uuid.New(), bob.ID, "reply", now.Add(-2*time.Second))
+			second := chat.NewMessageWithTime(uuid.New(), alice.ID, "second", now.Add(-time.Second))
+			deleted := chat.NewMessageWithTime(uuid.New(), alice.ID, "deleted", now)
+			deleted.Delete()
+			for _, message := range []*chat.Message{first, reply, second, deleted} {
+				assert.Nil(t, chatRepo.AddMessage(dm.ID, message))
+				if message.OwnerId == alice.ID {
+					assert.Nil(t, chatRepo.IncrUnreadCount(bob.ID, dm.ID))
+				}
+			}
+
+			// the partner's message sent after the read one is still unread,
+			// the own and the deleted messages are not counted
+			assert.Nil(t, chatRepo.RecountUnreadCount(bob.ID, dm.ID, first.Timestamp))
+			counts, err := chatRepo.GetUnreadCounts(bob.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, map[uuid.UUID]int{dm.ID: 1}, counts)
+
+			assert.Nil(t, chatRepo.RecountUnreadCount(bob.ID, dm.ID, second.Timestamp))
+			counts, err = chatRepo.GetUnreadCounts(bob.ID)
+			assert.Nil(t, err)
+			assert.Empty(t, counts)
+
+			// count again after the recount
+			assert.Nil(t, chatRepo.IncrUnreadCount(bob.ID, dm.ID))
+			counts, err = chatRepo.GetUnreadCounts(bob.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, map[uuid.UUID]int{dm.ID: 1}, counts)
+		})
+	}
+}
+
+func TestSaveReceipt(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			now := time.Now()
+			first := dm.AddMessageWithTime(alice.ID, "first", now)
+			second := dm.AddMessageWithTime(alice.ID, "second", now.Add(time.Second))
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			assert.Nil(t, chatRepo.SaveReceipt(chat.NewReceipt(dm.ID, bob.ID, second, entity_enums.MESSAGE_DELIVERED)))
+			assert.Nil(t, chatRepo.SaveReceipt(chat.NewReceipt(dm.ID, bob.ID, first, entity_enums.MESSAGE_READ)))
+			// the older receipt is ignored
+			assert.Nil(t, chatRepo.SaveReceipt(chat.NewReceipt(dm.ID, bob.ID, first, entity_enums.MESSAGE_DELIVERED)))
+
+			receipts, err := chatRepo.GetReceipts(dm.ID)
+			assert.Nil(t, err)
+			assert.Len(t, receipts, 2)
+			for _, receipt := range receipts {
+				assert.Equal(t, dm.ID, receipt.DMId)
+				assert.Equal(t, bob.ID, receipt.UserId)
+				switch receipt.Status {
+				case entity_enums.MESSAGE_DELIVERED:
+					assert.Equal(t, second.ID, receipt.MessageId)
+				case entity_enums.MESSAGE_READ:
+					assert.Equal(t, first.ID, receipt.MessageId)
+				}
+			}
+
+			// move the read receipt forward
+			assert.Nil(t, chatRepo.SaveReceipt(chat.NewReceipt(dm.ID, bob.ID, second, entity_enums.MESSAGE_READ)))
+			receipts, err = chatRepo.GetReceipts(dm.ID)
+			assert.Nil(t, err)
+			assert.Len(t, receipts, 2)
+			for _, receipt := range receipts {
+				assert.Equal(t, second.ID, receipt.MessageId)
+			}
+			assert.Equal(t, entity_enums.MESSAGE_READ, chat.GetMessageStatus(second, receipts))
+		})
+	}
+}
+
+func TestSaveAndGetGroupChat(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			groupId := uuid.New()
+			groupChat := chat.NewGroupChat(uuid.New(), groupId)
+			assert.Nil(t, chatRepo.SaveGroupChat(groupChat))
+
+			result, err := chatRepo.GetGroupChatByGroupId(groupId)
+			assert.Nil(t, err)
+			assert.Equal(t, groupChat.ID, result.ID)
+			assert.Equal(t, groupId, result.GroupId)
+			assert.Empty(t, result.Messages)
+
+			// the messages are added to the saved chat one by one
+			now := time.Now()
+			assert.Nil(t, chatRepo.AddGroupMessage(groupChat.ID, chat.NewMessageWithTime(uuid.New(), alice.ID, "hi", now)))
+			assert.Nil(t, chatRepo.AddGroupMessage(groupChat.ID, chat.NewMessageWithTime(uuid.New(), bob.ID, "hello", now.Add(time.Second))))
+
+			// the history is not loaded along with the chat
+			result, err = chatRepo.GetGroupChatByGroupId(groupId)
+			assert.Nil(t, err)
+			assert.Empty(t, result.Messages)
+
+			messages, err := chatRepo.ListGroupMessages(groupChat.ID, time.Time{}, 10)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, "hi", messages[0].Content)
+			assert.Equal(t, bob.ID, messages[1].OwnerId)
+
+			err = chatRepo.AddGroupMessage(uuid.New(), chat.NewMessage(uuid.New(), alice.ID, "hi"))
+			assert.IsType(t, &repository.ErrGroupChatNotFound{}, err)
+
+			_, err = chatRepo.GetGroupChatByGroupId(uuid.New())
+			assert.IsType(t, &repository.ErrGroupChatNotFound{}, err)
+		})
+	}
+}
+
+func TestListGroupMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice := users[0]
+
+			groupChat := chat.NewGroupChat(uuid.New(), uuid.New())
+			now := time.Now()
+			for i := 0; i < 3; i++ {
+				groupChat.AddMessageWithTime(alice.ID, fmt.Sprintf("message %d", i), now.Add(time.Duration(i)*time.Second))
+			}
+			assert.Nil(t, chatRepo.SaveGroupChat(groupChat))
+
+			messages, err := chatRepo.ListGroupMessages(groupChat.ID, time.Time{}, 2)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 2)
+			assert.Equal(t, groupChat.Messages[1].ID, messages[0].ID)
+			assert.Equal(t, groupChat.Messages[2].ID, messages[1].ID)
+
+			messages, err = chatRepo.ListGroupMessages(groupChat.ID, messages[0].Timestamp, 2)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 1)
+			assert.Equal(t, groupChat.Messages[0].ID, messages[0].ID)
+
+			messages, err = chatRepo.ListGroupMessages(uuid.New(), time.Time{}, 2)
+			assert.Nil(t, err)
+			assert.Empty(t, messages)
+		})
+	}
+}
+
+func TestReactions(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob := users[0], users[1]
+
+			dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+			message := dm.AddMessage(alice.ID, "hi")
+			assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+			assert.Nil(t, chatRepo.AddReaction(dm.ID, message.ID, bob.ID, "👍"))
+			assert.Nil(t, chatRepo.AddReaction(dm.ID, message.ID, bob.ID, "🎉"))
+			assert.Nil(t, chatRepo.AddReaction(dm.ID, message.ID, alice.ID, "👍"))
+			// adding the same reaction again does nothing
+			assert.Nil(t, chatRepo.AddReaction(dm.ID, message.ID, bob.ID, "👍"))
+
+			result, err := chatRepo.GetMessage(dm.ID, message.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"👍", "🎉"}, result.Reactions[bob.ID])
+			assert.Equal(t, []string{"👍"}, result.Reactions[alice.ID])
+
+			assert.Nil(t, chatRepo.RemoveReaction(dm.ID, message.ID, bob.ID, "👍"))
+			assert.Nil(t, chatRepo.RemoveReaction(dm.ID, message.ID, alice.ID, "👍"))
+			assert.Nil(t, chatRepo.RemoveReaction(dm.ID, message.ID, alice.ID, "👍"))
+
+			// the reactions are loaded along with the messages
+			messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 10)
+			assert.Nil(t, err)
+			assert.Len(t, messages, 1)
+			assert.Equal(t, map[uuid.UUID][]string{bob.ID: {"🎉"}}, messages[0].Reactions)
+
+			err = chatRepo.AddReaction(dm.ID, uuid.New(), bob.ID, "👍")
+			assert.IsType(t, &repository.ErrMessageNotFound{}, err)
+		})
+	}
+}
+
+func TestSearchMessages(t *testing.T) {
+	for name, newRepo := range chatRepoImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			db, userRepo, users := setupChatUsers(t)
+			chatRepo := newRepo(db, userRepo)
+			alice, bob, carol := users[0], users[1], users[2]
+
+			now := time.Now()
+			withBob := chat.NewDirectMessage(uuid.New(), alice, bob)
+			first := withBob.AddMessageWithTime(alice.ID, "Shall we have lunch tomorrow?", now)
+			withBob.AddMessageWithTime(bob.ID, "sure", now.Add(time.Second))
+			second := withBob.AddMessageWithTime(bob.ID, "The lunch place is closed, let's find another one", now.Add(2*time.Second))
+			deleted := withBob.AddMessageWithTime(bob.ID, "lunch?", now.Add(3*time.Second))
+			// keep the content to make sure the tombstone is excluded
+			deleted.Delete()
+			deleted.Content = "lunch?"
+			withCarol := chat.NewDirectMessage(uuid.New(), carol, alice)
+			third := withCarol.AddMessageWithTime(carol.ID, "LUNCH at noon", now.Add(4*time.Second))
+			others := chat.NewDirectMessage(uuid.New(), bob, carol)
+			others.AddMessageWithTime(bob.ID, "lunch without alice", now.Add(5*time.Second))
+			for _, dm := range []*chat.DirectMessage{withBob, withCarol, others} {
+				assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+			}
+			assert.Nil(t, chatRepo.UpdateMessage(withBob.ID, deleted))
+
+			// the messages of the dms of the user from the latest one
+			results, err := chatRepo.SearchMessages(&repository.MessageSearchQuery{
+				UserId:  alice.ID,
+				Keyword: "lunch",
+				Limit:   10,
+			})
+			assert.Nil(t, err)
+			assert.Len(t, results, 3)
+			assert.Equal(t, third.ID, results[0].Message.ID)
+			assert.Equal(t, withCarol.ID, results[0].DMId)
+			assert.Equal(t, second.ID, results[1].Message.ID)
+			assert.Equal(t, first.ID, results[2].Message.ID)
+			assert.Contains(t, results[0].Snippet, "<mark>LUNCH</mark>")
+			assert.Contains(t, results[2].Snippet, "<mark>lunch</mark>")
+
+			// pagination
+			results, err = chatRepo.SearchMessages(&repository.MessageSearchQuery{
+				UserId:  alice.ID,
+				Keyword: "lunch",
+				Offset:  1,
+				Limit:   1,
+			})
+			assert.Nil(t, err)
+			assert.Len(t, results, 1)
+			assert.Equal(t, second.ID, results[0].Message.ID)
+
+			// the partner and the date range
+			results, err = chatRepo.SearchMessages(&repository.MessageSearchQuery{
+				UserId:    alice.ID,
+				Keyword:   "lunch",
+				PartnerId: bob.ID,
+				Since:     now.Add(time.Second),
+				Until:     now.Add(10 * time.Second),
+				Limit:     10,
+			})
+			assert.Nil(t, err)
+			assert.Len(t, results, 1)
+			assert.Equal(t, second.ID, results[0].Message.ID)
+
+			results, err = chatRepo.SearchMessages(&repository.MessageSearchQuery{
+				UserId:  alice.ID,
+				Keyword: "dinner",
+				Limit:   10,
+			})
+			assert.Nil(t, err)
+			assert.Empty(t, results)
+		})
+	}
+}
+
+// the in-memory store is shared by the goroutines of the connections, the
+// loaded messages must not change along with the store. run with `-race`
+func TestMemChatRepoReactWhileListingMessages(t *testing.T) {
+	_, _, users := setupChatUsers(t)
+	chatRepo := adapter_repository.NewMemChatRepository()
+	alice, bob := users[0], users[1]
+
+	dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+	message := dm.AddMessage(alice.ID, "hi")
+	assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			chatRepo.AddReaction(dm.ID, message.ID, bob.ID, fmt.Sprint(i))
+			chatRepo.RemoveReaction(dm.ID, message.ID, bob.ID, fmt.Sprint(i-1))
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		messages, err := chatRepo.ListMessages(dm.ID, time.Time{}, 10)
+		assert.Nil(t, err)
+		for _, emojis := range messages[0].Reactions {
+			assert.LessOrEqual(t, len(emojis), 2)
+		}
+	}
+	<-done
+
+	result, err := chatRepo.GetMessage(dm.ID, message.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"49"}, result.Reactions[bob.ID])
+
+	// changing the loaded message does not change the saved one
+	result.Edit("hello")
+	result.AddReaction(alice.ID, "👍")
+	saved, _ := chatRepo.GetMessage(dm.ID, message.ID)
+	assert.Equal(t, "hi", saved.Content)
+	assert.NotContains(t, saved.Reactions, alice.ID)
+}
+
+// the edits reach the in-memory store through `UpdateMessage` only, so that the
+// history loaded at the same time is not changed under it. run with `-race`
+func TestMemChatRepoEditWhileLoadingHistory(t *testing.T) {
+	_, userRepo, users := setupChatUsers(t)
+	chatRepo := adapter_repository.NewMemChatRepository()
+	alice, bob := users[0], users[1]
+
+	dm := chat.NewDirectMessage(uuid.New(), alice, bob)
+	message := dm.AddMessage(alice.ID, "hi")
+	assert.Nil(t, chatRepo.SaveDirectMessage(dm))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			req := edit_message.NewEditMessageUseCaseReq(alice.ID, dm.ID, message.ID, fmt.Sprint(i))
+			res := edit_message.NewEditMessageUseCaseRes()
+			edit_message.NewEditMessageUseCase(chatRepo, req, res).Execute()
+			assert.Nil(t, res.Err)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		req := load_message_history.NewLoadMessageHistoryUseCaseReq(bob.ID, time.Time{}, 10)
+		res := load_message_history.NewLoadMessageHistoryUseCaseRes()
+		load_message_history.NewLoadMessageHistoryUseCase(userRepo, chatRepo, req, res).Execute()
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.MessageMap[dm.ID.String()], 1)
+	}
+	<-done
+
+	result, err := chatRepo.GetMessage(dm.ID, message.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "49", result.Content)
+	assert.True(t, result.IsEdited())
+}
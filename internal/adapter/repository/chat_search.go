@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"mashu.example/internal/adapter/datamapper/chat_data_mapper"
+	"mashu.example/internal/usecase/repository"
+)
+
+// max number of tokens of a snippet built by fts5
+const ftsSnippetTokens = 10
+
+// the fts5 table indexes the contents of the messages table, it is kept in
+// sync by the triggers. fts5 is only compiled into go-sqlite3 with the
+// `sqlite_fts5` build tag (see the Makefile).
+//
+// the index is keyed by the implicit rowid of the messages table, since the
+// uuid primary key can not be the rowid. `VACUUM` may renumber the implicit
+// rowids, so the index has to be rebuilt after vacuuming the database:
+// `INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')`
+var messageSearchStatements = []string{
+	`CREATE VIRTUAL TABLE messages_fts USING fts5(content, content='messages', content_rowid='rowid')`,
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_insert AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, content) VALUES (new.rowid, new.content);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_delete AFTER DELETE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_update AFTER UPDATE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+		INSERT INTO messages_fts(rowid, content) VALUES (new.rowid, new.content);
+	END`,
+	// index the messages saved before the table is created
+	`INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')`,
+}
+
+// create the fts5 index of the messages if it does not exist, it returns false
+// if the database does not support fts5
+func setupMessageSearch(db *gorm.DB) bool {
+	if db.Migrator().HasTable("messages_fts") {
+		return true
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range messageSearchStatements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.Warn("full text search of the messages is disabled: ", err)
+		return false
+	}
+
+	return true
+}
+
+type messageSearchRow struct {
+	chat_data_mapper.MessageDataMapper
+	Snippet string `gorm:"column:snippet"`
+}
+
+func (cr *chatRepo) SearchMessages(
+	query *repository.MessageSearchQuery,
+) ([]*repository.MessageSearchResult, error) {
+	if query.Limit <= 0 {
+		return []*repository.MessageSearchResult{}, nil
+	}
+
+	db := cr.db.
+		Table("messages").
+		Joins("JOIN direct_messages ON direct_messages.id = messages.dm_id").
+		Where("direct_messages.creator_id = ? OR direct_messages.receiver_id = ?", query.UserId, query.UserId).
+		Where("messages.deleted_at IS NULL")
+	if cr.fullTextSearch {
+		db = db.
+			Select(
+				"messages.*, snippet(messages_fts, 0, ?, ?, ?, ?) AS snippet",
+				repository.SnippetHighlightStart,
+				repository.SnippetHighlightEnd,
+				snippetEllipsis,
+				ftsSnippetTokens,
+			).
+			Joins("JOIN messages_fts ON messages_fts.rowid = messages.rowid").
+			Where("messages_fts MATCH ?", newFTSQuery(query.Keyword))
+	} else {
+		db = db.
+			Select("messages.*").
+			Where("messages.content LIKE ? ESCAPE '\\'", "%"+escapeLikePattern(query.Keyword)+"%")
+	}
+	if query.PartnerId != uuid.Nil {
+		db = db.Where("direct_messages.creator_id = ? OR direct_messages.receiver_id = ?", query.PartnerId, query.PartnerId)
+	}
+	if !query.Since.IsZero() {
+		db = db.Where("messages.timestamp >= ?", query.Since)
+	}
+	if !query.Until.IsZero() {
+		db = db.Where("messages.timestamp < ?", query.Until)
+	}
+
+	rows := []*messageSearchRow{}
+	if err := db.
+		Order("messages.timestamp desc").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	pattern := newKeywordPattern(query.Keyword)
+	results := []*repository.MessageSearchResult{}
+	for _, row := range rows {
+		snippet := row.Snippet
+		if !cr.fullTextSearch {
+			snippet = buildSnippet(row.Content, pattern)
+		}
+		results = append(results, &repository.MessageSearchResult{
+			DMId:    row.DMId,
+			Message: row.ToMessage(),
+			Snippet: snippet,
+		})
+	}
+
+	return results, nil
+}
+
+// quote the keyword as a phrase so that the syntax of fts5 in it is not
+// interpreted, the last token matches as a prefix
+func newFTSQuery(keyword string) string {
+	return fmt.Sprintf(`"%s"*`, strings.ReplaceAll(keyword, `"`, `""`))
+}
+
+func escapeLikePattern(keyword string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(keyword)
+}
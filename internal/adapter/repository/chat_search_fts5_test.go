@@ -0,0 +1,20 @@
+//go:build sqlite_fts5
+
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/pkg"
+)
+
+// `TestSearchMessages` covers the search itself, this makes sure it runs
+// against the fts5 index instead of the `LIKE` fallback
+func TestMessageSearchUsesFTS5(t *testing.T) {
+	db := pkg.NewMemoryGormClient()
+	adapter_repository.NewChatRepository(db)
+
+	assert.True(t, db.Migrator().HasTable("messages_fts"))
+}
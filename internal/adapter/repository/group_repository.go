@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"mashu.example/internal/adapter/datamapper/group_data_mapper"
@@ -13,31 +15,95 @@ type groupRepo struct {
 }
 
 func (gr *groupRepo) GetGroupById(groupId uuid.UUID) (*entity.Group, error) {
-	//get group
 	groupData := &group_data_mapper.GroupDataMapper{}
-	if err := gr.db.
+	if err := gr.preload().
 		Where("groups.id = ?", groupId).
 		First(groupData).Error; err != nil {
 		return nil, err
 	}
 
-	group := groupData.ToGroup()
-
-	return group, nil
+	return groupData.ToGroup(), nil
 }
 
 func (gr *groupRepo) GetGroupByName(groupName string) (*entity.Group, error) {
-	return nil, nil
+	groupData := &group_data_mapper.GroupDataMapper{}
+	if err := gr.preload().
+		Where("groups.name = ?", groupName).
+		First(groupData).Error; err != nil {
+		return nil, err
+	}
+
+	return groupData.ToGroup(), nil
 }
 
 func (gr *groupRepo) Save(group *entity.Group) error {
-	return nil
+	groupData := group_data_mapper.NewGroupDataMapper(group)
+
+	return gr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Admins", "Joins").Save(groupData).Error; err != nil {
+			return err
+		}
+
+		// replace the admins and the join relation with the current state
+		if err := gr.deleteRelations(tx, group.ID); err != nil {
+			return err
+		}
+		if len(groupData.Admins) != 0 {
+			if err := tx.Create(groupData.Admins).Error; err != nil {
+				return err
+			}
+		}
+		if len(groupData.Joins) != 0 {
+			if err := tx.Create(groupData.Joins).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 }
 
 func (gr *groupRepo) Delete(groupId uuid.UUID) error {
-	return nil
+	return gr.db.Transaction(func(tx *gorm.DB) error {
+		if err := gr.deleteRelations(tx, groupId); err != nil {
+			return err
+		}
+
+		return tx.Delete(&group_data_mapper.GroupDataMapper{ID: groupId}).Error
+	})
+}
+
+func (gr *groupRepo) preload() *gorm.DB {
+	return gr.db.
+		Preload("Owner").
+		Preload("Admins", func(db *gorm.DB) *gorm.DB {
+			return db.Order("group_admins.promoted_at")
+		}).
+		Preload("Joins", func(db *gorm.DB) *gorm.DB {
+			return db.Order("joins.created_at")
+		})
+}
+
+func (gr *groupRepo) deleteRelations(tx *gorm.DB, groupId uuid.UUID) error {
+	if err := tx.
+		Where("group_admins.group_id = ?", groupId).
+		Delete(&group_data_mapper.GroupAdminDataMapper{}).Error; err != nil {
+		return err
+	}
+
+	return tx.
+		Where("joins.group_id = ?", groupId).
+		Delete(&group_data_mapper.JoinDataMapper{}).Error
 }
 
 func NewGroupRepository(db *gorm.DB) repository.GroupRepo {
+	if err := db.AutoMigrate(
+		&group_data_mapper.GroupDataMapper{},
+		&group_data_mapper.GroupAdminDataMapper{},
+		&group_data_mapper.JoinDataMapper{},
+	); err != nil {
+		fmt.Println(err.Error())
+	}
+
 	return &groupRepo{db}
 }
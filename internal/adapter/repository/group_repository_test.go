@@ -0,0 +1,132 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg"
+)
+
+func setupGroupRepo() repository.GroupRepo {
+	return adapter_repository.NewGroupRepository(pkg.NewMemoryGormClient())
+}
+
+func newGroupWithRelations() *entity.Group {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	group := entity.NewGroup(uuid.New(), "my group", owner, entity_enums.GROUP_PUBLIC)
+
+	memberId := uuid.New()
+	adminId := uuid.New()
+	group.AddMember(memberId, uuid.Nil, owner.ID)
+	group.AddMember(adminId, memberId, owner.ID)
+	group.AddAdmin(adminId, owner.ID)
+	group.AddJoinRequest(uuid.New())
+	group.AddInviteRequest(uuid.New(), memberId)
+
+	return group
+}
+
+func TestSaveAndGetGroup(t *testing.T) {
+	groupRepo := setupGroupRepo()
+
+	group := newGroupWithRelations()
+	assert.Nil(t, groupRepo.Save(group))
+
+	for name, getGroup := range map[string]func() (*entity.Group, error){
+		"by id":   func() (*entity.Group, error) { return groupRepo.GetGroupById(group.ID) },
+		"by name": func() (*entity.Group, error) { return groupRepo.GetGroupByName("my group") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := getGroup()
+			assert.Nil(t, err)
+
+			assert.Equal(t, group.ID, result.ID)
+			assert.Equal(t, "my group", result.Name)
+			assert.Equal(t, group.Owner.ID, result.Owner.ID)
+			assert.Equal(t, "owner", result.Owner.UserName)
+			assert.Equal(t, entity_enums.GROUP_PUBLIC, result.Permission)
+			assert.WithinDuration(t, group.CreatedAt, result.CreatedAt, time.Millisecond)
+
+			assert.Len(t, result.Members, 2)
+			for i, member := range result.Members {
+				assert.Equal(t, group.Members[i].UserId, member.UserId)
+				assert.Equal(t, group.Members[i].InvitedBy, member.InvitedBy)
+				assert.Equal(t, group.Members[i].ApprovedBy, member.ApprovedBy)
+				assert.WithinDuration(t, group.Members[i].JoinAt, member.JoinAt, time.Millisecond)
+			}
+
+			assert.Len(t, result.Admins, 1)
+			assert.Equal(t, group.Admins[0].UserId, result.Admins[0].UserId)
+			assert.Equal(t, group.Owner.ID, result.Admins[0].PromotedBy)
+
+			assert.Len(t, result.JoinRequests, 1)
+			assert.Equal(t, group.JoinRequests[0].Requester, result.JoinRequests[0].Requester)
+
+			assert.Len(t, result.InviteRequests, 1)
+			assert.Equal(t, group.InviteRequests[0].Invitee, result.InviteRequests[0].Invitee)
+			assert.Equal(t, group.InviteRequests[0].Inviter, result.InviteRequests[0].Inviter)
+		})
+	}
+}
+
+func TestUpdateGroup(t *testing.T) {
+	groupRepo := setupGroupRepo()
+
+	group := newGroupWithRelations()
+	assert.Nil(t, groupRepo.Save(group))
+
+	// accept the join request, remove the admin and cancel the invitation
+	requesterId := group.JoinRequests[0].Requester
+	group.AddMember(requesterId, uuid.Nil, group.Owner.ID)
+	group.RemoveJoinRequest(requesterId)
+	group.RemoveAdmin(group.Admins[0].UserId)
+	group.RemoveInvitation(group.InviteRequests[0].Invitee)
+	group.EditName("renamed group")
+	assert.Nil(t, groupRepo.Save(group))
+
+	result, err := groupRepo.GetGroupById(group.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed group", result.Name)
+	assert.Len(t, result.Members, 3)
+	assert.Equal(t, requesterId, result.Members[2].UserId)
+	assert.Len(t, result.Admins, 0)
+	assert.Len(t, result.JoinRequests, 0)
+	assert.Len(t, result.InviteRequests, 0)
+}
+
+func TestDeleteGroup(t *testing.T) {
+	groupRepo := setupGroupRepo()
+
+	group := newGroupWithRelations()
+	assert.Nil(t, groupRepo.Save(group))
+	assert.Nil(t, groupRepo.Delete(group.ID))
+
+	_, err := groupRepo.GetGroupById(group.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	// the relations of the deleted group should not be restored by a new group with the same id
+	newGroup := entity.NewGroup(group.ID, "new group", group.Owner, entity_enums.GROUP_PRIVATE)
+	assert.Nil(t, groupRepo.Save(newGroup))
+
+	result, err := groupRepo.GetGroupById(group.ID)
+	assert.Nil(t, err)
+	assert.Len(t, result.Members, 0)
+	assert.Len(t, result.Admins, 0)
+	assert.Len(t, result.JoinRequests, 0)
+	assert.Len(t, result.InviteRequests, 0)
+}
+
+func TestGetNonExistGroupByName(t *testing.T) {
+	groupRepo := setupGroupRepo()
+
+	_, err := groupRepo.GetGroupByName("not exist")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+}
@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 	chat "mashu.example/internal/entity/chat"
@@ -8,22 +12,36 @@ import (
 )
 
 type memChatRepo struct {
-	directMessages []chat.DirectMessage
+	mu              sync.RWMutex
+	directMessages  []chat.DirectMessage
+	groupChats      []chat.GroupChat
+	unreadCounts    map[uuid.UUID]map[uuid.UUID]int      // user id to the unread counts by dm id
+	pendingMessages map[uuid.UUID][]*chat.PendingMessage // user id to the pending messages
+	receipts        map[uuid.UUID][]*chat.Receipt        // dm id to the latest receipts
 }
 
 func (mct *memChatRepo) GetDirectMessage(dmId uuid.UUID) (*chat.DirectMessage, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
 	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
 		return dm.ID == dmId
 	})
 
 	if idx == -1 {
-		return nil, &repository.ErrDMNotFound{}
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
 	}
 
-	return &mct.directMessages[idx], nil
+	dm := mct.directMessages[idx]
+	dm.Messages = []*chat.Message{}
+
+	return &dm, nil
 }
 
 func (mct *memChatRepo) GetDMByUserId(userA uuid.UUID, userB uuid.UUID) (*chat.DirectMessage, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
 	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
 		return (dm.Creator.ID == userA && dm.Receiver.ID == userB) || (dm.Creator.ID == userB && dm.Receiver.ID == userA)
 	})
@@ -32,14 +50,22 @@ func (mct *memChatRepo) GetDMByUserId(userA uuid.UUID, userB uuid.UUID) (*chat.D
 		return nil, &repository.ErrDMNotFound{}
 	}
 
-	return &mct.directMessages[idx], nil
+	dm := mct.directMessages[idx]
+	dm.Messages = []*chat.Message{}
+
+	return &dm, nil
 }
 
 func (mct *memChatRepo) GetDMsByPartUserId(userId uuid.UUID) ([]*chat.DirectMessage, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
 	dms := []*chat.DirectMessage{}
 
-	for _, dm := range mct.directMessages {
+	for i := range mct.directMessages {
+		dm := mct.directMessages[i]
 		if dm.Creator.ID == userId || dm.Receiver.ID == userId {
+			dm.Messages = []*chat.Message{}
 			dms = append(dms, &dm)
 		}
 	}
@@ -48,17 +74,432 @@ func (mct *memChatRepo) GetDMsByPartUserId(userId uuid.UUID) ([]*chat.DirectMess
 }
 
 func (mct *memChatRepo) SaveDirectMessage(dm *chat.DirectMessage) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
 	idx := slices.IndexFunc(mct.directMessages, func(d chat.DirectMessage) bool {
 		return d.ID == dm.ID
 	})
+	if idx == -1 {
+		saved := *dm
+		saved.Messages = []*chat.Message{}
+		mct.directMessages = append(mct.directMessages, saved)
+		idx = len(mct.directMessages) - 1
+	}
+
+	// the saved messages may be edited or deleted since they are loaded
+	for _, message := range dm.Messages {
+		mct.directMessages[idx].Messages = insertMemMessage(mct.directMessages[idx].Messages, message)
+	}
+
+	return nil
+}
+
+func (mct *memChatRepo) AddMessage(dmId uuid.UUID, message *chat.Message) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
+		return dm.ID == dmId
+	})
+	if idx == -1 {
+		return &repository.ErrDMNotFound{DMId: dmId}
+	}
+	mct.directMessages[idx].Messages = insertMemMessage(mct.directMessages[idx].Messages, message)
+
+	return nil
+}
+
+// append a copy of the message unless it is saved already, the saved messages
+// are never shared with the callers so that they are only changed under the lock
+func insertMemMessage(messages []*chat.Message, message *chat.Message) []*chat.Message {
+	if slices.IndexFunc(messages, func(m *chat.Message) bool { return m.ID == message.ID }) != -1 {
+		return messages
+	}
+
+	return append(messages, message.Clone())
+}
+
+func (mct *memChatRepo) ListMessages(
+	dmId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
+		return dm.ID == dmId
+	})
+	if idx == -1 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+	if limit <= 0 {
+		return []*chat.Message{}, nil
+	}
+
+	messages := []*chat.Message{}
+	for _, message := range mct.directMessages[idx].Messages {
+		if before.IsZero() || message.Timestamp.Before(before) {
+			messages = append(messages, message.Clone())
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	return messages, nil
+}
+
+func (mct *memChatRepo) GetMessage(dmId uuid.UUID, messageId uuid.UUID) (*chat.Message, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	return mct.findMessage(dmId, messageId)
+}
+
+func (mct *memChatRepo) UpdateMessage(dmId uuid.UUID, message *chat.Message) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	return mct.updateMessage(dmId, message)
+}
+
+// replace the saved message by a copy, the caller must hold the lock
+func (mct *memChatRepo) updateMessage(dmId uuid.UUID, message *chat.Message) error {
+	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
+		return dm.ID == dmId
+	})
+	if idx == -1 {
+		return &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	messages := mct.directMessages[idx].Messages
+	for i := range messages {
+		if messages[i].ID == message.ID {
+			messages[i] = message.Clone()
+			return nil
+		}
+	}
+
+	return &repository.ErrMessageNotFound{MessageId: message.ID}
+}
+
+func (mct *memChatRepo) AddReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	message, err := mct.findMessage(dmId, messageId)
+	if err != nil {
+		return err
+	}
+	if !message.AddReaction(userId, emoji) {
+		return nil
+	}
+
+	return mct.updateMessage(dmId, message)
+}
+
+func (mct *memChatRepo) RemoveReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	message, err := mct.findMessage(dmId, messageId)
+	if err != nil {
+		return err
+	}
+	if !message.RemoveReaction(userId, emoji) {
+		return nil
+	}
+
+	return mct.updateMessage(dmId, message)
+}
+
+// find a copy of the saved message, the caller must hold the lock
+func (mct *memChatRepo) findMessage(dmId uuid.UUID, messageId uuid.UUID) (*chat.Message, error) {
+	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
+		return dm.ID == dmId
+	})
+	if idx == -1 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	for _, message := range mct.directMessages[idx].Messages {
+		if message.ID == messageId {
+			return message.Clone(), nil
+		}
+	}
+
+	return nil, &repository.ErrMessageNotFound{MessageId: messageId}
+}
+
+func (mct *memChatRepo) SearchMessages(
+	query *repository.MessageSearchQuery,
+) ([]*repository.MessageSearchResult, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	pattern := newKeywordPattern(query.Keyword)
+	results := []*repository.MessageSearchResult{}
+	for i := range mct.directMessages {
+		dm := &mct.directMessages[i]
+		if dm.Creator.ID != query.UserId && dm.Receiver.ID != query.UserId {
+			continue
+		}
+
+		for _, message := range dm.Messages {
+			if matchSearchQuery(query, pattern, dm, message) {
+				results = append(results, &repository.MessageSearchResult{
+					DMId:    dm.ID,
+					Message: message.Clone(),
+					Snippet: buildSnippet(message.Content, pattern),
+				})
+			}
+		}
+	}
+
+	return paginateSearchResults(query, results), nil
+}
+
+func (mct *memChatRepo) GetGroupChatByGroupId(groupId uuid.UUID) (*chat.GroupChat, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	idx := slices.IndexFunc(mct.groupChats, func(gc chat.GroupChat) bool {
+		return gc.GroupId == groupId
+	})
+	if idx == -1 {
+		return nil, &repository.ErrGroupChatNotFound{GroupId: groupId}
+	}
+
+	groupChat := mct.groupChats[idx]
+	groupChat.Messages = []*chat.Message{}
+
+	return &groupChat, nil
+}
+
+func (mct *memChatRepo) SaveGroupChat(groupChat *chat.GroupChat) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	idx := slices.IndexFunc(mct.groupChats, func(gc chat.GroupChat) bool {
+		return gc.ID == groupChat.ID
+	})
+	if idx == -1 {
+		saved := *groupChat
+		saved.Messages = []*chat.Message{}
+		mct.groupChats = append(mct.groupChats, saved)
+		idx = len(mct.groupChats) - 1
+	}
+
+	// the saved messages may be edited or deleted since they are loaded
+	for _, message := range groupChat.Messages {
+		mct.groupChats[idx].Messages = insertMemMessage(mct.groupChats[idx].Messages, message)
+	}
+
+	return nil
+}
+
+func (mct *memChatRepo) AddGroupMessage(groupChatId uuid.UUID, message *chat.Message) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	idx := slices.IndexFunc(mct.groupChats, func(gc chat.GroupChat) bool {
+		return gc.ID == groupChatId
+	})
+	if idx == -1 {
+		return &repository.ErrGroupChatNotFound{}
+	}
+	mct.groupChats[idx].Messages = insertMemMessage(mct.groupChats[idx].Messages, message)
+
+	return nil
+}
+
+func (mct *memChatRepo) ListGroupMessages(
+	groupChatId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	idx := slices.IndexFunc(mct.groupChats, func(gc chat.GroupChat) bool {
+		return gc.ID == groupChatId
+	})
+	if idx == -1 || limit <= 0 {
+		return []*chat.Message{}, nil
+	}
+
+	messages := []*chat.Message{}
+	for _, message := range mct.groupChats[idx].Messages {
+		if before.IsZero() || message.Timestamp.Before(before) {
+			messages = append(messages, message.Clone())
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	return messages, nil
+}
+
+func (mct *memChatRepo) IncrUnreadCount(userId uuid.UUID, dmId uuid.UUID) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	if _, ok := mct.unreadCounts[userId]; !ok {
+		mct.unreadCounts[userId] = map[uuid.UUID]int{}
+	}
+	mct.unreadCounts[userId][dmId]++
+
+	return nil
+}
+
+func (mct *memChatRepo) GetUnreadCounts(userId uuid.UUID) (map[uuid.UUID]int, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	counts := map[uuid.UUID]int{}
+	for dmId, count := range mct.unreadCounts[userId] {
+		if count > 0 {
+			counts[dmId] = count
+		}
+	}
+
+	return counts, nil
+}
+
+func (mct *memChatRepo) RecountUnreadCount(userId uuid.UUID, dmId uuid.UUID, readUntil time.Time) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	count := 0
+	idx := slices.IndexFunc(mct.directMessages, func(dm chat.DirectMessage) bool {
+		return dm.ID == dmId
+	})
 	if idx != -1 {
-		slices.Delete(mct.directMessages, idx, idx+1)
+		for _, message := range mct.directMessages[idx].Messages {
+			if message.OwnerId != userId && !message.IsDeleted() && message.Timestamp.After(readUntil) {
+				count++
+			}
+		}
+	}
+
+	if count == 0 {
+		delete(mct.unreadCounts[userId], dmId)
+		return nil
+	}
+
+	if _, ok := mct.unreadCounts[userId]; !ok {
+		mct.unreadCounts[userId] = map[uuid.UUID]int{}
 	}
-	mct.directMessages = append(mct.directMessages, *dm)
+	mct.unreadCounts[userId][dmId] = count
+
+	return nil
+}
+
+func (mct *memChatRepo) SaveReceipt(receipt *chat.Receipt) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	receipts := mct.receipts[receipt.DMId]
+	idx := slices.IndexFunc(receipts, func(r *chat.Receipt) bool {
+		return r.UserId == receipt.UserId && r.Status == receipt.Status
+	})
+	if idx == -1 {
+		mct.receipts[receipt.DMId] = append(receipts, receipt)
+		return nil
+	}
+	if receipt.IsNewerThan(receipts[idx]) {
+		receipts[idx] = receipt
+	}
+
+	return nil
+}
+
+func (mct *memChatRepo) GetReceipts(dmId uuid.UUID) ([]*chat.Receipt, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	receipts := []*chat.Receipt{}
+	receipts = append(receipts, mct.receipts[dmId]...)
+
+	return receipts, nil
+}
+
+func (mct *memChatRepo) AddPendingMessage(userId uuid.UUID, pending *chat.PendingMessage) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	mct.pendingMessages[userId] = append(
+		mct.pendingMessages[userId],
+		chat.NewPendingMessage(pending.DMId, pending.Message.Clone()),
+	)
+
+	return nil
+}
+
+func (mct *memChatRepo) GetPendingMessages(userId uuid.UUID, since time.Time) ([]*chat.PendingMessage, error) {
+	mct.mu.RLock()
+	defer mct.mu.RUnlock()
+
+	pendingMessages := []*chat.PendingMessage{}
+	for _, pending := range mct.pendingMessages[userId] {
+		if !pending.Message.Timestamp.After(since) {
+			continue
+		}
+
+		// sync the saved message which may be edited or deleted after it is queued
+		message, err := mct.findMessage(pending.DMId, pending.Message.ID)
+		if err != nil {
+			continue
+		}
+		pendingMessages = append(pendingMessages, chat.NewPendingMessage(pending.DMId, message))
+	}
+	sort.SliceStable(pendingMessages, func(i, j int) bool {
+		return pendingMessages[i].Message.Timestamp.Before(pendingMessages[j].Message.Timestamp)
+	})
+
+	return pendingMessages, nil
+}
+
+func (mct *memChatRepo) RemovePendingMessages(userId uuid.UUID, until time.Time) error {
+	mct.mu.Lock()
+	defer mct.mu.Unlock()
+
+	pendingMessages := []*chat.PendingMessage{}
+	for _, pending := range mct.pendingMessages[userId] {
+		if pending.Message.Timestamp.After(until) {
+			pendingMessages = append(pendingMessages, pending)
+		}
+	}
+	mct.pendingMessages[userId] = pendingMessages
 
 	return nil
 }
 
 func NewMemChatRepository() repository.ChatRepo {
-	return &memChatRepo{}
+	return &memChatRepo{
+		unreadCounts:    map[uuid.UUID]map[uuid.UUID]int{},
+		pendingMessages: map[uuid.UUID][]*chat.PendingMessage{},
+		receipts:        map[uuid.UUID][]*chat.Receipt{},
+	}
 }
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+)
+
+type memSessionRepo struct {
+	mu       sync.RWMutex
+	sessions map[uuid.UUID]entity.Session
+}
+
+func (msr *memSessionRepo) GetSessionById(sessionId uuid.UUID) (*entity.Session, error) {
+	msr.mu.RLock()
+	defer msr.mu.RUnlock()
+
+	session, ok := msr.sessions[sessionId]
+	if !ok {
+		return nil, &repository.ErrSessionNotFound{SessionId: sessionId}
+	}
+
+	return &session, nil
+}
+
+func (msr *memSessionRepo) GetSessionByRefreshTokenHash(refreshTokenHash string) (*entity.Session, error) {
+	msr.mu.RLock()
+	defer msr.mu.RUnlock()
+
+	for _, session := range msr.sessions {
+		if session.RefreshTokenHash == refreshTokenHash {
+			return &session, nil
+		}
+	}
+
+	return nil, &repository.ErrSessionNotFound{}
+}
+
+func (msr *memSessionRepo) GetSessionsByUserId(userId uuid.UUID) ([]*entity.Session, error) {
+	msr.mu.RLock()
+	defer msr.mu.RUnlock()
+
+	sessions := []*entity.Session{}
+	for _, session := range msr.sessions {
+		if session.UserId == userId {
+			s := session
+			sessions = append(sessions, &s)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (msr *memSessionRepo) Save(session *entity.Session) error {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	msr.sessions[session.ID] = *session
+
+	return nil
+}
+
+func (msr *memSessionRepo) RotateRefreshToken(session *entity.Session, oldRefreshTokenHash string) error {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	saved, ok := msr.sessions[session.ID]
+	if !ok || saved.RefreshTokenHash != oldRefreshTokenHash || saved.RevokedAt != nil {
+		return &repository.ErrSessionNotFound{SessionId: session.ID}
+	}
+	saved.RefreshTokenHash = session.RefreshTokenHash
+	saved.ExpiredAt = session.ExpiredAt
+	msr.sessions[session.ID] = saved
+
+	return nil
+}
+
+func NewMemSessionRepository() repository.SessionRepo {
+	return &memSessionRepo{sessions: map[uuid.UUID]entity.Session{}}
+}
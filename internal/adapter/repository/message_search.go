@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"regexp"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	chat "mashu.example/internal/entity/chat"
+	"mashu.example/internal/usecase/repository"
+)
+
+// number of characters kept around the first match of the keyword in a snippet
+const snippetContextLength = 30
+
+const snippetEllipsis = "…"
+
+func newKeywordPattern(keyword string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(keyword))
+}
+
+// match the message of the dm against the filters of the query, it is used by
+// the stores scanning the messages without an index
+func matchSearchQuery(
+	query *repository.MessageSearchQuery,
+	pattern *regexp.Regexp,
+	dm *chat.DirectMessage,
+	message *chat.Message,
+) bool {
+	if query.PartnerId != uuid.Nil && dm.Creator.ID != query.PartnerId && dm.Receiver.ID != query.PartnerId {
+		return false
+	}
+	if !query.Since.IsZero() && message.Timestamp.Before(query.Since) {
+		return false
+	}
+	if !query.Until.IsZero() && !message.Timestamp.Before(query.Until) {
+		return false
+	}
+
+	return !message.IsDeleted() && pattern.MatchString(message.Content)
+}
+
+// sort the results from the latest message and take the page of the query
+func paginateSearchResults(
+	query *repository.MessageSearchQuery,
+	results []*repository.MessageSearchResult,
+) []*repository.MessageSearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Message.Timestamp.After(results[j].Message.Timestamp)
+	})
+
+	if query.Offset >= len(results) || query.Limit <= 0 {
+		return []*repository.MessageSearchResult{}
+	}
+	results = results[query.Offset:]
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+
+	return results
+}
+
+// cut the content around the first match of the keyword and highlight the
+// matches in it
+func buildSnippet(content string, pattern *regexp.Regexp) string {
+	loc := pattern.FindStringIndex(content)
+	if loc == nil {
+		return content
+	}
+
+	start := loc[0]
+	for i := 0; i < snippetContextLength && start > 0; i++ {
+		_, size := utf8.DecodeLastRuneInString(content[:start])
+		start -= size
+	}
+	end := loc[1]
+	for i := 0; i < snippetContextLength && end < len(content); i++ {
+		_, size := utf8.DecodeRuneInString(content[end:])
+		end += size
+	}
+
+	snippet := pattern.ReplaceAllStringFunc(content[start:end], func(match string) string {
+		return repository.SnippetHighlightStart + match + repository.SnippetHighlightEnd
+	})
+	if start > 0 {
+		snippet = snippetEllipsis + snippet
+	}
+	if end < len(content) {
+		snippet = snippet + snippetEllipsis
+	}
+
+	return snippet
+}
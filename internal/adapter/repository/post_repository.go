@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 
+	"mashu.example/internal/adapter/datamapper/group_data_mapper"
 	"mashu.example/internal/adapter/datamapper/post_data_mapper"
+	"mashu.example/internal/adapter/datamapper/user_data_mapper"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,22 +19,35 @@ type postRepo struct {
 }
 
 func (pr *postRepo) GetPostById(postId uuid.UUID) (*entity.Post, error) {
-	// get post
+	// get post with its comments
 	postData := post_data_mapper.PostDataMapper{ID: postId}
 	if err := pr.db.
 		Preload("Owner").
+		Preload("Group.Owner").
+		Preload("Comments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("comments.created_at")
+		}).
+		Preload("Comments.Owner").
 		First(&postData).Error; err != nil {
 		return nil, err
 	}
 
+	// get follow relation and blocked users of the owner to check the post permission
+	if err := pr.loadRelations(postData.Owner); err != nil {
+		return nil, err
+	}
+
 	return postData.ToPost(), nil
 }
 
 func (pr *postRepo) GetPostByUserId(userId uuid.UUID) ([]*entity.Post, error) {
 	postDataMappers := []*post_data_mapper.PostDataMapper{}
 	if err := pr.db.
+		Preload("Owner").
+		Preload("Group.Owner").
 		Where("posts.owner_id = ?", userId).
-		Find(postDataMappers).Error; err != nil {
+		Order("posts.created_at desc").
+		Find(&postDataMappers).Error; err != nil {
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, err
 		}
@@ -46,30 +61,113 @@ func (pr *postRepo) GetPostByUserId(userId uuid.UUID) ([]*entity.Post, error) {
 	return posts, nil
 }
 
+func (pr *postRepo) GetCommentById(commentId uuid.UUID) (*entity.Comment, error) {
+	commentData := post_data_mapper.CommentDataMapper{}
+	if err := pr.db.
+		Preload("Owner").
+		Preload("Post.Owner").
+		Where("comments.id = ?", commentId).
+		First(&commentData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrCommentNotFound{CommentId: commentId}
+		}
+		return nil, err
+	}
+
+	return commentData.ToComment(nil), nil
+}
+
+func (pr *postRepo) GetCommentsByPostId(postId uuid.UUID, offset int, limit int) ([]*entity.Comment, int64, error) {
+	var total int64
+	if err := pr.db.
+		Model(&post_data_mapper.CommentDataMapper{}).
+		Where("comments.post_id = ?", postId).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	commentDataMappers := []*post_data_mapper.CommentDataMapper{}
+	if err := pr.db.
+		Preload("Owner").
+		Preload("Post.Owner").
+		Where("comments.post_id = ?", postId).
+		Order("comments.created_at").
+		Offset(offset).
+		Limit(limit).
+		Find(&commentDataMappers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	comments := []*entity.Comment{}
+	for _, comment := range commentDataMappers {
+		comments = append(comments, comment.ToComment(nil))
+	}
+
+	return comments, total, nil
+}
+
 func (pr *postRepo) Save(post *entity.Post) error {
-	postDataMapper := post_data_mapper.NewPostDataMapper(post)
-	if err := pr.db.Save(postDataMapper).Error; err != nil {
-		return err
+	// the comments are not synced with the post, since the loaded comments may be
+	// outdated or not loaded at all
+	return pr.db.Omit("Comments").Save(post_data_mapper.NewPostDataMapper(post)).Error
+}
+
+func (pr *postRepo) Delete(postId uuid.UUID) error {
+	return pr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("post_id = ?", postId).
+			Delete(&post_data_mapper.CommentDataMapper{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&post_data_mapper.PostDataMapper{ID: postId}).Error
+	})
+}
+
+func (pr *postRepo) AddComment(comment *entity.Comment) error {
+	return pr.db.Create(post_data_mapper.NewCommentDataMapper(comment)).Error
+}
+
+func (pr *postRepo) DeleteComment(commentId uuid.UUID) error {
+	result := pr.db.Delete(&post_data_mapper.CommentDataMapper{ID: commentId})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &repository.ErrCommentNotFound{CommentId: commentId}
 	}
 
 	return nil
 }
 
-func (pr *postRepo) Delete(postId uuid.UUID) error {
-	if err := pr.db.Delete(&entity.Post{ID: postId}).Error; err != nil {
+func (pr *postRepo) loadRelations(user *user_data_mapper.UserDataMapper) error {
+	if err := pr.db.
+		Where("follows.user_id = ? OR follows.follower_id = ?", user.ID, user.ID).
+		Find(&user.Follows).Error; err != nil {
 		return err
 	}
 
-	return nil
+	return pr.db.
+		Where("blocks.user_id = ?", user.ID).
+		Find(&user.Blocks).Error
 }
 
 func NewPostRepository(db *gorm.DB) repository.PostRepo {
+	if err := db.AutoMigrate(&group_data_mapper.GroupDataMapper{}); err != nil {
+		fmt.Println(err.Error())
+	}
 	if err := db.AutoMigrate(&post_data_mapper.PostDataMapper{}); err != nil {
 		fmt.Println(err.Error())
 	}
 	if err := db.AutoMigrate(&post_data_mapper.CommentDataMapper{}); err != nil {
 		fmt.Println(err.Error())
 	}
+	if err := db.AutoMigrate(&user_data_mapper.FollowDataMapper{}); err != nil {
+		fmt.Println(err.Error())
+	}
+	if err := db.AutoMigrate(&user_data_mapper.BlockDataMapper{}); err != nil {
+		fmt.Println(err.Error())
+	}
 
 	return &postRepo{db}
 }
@@ -3,6 +3,7 @@ package repository_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-playground/assert/v2"
 	"github.com/google/uuid"
@@ -55,6 +56,42 @@ func TestGetPostById(t *testing.T) {
 	assert.Equal(t, resultPost.Owner.Public, false)
 }
 
+func TestSaveAndGetPostInGroup(t *testing.T) {
+	db := pkg.NewMemoryGormClient()
+	groupRepo := adapter_repository.NewGroupRepository(db)
+	postRepo := adapter_repository.NewPostRepository(db)
+
+	owner := entity.NewUser(uuid.New(), "owner", "owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+	if err := groupRepo.Save(group); err != nil {
+		t.Error("failed to save group")
+	}
+
+	post := entity.NewPost(uuid.New(), "title", "content", owner, group, entity_enums.POST_PUBLIC)
+	if err := postRepo.Save(post); err != nil {
+		t.Error("failed to save post")
+	}
+
+	resultPost, err := postRepo.GetPostById(post.ID)
+	if err != nil {
+		t.Error("failed to get post")
+	}
+	assert.Equal(t, resultPost.Group().ID, group.ID)
+	assert.Equal(t, resultPost.Group().Name, "group")
+
+	posts, err := postRepo.GetPostByUserId(owner.ID)
+	if err != nil {
+		t.Error("failed to get posts")
+	}
+	assert.Equal(t, posts[0].Group().ID, group.ID)
+
+	// saving the post keeps the group
+	resultPost.Title = "new title"
+	postRepo.Save(resultPost)
+	resultPost, _ = postRepo.GetPostById(post.ID)
+	assert.Equal(t, resultPost.Group().ID, group.ID)
+}
+
 func TestGetNonExistPost(t *testing.T) {
 	postRepo := setup()
 
@@ -63,3 +100,166 @@ func TestGetNonExistPost(t *testing.T) {
 
 	assert.Equal(t, errors.Is(err, gorm.ErrRecordNotFound), true)
 }
+
+func TestGetPostByUserId(t *testing.T) {
+	postRepo := setup()
+
+	owner := entity.NewUser(
+		uuid.MustParse("10101010-0000-0000-0000-000000000000"),
+		"owner",
+		"owner display name",
+		"owner@email.com",
+		false,
+	)
+	firstPost := entity.NewPost(uuid.New(), "first", "first content", owner, nil, entity_enums.POST_PUBLIC)
+	secondPost := entity.NewPost(uuid.New(), "second", "second content", owner, nil, entity_enums.POST_PRIVATE)
+	postRepo.Save(firstPost)
+	postRepo.Save(secondPost)
+
+	posts, err := postRepo.GetPostByUserId(owner.ID)
+	if err != nil {
+		t.Error("failed to get posts")
+	}
+
+	assert.Equal(t, len(posts), 2)
+	for _, post := range posts {
+		assert.Equal(t, post.Owner.ID, owner.ID)
+	}
+
+	otherPosts, err := postRepo.GetPostByUserId(uuid.New())
+	if err != nil {
+		t.Error("failed to get posts")
+	}
+	assert.Equal(t, len(otherPosts), 0)
+}
+
+func TestDeletePost(t *testing.T) {
+	postRepo := setup()
+
+	owner := entity.NewUser(
+		uuid.MustParse("10101010-0000-0000-0000-000000000000"),
+		"owner",
+		"owner display name",
+		"owner@email.com",
+		false,
+	)
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_PUBLIC)
+	postRepo.Save(post)
+
+	if err := postRepo.Delete(post.ID); err != nil {
+		t.Error("failed to delete post")
+	}
+
+	_, err := postRepo.GetPostById(post.ID)
+	assert.Equal(t, errors.Is(err, gorm.ErrRecordNotFound), true)
+}
+
+func TestSaveAndGetComments(t *testing.T) {
+	postRepo := setup()
+
+	postOwner := entity.NewUser(uuid.New(), "post_owner", "post owner", "post_owner@email.com", true)
+	commentOwner := entity.NewUser(uuid.New(), "comment_owner", "comment owner", "comment_owner@email.com", true)
+	post := entity.NewPost(uuid.New(), "title", "content", postOwner, nil, entity_enums.POST_PUBLIC)
+	if err := postRepo.Save(post); err != nil {
+		t.Error("failed to save post")
+	}
+	for i, content := range []string{"first", "second", "third"} {
+		comment := entity.NewComment(uuid.New(), commentOwner, post, content)
+		comment.CreatedAt = comment.CreatedAt.Add(time.Duration(i) * time.Second)
+		post.Comments = append(post.Comments, comment)
+		if err := postRepo.AddComment(comment); err != nil {
+			t.Error("failed to add comment")
+		}
+	}
+
+	resultPost, err := postRepo.GetPostById(post.ID)
+	if err != nil {
+		t.Error("failed to get post")
+	}
+	assert.Equal(t, len(resultPost.Comments), 3)
+	assert.Equal(t, resultPost.Comments[0].Content, "first")
+	assert.Equal(t, resultPost.Comments[0].Owner.ID, commentOwner.ID)
+	assert.Equal(t, resultPost.Comments[0].Post.ID, post.ID)
+
+	comment, err := postRepo.GetCommentById(post.Comments[1].ID)
+	if err != nil {
+		t.Error("failed to get comment")
+	}
+	assert.Equal(t, comment.Content, "second")
+	assert.Equal(t, comment.Post.ID, post.ID)
+	assert.Equal(t, comment.Post.Owner.ID, postOwner.ID)
+
+	comments, total, err := postRepo.GetCommentsByPostId(post.ID, 1, 5)
+	if err != nil {
+		t.Error("failed to get comments")
+	}
+	assert.Equal(t, total, int64(3))
+	assert.Equal(t, len(comments), 2)
+	assert.Equal(t, comments[0].Content, "second")
+	assert.Equal(t, comments[1].Content, "third")
+
+	// remove the first comment
+	if err := postRepo.DeleteComment(post.Comments[0].ID); err != nil {
+		t.Error("failed to delete comment")
+	}
+
+	_, err = postRepo.GetCommentById(post.Comments[0].ID)
+	var errCommentNotFound *repository.ErrCommentNotFound
+	assert.Equal(t, errors.As(err, &errCommentNotFound), true)
+
+	_, total, _ = postRepo.GetCommentsByPostId(post.ID, 0, 5)
+	assert.Equal(t, total, int64(2))
+
+	err = postRepo.DeleteComment(post.Comments[0].ID)
+	assert.Equal(t, errors.As(err, &errCommentNotFound), true)
+}
+
+func TestSavePostKeepsComments(t *testing.T) {
+	postRepo := setup()
+
+	owner := entity.NewUser(uuid.New(), "owner", "owner", "owner@email.com", true)
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_PUBLIC)
+	postRepo.Save(post)
+	postRepo.AddComment(entity.NewComment(uuid.New(), owner, post, "comment"))
+
+	// the posts of the user are loaded without comments
+	posts, err := postRepo.GetPostByUserId(owner.ID)
+	if err != nil {
+		t.Error("failed to get posts")
+	}
+	posts[0].Title = "new title"
+	if err := postRepo.Save(posts[0]); err != nil {
+		t.Error("failed to save post")
+	}
+
+	resultPost, err := postRepo.GetPostById(post.ID)
+	if err != nil {
+		t.Error("failed to get post")
+	}
+	assert.Equal(t, resultPost.Title, "new title")
+	assert.Equal(t, len(resultPost.Comments), 1)
+}
+
+func TestGetPostWithOwnerFollowers(t *testing.T) {
+	db := pkg.NewMemoryGormClient()
+	userRepo := adapter_repository.NewUserRepository(db)
+	postRepo := adapter_repository.NewPostRepository(db)
+
+	follower := entity.NewUser(uuid.New(), "follower", "follower", "follower@email.com", true)
+	owner := entity.NewUser(uuid.New(), "owner", "owner", "owner@email.com", true)
+	owner.Followers = append(owner.Followers, follower.ID)
+	userRepo.Save(follower)
+	userRepo.Save(owner)
+
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_FOLLOWER_ONLY)
+	postRepo.Save(post)
+
+	resultPost, err := postRepo.GetPostById(post.ID)
+	if err != nil {
+		t.Error("failed to get post")
+	}
+
+	assert.Equal(t, resultPost.Owner.Followers, []uuid.UUID{follower.ID})
+	assert.Equal(t, resultPost.IsVisibleTo(follower.ID), true)
+	assert.Equal(t, resultPost.IsVisibleTo(uuid.New()), false)
+}
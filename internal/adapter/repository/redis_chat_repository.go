@@ -1,35 +1,806 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
 	"mashu.example/internal/usecase/repository"
 )
 
+// redis keys:
+// - dm:{dmId}                      hash of the direct message metadata
+// - dm:{dmId}:timeline             sorted set of message ids scored by the sent time
+// - dm:{dmId}:messages             hash of message id to the json encoded message
+// - dm:{dmId}:receipts             hash of {userId}:{status} to the json encoded latest receipt
+// - dm:pair:{userId}:{userId}      id of the direct message between two users (smaller id first)
+// - message:{messageId}:reactions  sorted set of {userId}:{emoji} scored by the reacted time
+// - group_chat:{chatId}            hash of the group chat metadata
+// - group_chat:{chatId}:timeline   sorted set of message ids scored by the sent time
+// - group_chat:{chatId}:messages   hash of message id to the json encoded message
+// - group:{groupId}:chat           id of the chat of the group
+// - user:{userId}:dms              set of direct message ids the user takes part in
+// - user:{userId}:unread           hash of direct message id to the number of unread messages
+// - user:{userId}:pending          sorted set of {dmId}:{messageId} of the pending messages scored by the sent time
+
+func redisDMKey(dmId uuid.UUID) string {
+	return fmt.Sprintf("dm:%s", dmId)
+}
+
+func redisDMTimelineKey(dmId uuid.UUID) string {
+	return fmt.Sprintf("dm:%s:timeline", dmId)
+}
+
+func redisDMMessagesKey(dmId uuid.UUID) string {
+	return fmt.Sprintf("dm:%s:messages", dmId)
+}
+
+func redisDMReceiptsKey(dmId uuid.UUID) string {
+	return fmt.Sprintf("dm:%s:receipts", dmId)
+}
+
+func redisDMPairKey(userA uuid.UUID, userB uuid.UUID) string {
+	if userA.String() > userB.String() {
+		userA, userB = userB, userA
+	}
+
+	return fmt.Sprintf("dm:pair:%s:%s", userA, userB)
+}
+
+func redisMessageReactionsKey(messageId string) string {
+	return fmt.Sprintf("message:%s:reactions", messageId)
+}
+
+func redisGroupChatKey(groupChatId uuid.UUID) string {
+	return fmt.Sprintf("group_chat:%s", groupChatId)
+}
+
+func redisGroupChatTimelineKey(groupChatId uuid.UUID) string {
+	return fmt.Sprintf("group_chat:%s:timeline", groupChatId)
+}
+
+func redisGroupChatMessagesKey(groupChatId uuid.UUID) string {
+	return fmt.Sprintf("group_chat:%s:messages", groupChatId)
+}
+
+func redisGroupChatIdKey(groupId uuid.UUID) string {
+	return fmt.Sprintf("group:%s:chat", groupId)
+}
+
+func redisUserDMsKey(userId uuid.UUID) string {
+	return fmt.Sprintf("user:%s:dms", userId)
+}
+
+func redisUserUnreadKey(userId uuid.UUID) string {
+	return fmt.Sprintf("user:%s:unread", userId)
+}
+
+func redisUserPendingKey(userId uuid.UUID) string {
+	return fmt.Sprintf("user:%s:pending", userId)
+}
+
+type redisReceipt struct {
+	UserId           uuid.UUID                  `json:"userId"`
+	MessageId        uuid.UUID                  `json:"messageId"`
+	MessageTimestamp time.Time                  `json:"messageTimestamp"`
+	Status           entity_enums.MessageStatus `json:"status"`
+	CreatedAt        time.Time                  `json:"createdAt"`
+}
+
+type redisMessage struct {
+	ID        uuid.UUID `json:"id"`
+	OwnerId   uuid.UUID `json:"ownerId"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+	EditedAt  time.Time `json:"editedAt"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+func newRedisMessage(message *chat.Message) redisMessage {
+	return redisMessage{
+		ID:        message.ID,
+		OwnerId:   message.OwnerId,
+		Content:   message.Content,
+		Timestamp: message.Timestamp,
+		EditedAt:  message.EditedAt,
+		DeletedAt: message.DeletedAt,
+	}
+}
+
+func (m redisMessage) toMessage() *chat.Message {
+	message := chat.NewMessageWithTime(m.ID, m.OwnerId, m.Content, m.Timestamp)
+	message.EditedAt = m.EditedAt
+	message.DeletedAt = m.DeletedAt
+
+	return message
+}
+
 type redisChatRepo struct {
-	db *redis.Client
+	db       *redis.Client
+	userRepo repository.UserRepo
 }
 
 func (rcr *redisChatRepo) GetDirectMessage(dmId uuid.UUID) (*chat.DirectMessage, error) {
-	return nil, nil
+	return rcr.getDMMetadata(context.Background(), dmId)
 }
 
 func (rcr *redisChatRepo) GetDMByUserId(
 	userA uuid.UUID,
 	userB uuid.UUID,
 ) (*chat.DirectMessage, error) {
-	return nil, nil
+	dmId, err := rcr.db.Get(context.Background(), redisDMPairKey(userA, userB)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, &repository.ErrDMNotFound{}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rcr.getDMMetadata(context.Background(), uuid.MustParse(dmId))
 }
 
 func (rcr *redisChatRepo) GetDMsByPartUserId(userId uuid.UUID) ([]*chat.DirectMessage, error) {
-	return nil, nil
+	ctx := context.Background()
+
+	dmIds, err := rcr.db.SMembers(ctx, redisUserDMsKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	dms := []*chat.DirectMessage{}
+	for _, dmId := range dmIds {
+		dm, err := rcr.getDMMetadata(ctx, uuid.MustParse(dmId))
+		if err != nil {
+			return nil, err
+		}
+		dms = append(dms, dm)
+	}
+
+	return dms, nil
 }
 
 func (rcr *redisChatRepo) SaveDirectMessage(dm *chat.DirectMessage) error {
+	ctx := context.Background()
+
+	_, err := rcr.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisDMKey(dm.ID), map[string]interface{}{
+			"id":          dm.ID.String(),
+			"creator_id":  dm.Creator.ID.String(),
+			"receiver_id": dm.Receiver.ID.String(),
+			"created_at":  dm.CreatedAt.Format(time.RFC3339Nano),
+		})
+		pipe.Set(ctx, redisDMPairKey(dm.Creator.ID, dm.Receiver.ID), dm.ID.String(), 0)
+		pipe.SAdd(ctx, redisUserDMsKey(dm.Creator.ID), dm.ID.String())
+		pipe.SAdd(ctx, redisUserDMsKey(dm.Receiver.ID), dm.ID.String())
+
+		// the saved messages may be edited or deleted since they are loaded
+		for _, message := range dm.Messages {
+			if err := addRedisMessage(ctx, pipe, redisDMTimelineKey(dm.ID), redisDMMessagesKey(dm.ID), message); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (rcr *redisChatRepo) AddMessage(dmId uuid.UUID, message *chat.Message) error {
+	ctx := context.Background()
+
+	exists, err := rcr.db.Exists(ctx, redisDMKey(dmId)).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	_, err = rcr.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return addRedisMessage(ctx, pipe, redisDMTimelineKey(dmId), redisDMMessagesKey(dmId), message)
+	})
+
+	return err
+}
+
+// queue the commands to insert the message into the timeline, the saved
+// message is left as is
+func addRedisMessage(
+	ctx context.Context,
+	pipe redis.Pipeliner,
+	timelineKey string,
+	messagesKey string,
+	message *chat.Message,
+) error {
+	data, err := json.Marshal(newRedisMessage(message))
+	if err != nil {
+		return err
+	}
+
+	pipe.ZAddNX(ctx, timelineKey, &redis.Z{
+		Score:  float64(message.Timestamp.UnixMicro()),
+		Member: message.ID.String(),
+	})
+	pipe.HSetNX(ctx, messagesKey, message.ID.String(), data)
+
+	return nil
+}
+
+func (rcr *redisChatRepo) ListMessages(
+	dmId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	ctx := context.Background()
+
+	exists, err := rcr.db.Exists(ctx, redisDMKey(dmId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	return rcr.listMessages(ctx, redisDMTimelineKey(dmId), redisDMMessagesKey(dmId), before, limit)
+}
+
+// list at most `limit` messages of the timeline sent before `before` in chronological order
+func (rcr *redisChatRepo) listMessages(
+	ctx context.Context,
+	timelineKey string,
+	messagesKey string,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	if limit <= 0 {
+		return []*chat.Message{}, nil
+	}
+
+	max := "+inf"
+	if !before.IsZero() {
+		max = fmt.Sprintf("(%d", before.UnixMicro())
+	}
+	messageIds, err := rcr.db.ZRevRangeByScore(ctx, timelineKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   max,
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	// the timeline is read from the latest message, reverse it to the sent order
+	for i, j := 0, len(messageIds)-1; i < j; i, j = i+1, j-1 {
+		messageIds[i], messageIds[j] = messageIds[j], messageIds[i]
+	}
+
+	return rcr.getMessages(ctx, messagesKey, messageIds)
+}
+
+// get the direct message without its messages
+func (rcr *redisChatRepo) getDMMetadata(ctx context.Context, dmId uuid.UUID) (*chat.DirectMessage, error) {
+	fields, err := rcr.db.HGetAll(ctx, redisDMKey(dmId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	creator, err := rcr.userRepo.GetUserById(uuid.MustParse(fields["creator_id"]))
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := rcr.userRepo.GetUserById(uuid.MustParse(fields["receiver_id"]))
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, fields["created_at"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &chat.DirectMessage{
+		ID:        dmId,
+		Creator:   creator,
+		Receiver:  receiver,
+		Messages:  []*chat.Message{},
+		CreatedAt: createdAt,
+	}, nil
+}
+
+// get the messages saved in the hash in the order of the given ids
+func (rcr *redisChatRepo) getMessages(
+	ctx context.Context,
+	messagesKey string,
+	messageIds []string,
+) ([]*chat.Message, error) {
+	messages := []*chat.Message{}
+	if len(messageIds) == 0 {
+		return messages, nil
+	}
+
+	values, err := rcr.db.HMGet(ctx, messagesKey, messageIds...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			// the message is missing in the hash
+			continue
+		}
+
+		message := redisMessage{}
+		if err := json.Unmarshal([]byte(data), &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message.toMessage())
+	}
+
+	if err := rcr.loadReactions(ctx, messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (rcr *redisChatRepo) loadReactions(ctx context.Context, messages []*chat.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	cmds := make([]*redis.StringSliceCmd, len(messages))
+	if _, err := rcr.db.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, message := range messages {
+			cmds[i] = pipe.ZRange(ctx, redisMessageReactionsKey(message.ID.String()), 0, -1)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		for _, member := range cmds[i].Val() {
+			// the user id is a uuid without colons, the rest is the emoji
+			userId, emoji, _ := strings.Cut(member, ":")
+			message.AddReaction(uuid.MustParse(userId), emoji)
+		}
+	}
+
 	return nil
 }
 
-func NewRedisChatRepository(db *redis.Client) repository.ChatRepo {
-	return &redisChatRepo{db}
+func (rcr *redisChatRepo) GetMessage(dmId uuid.UUID, messageId uuid.UUID) (*chat.Message, error) {
+	ctx := context.Background()
+
+	exists, err := rcr.db.Exists(ctx, redisDMKey(dmId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, &repository.ErrDMNotFound{DMId: dmId}
+	}
+
+	messages, err := rcr.getMessages(ctx, redisDMMessagesKey(dmId), []string{messageId.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, &repository.ErrMessageNotFound{MessageId: messageId}
+	}
+
+	return messages[0], nil
+}
+
+func (rcr *redisChatRepo) UpdateMessage(dmId uuid.UUID, message *chat.Message) error {
+	ctx := context.Background()
+
+	if err := rcr.checkMessageExists(ctx, dmId, message.ID); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(newRedisMessage(message))
+	if err != nil {
+		return err
+	}
+
+	return rcr.db.HSet(ctx, redisDMMessagesKey(dmId), message.ID.String(), data).Err()
+}
+
+func (rcr *redisChatRepo) AddReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	ctx := context.Background()
+
+	if err := rcr.checkMessageExists(ctx, dmId, messageId); err != nil {
+		return err
+	}
+
+	return rcr.db.ZAddNX(ctx, redisMessageReactionsKey(messageId.String()), &redis.Z{
+		Score:  float64(time.Now().UnixMicro()),
+		Member: redisReactionMember(userId, emoji),
+	}).Err()
+}
+
+func (rcr *redisChatRepo) RemoveReaction(
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	userId uuid.UUID,
+	emoji string,
+) error {
+	ctx := context.Background()
+
+	if err := rcr.checkMessageExists(ctx, dmId, messageId); err != nil {
+		return err
+	}
+
+	return rcr.db.ZRem(ctx, redisMessageReactionsKey(messageId.String()), redisReactionMember(userId, emoji)).Err()
+}
+
+func redisReactionMember(userId uuid.UUID, emoji string) string {
+	return fmt.Sprintf("%s:%s", userId, emoji)
+}
+
+func (rcr *redisChatRepo) checkMessageExists(ctx context.Context, dmId uuid.UUID, messageId uuid.UUID) error {
+	exists, err := rcr.db.HExists(ctx, redisDMMessagesKey(dmId), messageId.String()).Result()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &repository.ErrMessageNotFound{MessageId: messageId}
+	}
+
+	return nil
+}
+
+// there is no index of the contents in redis, so the messages of the dms of
+// the user are scanned
+func (rcr *redisChatRepo) SearchMessages(
+	query *repository.MessageSearchQuery,
+) ([]*repository.MessageSearchResult, error) {
+	ctx := context.Background()
+
+	dms, err := rcr.GetDMsByPartUserId(query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := newKeywordPattern(query.Keyword)
+	results := []*repository.MessageSearchResult{}
+	for _, dm := range dms {
+		if query.PartnerId != uuid.Nil && dm.Creator.ID != query.PartnerId && dm.Receiver.ID != query.PartnerId {
+			continue
+		}
+
+		messageIds, err := rcr.db.HKeys(ctx, redisDMMessagesKey(dm.ID)).Result()
+		if err != nil {
+			return nil, err
+		}
+		messages, err := rcr.getMessages(ctx, redisDMMessagesKey(dm.ID), messageIds)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, message := range messages {
+			if matchSearchQuery(query, pattern, dm, message) {
+				results = append(results, &repository.MessageSearchResult{
+					DMId:    dm.ID,
+					Message: message,
+					Snippet: buildSnippet(message.Content, pattern),
+				})
+			}
+		}
+	}
+
+	return paginateSearchResults(query, results), nil
+}
+
+func (rcr *redisChatRepo) GetGroupChatByGroupId(groupId uuid.UUID) (*chat.GroupChat, error) {
+	ctx := context.Background()
+
+	groupChatId, err := rcr.db.Get(ctx, redisGroupChatIdKey(groupId)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, &repository.ErrGroupChatNotFound{GroupId: groupId}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := rcr.db.HGetAll(ctx, redisGroupChatKey(uuid.MustParse(groupChatId))).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, &repository.ErrGroupChatNotFound{GroupId: groupId}
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, fields["created_at"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &chat.GroupChat{
+		ID:        uuid.MustParse(groupChatId),
+		GroupId:   groupId,
+		Messages:  []*chat.Message{},
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (rcr *redisChatRepo) SaveGroupChat(groupChat *chat.GroupChat) error {
+	ctx := context.Background()
+
+	_, err := rcr.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, redisGroupChatKey(groupChat.ID), map[string]interface{}{
+			"id":         groupChat.ID.String(),
+			"group_id":   groupChat.GroupId.String(),
+			"created_at": groupChat.CreatedAt.Format(time.RFC3339Nano),
+		})
+		pipe.Set(ctx, redisGroupChatIdKey(groupChat.GroupId), groupChat.ID.String(), 0)
+
+		// the saved messages may be edited or deleted since they are loaded
+		for _, message := range groupChat.Messages {
+			if err := addRedisMessage(
+				ctx,
+				pipe,
+				redisGroupChatTimelineKey(groupChat.ID),
+				redisGroupChatMessagesKey(groupChat.ID),
+				message,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (rcr *redisChatRepo) AddGroupMessage(groupChatId uuid.UUID, message *chat.Message) error {
+	ctx := context.Background()
+
+	exists, err := rcr.db.Exists(ctx, redisGroupChatKey(groupChatId)).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return &repository.ErrGroupChatNotFound{}
+	}
+
+	_, err = rcr.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		return addRedisMessage(
+			ctx,
+			pipe,
+			redisGroupChatTimelineKey(groupChatId),
+			redisGroupChatMessagesKey(groupChatId),
+			message,
+		)
+	})
+
+	return err
+}
+
+func (rcr *redisChatRepo) ListGroupMessages(
+	groupChatId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]*chat.Message, error) {
+	return rcr.listMessages(
+		context.Background(),
+		redisGroupChatTimelineKey(groupChatId),
+		redisGroupChatMessagesKey(groupChatId),
+		before,
+		limit,
+	)
+}
+
+func (rcr *redisChatRepo) IncrUnreadCount(userId uuid.UUID, dmId uuid.UUID) error {
+	return rcr.db.HIncrBy(context.Background(), redisUserUnreadKey(userId), dmId.String(), 1).Err()
+}
+
+func (rcr *redisChatRepo) GetUnreadCounts(userId uuid.UUID) (map[uuid.UUID]int, error) {
+	fields, err := rcr.db.HGetAll(context.Background(), redisUserUnreadKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[uuid.UUID]int{}
+	for dmId, value := range fields {
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			counts[uuid.MustParse(dmId)] = count
+		}
+	}
+
+	return counts, nil
+}
+
+func (rcr *redisChatRepo) RecountUnreadCount(userId uuid.UUID, dmId uuid.UUID, readUntil time.Time) error {
+	ctx := context.Background()
+
+	messageIds, err := rcr.db.ZRangeByScore(ctx, redisDMTimelineKey(dmId), &redis.ZRangeBy{
+		Min: fmt.Sprintf("(%d", readUntil.UnixMicro()),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	messages, err := rcr.getMessages(ctx, redisDMMessagesKey(dmId), messageIds)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, message := range messages {
+		if message.OwnerId != userId && !message.IsDeleted() {
+			count++
+		}
+	}
+
+	if count == 0 {
+		return rcr.db.HDel(ctx, redisUserUnreadKey(userId), dmId.String()).Err()
+	}
+
+	return rcr.db.HSet(ctx, redisUserUnreadKey(userId), dmId.String(), count).Err()
+}
+
+func (rcr *redisChatRepo) SaveReceipt(receipt *chat.Receipt) error {
+	ctx := context.Background()
+	key := redisDMReceiptsKey(receipt.DMId)
+	field := fmt.Sprintf("%s:%d", receipt.UserId, receipt.Status)
+
+	data, err := json.Marshal(redisReceipt{
+		UserId:           receipt.UserId,
+		MessageId:        receipt.MessageId,
+		MessageTimestamp: receipt.MessageTimestamp,
+		Status:           receipt.Status,
+		CreatedAt:        receipt.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	// compare with the saved receipt and set it atomically
+	return rcr.db.Watch(ctx, func(tx *redis.Tx) error {
+		value, err := tx.HGet(ctx, key, field).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if err == nil {
+			saved := redisReceipt{}
+			if err := json.Unmarshal([]byte(value), &saved); err != nil {
+				return err
+			}
+			if !receipt.MessageTimestamp.After(saved.MessageTimestamp) {
+				return nil
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, field, data)
+			return nil
+		})
+		return err
+	}, key)
+}
+
+func (rcr *redisChatRepo) GetReceipts(dmId uuid.UUID) ([]*chat.Receipt, error) {
+	values, err := rcr.db.HVals(context.Background(), redisDMReceiptsKey(dmId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := []*chat.Receipt{}
+	for _, value := range values {
+		receipt := redisReceipt{}
+		if err := json.Unmarshal([]byte(value), &receipt); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, &chat.Receipt{
+			DMId:             dmId,
+			UserId:           receipt.UserId,
+			MessageId:        receipt.MessageId,
+			MessageTimestamp: receipt.MessageTimestamp,
+			Status:           receipt.Status,
+			CreatedAt:        receipt.CreatedAt,
+		})
+	}
+
+	return receipts, nil
+}
+
+// the pending message refers to the saved message, so the edits and the
+// deletion after it is queued are synced as well
+func (rcr *redisChatRepo) AddPendingMessage(userId uuid.UUID, pending *chat.PendingMessage) error {
+	return rcr.db.ZAdd(context.Background(), redisUserPendingKey(userId), &redis.Z{
+		Score:  float64(pending.Message.Timestamp.UnixMicro()),
+		Member: redisPendingMember(pending.DMId, pending.Message.ID),
+	}).Err()
+}
+
+func (rcr *redisChatRepo) GetPendingMessages(userId uuid.UUID, since time.Time) ([]*chat.PendingMessage, error) {
+	ctx := context.Background()
+
+	min := "-inf"
+	if !since.IsZero() {
+		min = fmt.Sprintf("(%d", since.UnixMicro())
+	}
+	members, err := rcr.db.ZRangeByScore(ctx, redisUserPendingKey(userId), &redis.ZRangeBy{
+		Min: min,
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pendingMessages := []*chat.PendingMessage{}
+	for _, member := range members {
+		dmId, messageId, ok := parseRedisPendingMember(member)
+		if !ok {
+			logrus.Warn("invalid pending message: ", member)
+			continue
+		}
+
+		messages, err := rcr.getMessages(ctx, redisDMMessagesKey(dmId), []string{messageId.String()})
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			// the dm of the message no longer exists
+			continue
+		}
+		pendingMessages = append(pendingMessages, chat.NewPendingMessage(dmId, messages[0]))
+	}
+
+	return pendingMessages, nil
+}
+
+func redisPendingMember(dmId uuid.UUID, messageId uuid.UUID) string {
+	return fmt.Sprintf("%s:%s", dmId, messageId)
+}
+
+func parseRedisPendingMember(member string) (uuid.UUID, uuid.UUID, bool) {
+	dmIdStr, messageIdStr, _ := strings.Cut(member, ":")
+	dmId, err := uuid.Parse(dmIdStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+	messageId, err := uuid.Parse(messageIdStr)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return dmId, messageId, true
+}
+
+func (rcr *redisChatRepo) RemovePendingMessages(userId uuid.UUID, until time.Time) error {
+	return rcr.db.ZRemRangeByScore(
+		context.Background(),
+		redisUserPendingKey(userId),
+		"-inf",
+		strconv.FormatInt(until.UnixMicro(), 10),
+	).Err()
+}
+
+func NewRedisChatRepository(db *redis.Client, userRepo repository.UserRepo) repository.ChatRepo {
+	return &redisChatRepo{db, userRepo}
 }
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"mashu.example/internal/adapter/datamapper/session_data_mapper"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+)
+
+type sessionRepo struct {
+	db *gorm.DB
+}
+
+func (sr *sessionRepo) GetSessionById(sessionId uuid.UUID) (*entity.Session, error) {
+	sessionData := &session_data_mapper.SessionDataMapper{}
+	if err := sr.db.
+		Where("sessions.id = ?", sessionId).
+		First(sessionData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrSessionNotFound{SessionId: sessionId}
+		}
+		return nil, err
+	}
+
+	return sessionData.ToSession(), nil
+}
+
+func (sr *sessionRepo) GetSessionByRefreshTokenHash(refreshTokenHash string) (*entity.Session, error) {
+	sessionData := &session_data_mapper.SessionDataMapper{}
+	if err := sr.db.
+		Where("sessions.refresh_token_hash = ?", refreshTokenHash).
+		First(sessionData).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &repository.ErrSessionNotFound{}
+		}
+		return nil, err
+	}
+
+	return sessionData.ToSession(), nil
+}
+
+func (sr *sessionRepo) GetSessionsByUserId(userId uuid.UUID) ([]*entity.Session, error) {
+	sessionDataMappers := []*session_data_mapper.SessionDataMapper{}
+	if err := sr.db.
+		Where("sessions.user_id = ?", userId).
+		Find(&sessionDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	sessions := []*entity.Session{}
+	for _, session := range sessionDataMappers {
+		sessions = append(sessions, session.ToSession())
+	}
+
+	return sessions, nil
+}
+
+func (sr *sessionRepo) Save(session *entity.Session) error {
+	return sr.db.Save(session_data_mapper.NewSessionDataMapper(session)).Error
+}
+
+func (sr *sessionRepo) RotateRefreshToken(session *entity.Session, oldRefreshTokenHash string) error {
+	result := sr.db.
+		Model(&session_data_mapper.SessionDataMapper{}).
+		Where("sessions.id = ? AND sessions.refresh_token_hash = ?", session.ID, oldRefreshTokenHash).
+		Where("sessions.revoked_at IS NULL").
+		Updates(map[string]interface{}{
+			"refresh_token_hash": session.RefreshTokenHash,
+			"expired_at":         session.ExpiredAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return &repository.ErrSessionNotFound{SessionId: session.ID}
+	}
+
+	return nil
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepo {
+	db.AutoMigrate(&session_data_mapper.SessionDataMapper{})
+
+	return &sessionRepo{db}
+}
@@ -0,0 +1,117 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg"
+)
+
+func sessionRepoImpls() map[string]func() repository.SessionRepo {
+	return map[string]func() repository.SessionRepo{
+		"gorm": func() repository.SessionRepo {
+			return adapter_repository.NewSessionRepository(pkg.NewMemoryGormClient())
+		},
+		"memory": adapter_repository.NewMemSessionRepository,
+	}
+}
+
+func TestSaveAndGetSession(t *testing.T) {
+	for name, newRepo := range sessionRepoImpls() {
+		t.Run(name, func(t *testing.T) {
+			sessionRepo := newRepo()
+
+			userId := uuid.New()
+			session := entity.NewSession(uuid.New(), userId, "hash", time.Now().Add(time.Hour))
+			assert.Nil(t, sessionRepo.Save(session))
+
+			result, err := sessionRepo.GetSessionById(session.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, userId, result.UserId)
+			assert.Equal(t, "hash", result.RefreshTokenHash)
+			assert.True(t, result.IsActive())
+
+			result, err = sessionRepo.GetSessionByRefreshTokenHash("hash")
+			assert.Nil(t, err)
+			assert.Equal(t, session.ID, result.ID)
+
+			// rotate and revoke
+			result.Rotate("new hash", time.Now().Add(time.Hour))
+			result.Revoke()
+			assert.Nil(t, sessionRepo.Save(result))
+
+			_, err = sessionRepo.GetSessionByRefreshTokenHash("hash")
+			assert.IsType(t, &repository.ErrSessionNotFound{}, err)
+
+			result, err = sessionRepo.GetSessionByRefreshTokenHash("new hash")
+			assert.Nil(t, err)
+			assert.False(t, result.IsActive())
+		})
+	}
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	for name, newRepo := range sessionRepoImpls() {
+		t.Run(name, func(t *testing.T) {
+			sessionRepo := newRepo()
+
+			session := entity.NewSession(uuid.New(), uuid.New(), "hash", time.Now().Add(time.Hour))
+			assert.Nil(t, sessionRepo.Save(session))
+
+			// two requests load the session with the same token
+			first, _ := sessionRepo.GetSessionByRefreshTokenHash("hash")
+			second, _ := sessionRepo.GetSessionByRefreshTokenHash("hash")
+
+			first.Rotate("first hash", time.Now().Add(2*time.Hour))
+			assert.Nil(t, sessionRepo.RotateRefreshToken(first, "hash"))
+
+			second.Rotate("second hash", time.Now().Add(2*time.Hour))
+			err := sessionRepo.RotateRefreshToken(second, "hash")
+			assert.IsType(t, &repository.ErrSessionNotFound{}, err)
+
+			result, err := sessionRepo.GetSessionById(session.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, "first hash", result.RefreshTokenHash)
+
+			// the token of a revoked session can't be rotated
+			result.Revoke()
+			assert.Nil(t, sessionRepo.Save(result))
+			result.Rotate("third hash", time.Now().Add(2*time.Hour))
+			err = sessionRepo.RotateRefreshToken(result, "first hash")
+			assert.IsType(t, &repository.ErrSessionNotFound{}, err)
+		})
+	}
+}
+
+func TestGetSessionsByUserId(t *testing.T) {
+	for name, newRepo := range sessionRepoImpls() {
+		t.Run(name, func(t *testing.T) {
+			sessionRepo := newRepo()
+
+			userId := uuid.New()
+			sessionRepo.Save(entity.NewSession(uuid.New(), userId, "hash1", time.Now().Add(time.Hour)))
+			sessionRepo.Save(entity.NewSession(uuid.New(), userId, "hash2", time.Now().Add(time.Hour)))
+			sessionRepo.Save(entity.NewSession(uuid.New(), uuid.New(), "hash3", time.Now().Add(time.Hour)))
+
+			sessions, err := sessionRepo.GetSessionsByUserId(userId)
+			assert.Nil(t, err)
+			assert.Len(t, sessions, 2)
+		})
+	}
+}
+
+func TestGetNonExistSession(t *testing.T) {
+	for name, newRepo := range sessionRepoImpls() {
+		t.Run(name, func(t *testing.T) {
+			sessionRepo := newRepo()
+
+			_, err := sessionRepo.GetSessionById(uuid.New())
+			assert.IsType(t, &repository.ErrSessionNotFound{}, err)
+		})
+	}
+}
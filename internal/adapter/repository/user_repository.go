@@ -2,10 +2,13 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"mashu.example/internal/adapter/datamapper/user_data_mapper"
 
 	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
 	"gorm.io/gorm"
 	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase/repository"
@@ -24,14 +27,9 @@ func (ur *userRepo) GetUserById(userId uuid.UUID) (*entity.User, error) {
 		return nil, err
 	}
 
-	// get follow relation
-	if err := ur.db.
-		Joins("JOIN users ON (follows.follower_id = users.id OR follows.user_id = users.id)").
-		Where("users.id = ?", userId).
-		First(&userData.Follows).Error; err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, err
-		}
+	// get follow relation and the blocked and muted users
+	if err := ur.loadRelations(userData); err != nil {
+		return nil, err
 	}
 	user := userData.ToUser()
 
@@ -46,20 +44,35 @@ func (ur *userRepo) GetUserByUserName(username string) (*entity.User, error) {
 		return nil, err
 	}
 
-	// get follow relation
-	if err := ur.db.
-		Joins("JOIN users ON (follows.follower_id = users.id OR follows.user_id = users.id)").
-		Where("users.name = ?", username).
-		First(&userData.Follows).Error; err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, err
-		}
+	// get follow relation and the blocked and muted users
+	if err := ur.loadRelations(userData); err != nil {
+		return nil, err
 	}
 	user := userData.ToUser()
 
 	return user, nil
 }
 
+// load all the follow relations in which the user is either side, and the
+// users blocked or muted by the user
+func (ur *userRepo) loadRelations(userData *user_data_mapper.UserDataMapper) error {
+	if err := ur.db.
+		Where("follows.user_id = ? OR follows.follower_id = ?", userData.ID, userData.ID).
+		Find(&userData.Follows).Error; err != nil {
+		return err
+	}
+
+	if err := ur.db.
+		Where("blocks.user_id = ?", userData.ID).
+		Find(&userData.Blocks).Error; err != nil {
+		return err
+	}
+
+	return ur.db.
+		Where("mutes.user_id = ?", userData.ID).
+		Find(&userData.Mutes).Error
+}
+
 func (ur *userRepo) Save(user *entity.User) error {
 	// build follow status
 	var followDataMappers []*user_data_mapper.FollowDataMapper
@@ -91,15 +104,51 @@ func (ur *userRepo) Save(user *entity.User) error {
 		))
 	}
 
+	var blockDataMappers []*user_data_mapper.BlockDataMapper
+	for _, blocked := range user.BlockedUsers {
+		blockDataMappers = append(blockDataMappers, user_data_mapper.NewBlockDataMapper(user.ID, blocked))
+	}
+
+	var muteDataMappers []*user_data_mapper.MuteDataMapper
+	for _, muted := range user.MutedUsers {
+		muteDataMappers = append(muteDataMappers, user_data_mapper.NewMuteDataMapper(user.ID, muted))
+	}
+
 	// save user
 	userDataMapper := user_data_mapper.NewUserDataMapper(user)
 	if err := ur.db.Transaction(func(tx *gorm.DB) error {
-		if err := ur.db.Save(userDataMapper).Error; err != nil {
+		if err := tx.Save(userDataMapper).Error; err != nil {
+			// the username is the only unique column besides the primary key
+			var sqliteErr sqlite3.Error
+			if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+				return &repository.ErrUserNameTaken{UserName: user.UserName}
+			}
+			return err
+		}
+
+		if err := ur.saveFollows(tx, user.ID, followDataMappers); err != nil {
 			return err
 		}
 
-		if len(followDataMappers) != 0 {
-			if err := ur.db.Save(followDataMappers).Error; err != nil {
+		// the blocked and muted users are replaced by the ones held by the user
+		if err := tx.
+			Where("user_id = ?", user.ID).
+			Delete(&user_data_mapper.BlockDataMapper{}).Error; err != nil {
+			return err
+		}
+		if len(blockDataMappers) != 0 {
+			if err := tx.Save(blockDataMappers).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.
+			Where("user_id = ?", user.ID).
+			Delete(&user_data_mapper.MuteDataMapper{}).Error; err != nil {
+			return err
+		}
+		if len(muteDataMappers) != 0 {
+			if err := tx.Save(muteDataMappers).Error; err != nil {
 				return err
 			}
 		}
@@ -112,8 +161,168 @@ func (ur *userRepo) Save(user *entity.User) error {
 	return nil
 }
 
+func (ur *userRepo) GetUsersByIds(userIds []uuid.UUID) ([]*entity.User, error) {
+	users := []*entity.User{}
+	if len(userIds) == 0 {
+		return users, nil
+	}
+
+	userDataMappers := []*user_data_mapper.UserDataMapper{}
+	if err := ur.db.
+		Where("users.id IN ?", userIds).
+		Find(&userDataMappers).Error; err != nil {
+		return nil, err
+	}
+
+	for _, userData := range userDataMappers {
+		users = append(users, userData.ToUser())
+	}
+
+	return users, nil
+}
+
+func (ur *userRepo) GetFollowers(
+	userId uuid.UUID,
+	cursor repository.FollowCursor,
+	limit int,
+) (*repository.FollowPage, error) {
+	return ur.getFollowPage("user_id", "follower_id", userId, cursor, limit)
+}
+
+func (ur *userRepo) GetFollowings(
+	userId uuid.UUID,
+	cursor repository.FollowCursor,
+	limit int,
+) (*repository.FollowPage, error) {
+	return ur.getFollowPage("follower_id", "user_id", userId, cursor, limit)
+}
+
+// get the page of the users in the follow relations and the total count in a
+// single query, the count is left joined with the page so that it is still
+// returned if the page is empty
+//
+// - userColumn: the column of the follows table matching the user
+// - otherColumn: the column of the follows table pointing to the users in the page
+func (ur *userRepo) getFollowPage(
+	userColumn string,
+	otherColumn string,
+	userId uuid.UUID,
+	cursor repository.FollowCursor,
+	limit int,
+) (*repository.FollowPage, error) {
+	type followPageRow struct {
+		Total       int64
+		ID          *uuid.UUID
+		Name        *string
+		DisplayName *string
+		Email       *string
+		Public      *bool
+		FollowedAt  *time.Time
+	}
+
+	query := fmt.Sprintf(`
+		SELECT counts.total, page.*
+		FROM (
+			SELECT COUNT(*) AS total FROM follows
+			WHERE follows.%[1]s = ? AND follows.status = ?
+		) counts
+		LEFT JOIN (
+			SELECT users.id, users.name, users.display_name, users.email, users.public,
+				follows.created_at AS followed_at
+			FROM follows JOIN users ON users.id = follows.%[2]s
+			WHERE follows.%[1]s = ? AND follows.status = ?
+				AND (? OR follows.created_at < ? OR (follows.created_at = ? AND users.id < ?))
+			ORDER BY follows.created_at DESC, users.id DESC
+			LIMIT ?
+		) page ON 1 = 1
+		ORDER BY page.followed_at DESC, page.id DESC`,
+		userColumn,
+		otherColumn,
+	)
+
+	rows := []*followPageRow{}
+	if err := ur.db.Raw(
+		query,
+		userId, user_data_mapper.FOLLOWING,
+		userId, user_data_mapper.FOLLOWING,
+		cursor.IsZero(), cursor.FollowedAt.UTC(), cursor.FollowedAt.UTC(), cursor.UserId,
+		limit,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	page := &repository.FollowPage{Items: []*repository.FollowPageItem{}}
+	for _, row := range rows {
+		page.Total = row.Total
+
+		// the only row of an empty page carries the count only
+		if row.ID == nil {
+			continue
+		}
+		page.Items = append(page.Items, &repository.FollowPageItem{
+			User:       entity.NewUser(*row.ID, *row.Name, *row.DisplayName, *row.Email, *row.Public),
+			FollowedAt: *row.FollowedAt,
+		})
+	}
+
+	return page, nil
+}
+
+// sync the follow relations of the user to the given ones. the user holds all
+// of its follow relations, so the relations missing from the user are
+// unfollowed, removed or withdrawn. the unchanged relations are kept as is to
+// preserve the time they are created, which orders the follower pages
+func (ur *userRepo) saveFollows(tx *gorm.DB, userId uuid.UUID, follows []*user_data_mapper.FollowDataMapper) error {
+	existingFollows := []*user_data_mapper.FollowDataMapper{}
+	if err := tx.
+		Where("user_id = ? OR follower_id = ?", userId, userId).
+		Find(&existingFollows).Error; err != nil {
+		return err
+	}
+
+	type followKey struct{ user, follower uuid.UUID }
+	staleFollows := map[followKey]*user_data_mapper.FollowDataMapper{}
+	for _, follow := range existingFollows {
+		staleFollows[followKey{follow.User, follow.Follower}] = follow
+	}
+
+	// stored in UTC so that the times are compared in the same time zone
+	now := time.Now().UTC()
+	changedFollows := []*user_data_mapper.FollowDataMapper{}
+	for _, follow := range follows {
+		key := followKey{follow.User, follow.Follower}
+		existingFollow, ok := staleFollows[key]
+		if ok && existingFollow.Status == follow.Status {
+			delete(staleFollows, key)
+			continue
+		}
+
+		// a new relation or an accepted follow request
+		delete(staleFollows, key)
+		follow.CreatedAt = now
+		changedFollows = append(changedFollows, follow)
+	}
+
+	for _, follow := range staleFollows {
+		if err := tx.Delete(follow).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(changedFollows) != 0 {
+		return tx.Save(changedFollows).Error
+	}
+
+	return nil
+}
+
 func NewUserRepository(db *gorm.DB) repository.UserRepo {
-	db.AutoMigrate(&user_data_mapper.UserDataMapper{}, &user_data_mapper.FollowDataMapper{})
+	db.AutoMigrate(
+		&user_data_mapper.UserDataMapper{},
+		&user_data_mapper.FollowDataMapper{},
+		&user_data_mapper.BlockDataMapper{},
+		&user_data_mapper.MuteDataMapper{},
+	)
 
 	return &userRepo{db}
 }
@@ -0,0 +1,222 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg"
+)
+
+func TestSaveUserFollowRelations(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	alice := entity.NewUser(uuid.New(), "alice", "Alice", "alice@email.com", true)
+	bob := entity.NewUser(uuid.New(), "bob", "Bob", "bob@email.com", true)
+	carol := entity.NewUser(uuid.New(), "carol", "Carol", "carol@email.com", false)
+
+	// bob and carol follow alice, alice requests to follow carol
+	alice.AddFollower(bob.ID)
+	bob.AddFollowing(alice.ID)
+	alice.AddFollower(carol.ID)
+	carol.AddFollowing(alice.ID)
+	followReq := &entity.FollowRequest{From: alice.ID, To: carol.ID}
+	alice.AddFollowRequest(followReq)
+	carol.AddFollowRequest(followReq)
+	for _, user := range []*entity.User{alice, bob, carol} {
+		assert.Nil(t, userRepo.Save(user))
+	}
+
+	result, err := userRepo.GetUserById(alice.ID)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{bob.ID, carol.ID}, result.Followers)
+	assert.Len(t, result.FollowRequests, 1)
+
+	result, err = userRepo.GetUserByUserName("carol")
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{alice.ID}, result.Followings)
+	assert.Len(t, result.FollowRequests, 1)
+
+	// bob unfollows alice and alice withdraws the request
+	alice.RemoveFollower(bob.ID)
+	bob.RemoveFollowing(alice.ID)
+	alice.RemoveFollowRequest(alice.ID, carol.ID)
+	carol.RemoveFollowRequest(alice.ID, carol.ID)
+	assert.Nil(t, userRepo.Save(alice))
+	assert.Nil(t, userRepo.Save(bob))
+	assert.Nil(t, userRepo.Save(carol))
+
+	result, err = userRepo.GetUserById(alice.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{carol.ID}, result.Followers)
+	assert.Len(t, result.FollowRequests, 0)
+
+	result, err = userRepo.GetUserById(bob.ID)
+	assert.Nil(t, err)
+	assert.Len(t, result.Followings, 0)
+
+	result, err = userRepo.GetUserById(carol.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{alice.ID}, result.Followings)
+	assert.Len(t, result.FollowRequests, 0)
+}
+
+func TestSaveUserBlocksAndMutes(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	blockedIds := []uuid.UUID{uuid.New(), uuid.New()}
+	mutedId := uuid.New()
+	for _, blockedId := range blockedIds {
+		user.Block(blockedId)
+	}
+	user.Mute(mutedId)
+	assert.Nil(t, userRepo.Save(user))
+
+	result, err := userRepo.GetUserById(user.ID)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, blockedIds, result.BlockedUsers)
+	assert.Equal(t, []uuid.UUID{mutedId}, result.MutedUsers)
+
+	result.Unblock(blockedIds[0])
+	result.Unmute(mutedId)
+	assert.Nil(t, userRepo.Save(result))
+
+	result, err = userRepo.GetUserByUserName("user")
+	assert.Nil(t, err)
+	assert.Equal(t, []uuid.UUID{blockedIds[1]}, result.BlockedUsers)
+	assert.Len(t, result.MutedUsers, 0)
+}
+
+func TestGetFollowPages(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	assert.Nil(t, userRepo.Save(user))
+
+	// followed one by one, so the latest follower is the last one
+	followers := []*entity.User{}
+	for _, name := range []string{"alice", "bob", "carol"} {
+		follower := entity.NewUser(uuid.New(), name, name, name+"@email.com", true)
+		follower.AddFollowing(user.ID)
+		user.AddFollower(follower.ID)
+		assert.Nil(t, userRepo.Save(follower))
+		assert.Nil(t, userRepo.Save(user))
+		followers = append(followers, follower)
+	}
+
+	// the pending request is not counted as a follower
+	requester := entity.NewUser(uuid.New(), "dave", "dave", "dave@email.com", true)
+	followReq := &entity.FollowRequest{From: requester.ID, To: user.ID}
+	requester.AddFollowRequest(followReq)
+	user.AddFollowRequest(followReq)
+	assert.Nil(t, userRepo.Save(requester))
+	assert.Nil(t, userRepo.Save(user))
+
+	page, err := userRepo.GetFollowers(user.ID, repository.FollowCursor{}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, "carol", page.Items[0].User.UserName)
+	assert.Equal(t, "bob", page.Items[1].User.UserName)
+
+	last := page.Items[1]
+	page, err = userRepo.GetFollowers(user.ID, repository.FollowCursor{FollowedAt: last.FollowedAt, UserId: last.User.ID}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "alice", page.Items[0].User.UserName)
+
+	// the total is still counted for the page after the last one
+	last = page.Items[0]
+	page, err = userRepo.GetFollowers(user.ID, repository.FollowCursor{FollowedAt: last.FollowedAt, UserId: last.User.ID}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Items, 0)
+
+	page, err = userRepo.GetFollowings(followers[0].ID, repository.FollowCursor{}, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), page.Total)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, user.ID, page.Items[0].User.ID)
+
+	// saving the user again keeps the order of the followers
+	assert.Nil(t, userRepo.Save(user))
+	page, err = userRepo.GetFollowers(user.ID, repository.FollowCursor{}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "carol", page.Items[0].User.UserName)
+
+	page, err = userRepo.GetFollowers(uuid.New(), repository.FollowCursor{}, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), page.Total)
+	assert.Len(t, page.Items, 0)
+}
+
+func TestGetUsersByIds(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	alice := entity.NewUser(uuid.New(), "alice", "Alice", "alice@email.com", true)
+	bob := entity.NewUser(uuid.New(), "bob", "Bob", "bob@email.com", false)
+	assert.Nil(t, userRepo.Save(alice))
+	assert.Nil(t, userRepo.Save(bob))
+
+	users, err := userRepo.GetUsersByIds([]uuid.UUID{alice.ID, bob.ID, uuid.New()})
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.ElementsMatch(t, []string{"alice", "bob"}, []string{users[0].UserName, users[1].UserName})
+
+	users, err = userRepo.GetUsersByIds(nil)
+	assert.Nil(t, err)
+	assert.Len(t, users, 0)
+}
+
+func TestSaveUserProfile(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	assert.Nil(t, userRepo.Save(user))
+
+	result, err := userRepo.GetUserById(user.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", result.Bio)
+	assert.Nil(t, result.Birthday)
+
+	birthday := time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC)
+	result.UserName = "newname"
+	result.Bio = "hello"
+	result.AvatarURL = "https://example.com/avatar.png"
+	result.Location = "Taipei"
+	result.Website = "https://example.com"
+	result.Birthday = &birthday
+	assert.Nil(t, userRepo.Save(result))
+
+	result, err = userRepo.GetUserByUserName("newname")
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID, result.ID)
+	assert.Equal(t, "hello", result.Bio)
+	assert.Equal(t, "https://example.com/avatar.png", result.AvatarURL)
+	assert.Equal(t, "Taipei", result.Location)
+	assert.Equal(t, "https://example.com", result.Website)
+	assert.True(t, birthday.Equal(*result.Birthday))
+}
+
+func TestSaveUserWithTakenUserName(t *testing.T) {
+	userRepo := adapter_repository.NewUserRepository(pkg.NewMemoryGormClient())
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	other := entity.NewUser(uuid.New(), "other", "Other", "other@email.com", true)
+	assert.Nil(t, userRepo.Save(user))
+	assert.Nil(t, userRepo.Save(other))
+
+	other.UserName = "user"
+	err := userRepo.Save(other)
+	assert.IsType(t, &repository.ErrUserNameTaken{}, err)
+
+	result, err := userRepo.GetUserById(other.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "other", result.UserName)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// handler of the messages published to a user
+type MessageBusHandler func(userId uuid.UUID, data []byte)
+
+// bus to route the websocket messages to the instance holding the sockets of
+// the user, every subscribed instance receives all the published messages
+type MessageBus interface {
+	// encode the message as json and publish it to the user
+	Publish(userId uuid.UUID, message interface{}) error
+	// handle the messages published after it returns
+	Subscribe(handler MessageBusHandler) error
+	Close() error
+}
+
+// message bus of a single process
+type memMessageBus struct {
+	mu       sync.RWMutex
+	handlers []MessageBusHandler
+}
+
+func (b *memMessageBus) Publish(userId uuid.UUID, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(userId, data)
+	}
+
+	return nil
+}
+
+func (b *memMessageBus) Subscribe(handler MessageBusHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+
+	return nil
+}
+
+func (b *memMessageBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers = nil
+
+	return nil
+}
+
+func NewMemMessageBus() MessageBus {
+	return &memMessageBus{}
+}
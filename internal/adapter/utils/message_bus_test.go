@@ -0,0 +1,101 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/adapter/utils"
+)
+
+type busMessage struct {
+	userId uuid.UUID
+	data   string
+}
+
+// create the buses of two instances sharing the same backend
+func messageBusImpls(t *testing.T) map[string]func() (utils.MessageBus, utils.MessageBus) {
+	return map[string]func() (utils.MessageBus, utils.MessageBus){
+		"memory": func() (utils.MessageBus, utils.MessageBus) {
+			bus := utils.NewMemMessageBus()
+			return bus, bus
+		},
+		"redis": func() (utils.MessageBus, utils.MessageBus) {
+			server := miniredis.RunT(t)
+			newBus := func() utils.MessageBus {
+				client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+				return utils.NewRedisMessageBus(client, utils.DefaultMessageBusChannel)
+			}
+			return newBus(), newBus()
+		},
+	}
+}
+
+func subscribe(t *testing.T, bus utils.MessageBus) chan busMessage {
+	messages := make(chan busMessage, 10)
+	assert.Nil(t, bus.Subscribe(func(userId uuid.UUID, data []byte) {
+		messages <- busMessage{userId, string(data)}
+	}))
+
+	return messages
+}
+
+func receive(t *testing.T, messages chan busMessage) busMessage {
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return busMessage{}
+	}
+}
+
+func TestPublishToAllInstances(t *testing.T) {
+	for name, newBuses := range messageBusImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			busA, busB := newBuses()
+			defer busA.Close()
+			defer busB.Close()
+
+			messagesA := subscribe(t, busA)
+			messagesB := subscribe(t, busB)
+
+			userId := uuid.New()
+			assert.Nil(t, busA.Publish(userId, map[string]string{"message": "hi"}))
+
+			for _, messages := range []chan busMessage{messagesA, messagesB} {
+				message := receive(t, messages)
+				assert.Equal(t, userId, message.userId)
+				assert.JSONEq(t, `{"message": "hi"}`, message.data)
+			}
+		})
+	}
+}
+
+func TestDeliverToHubOfAnotherInstance(t *testing.T) {
+	for name, newBuses := range messageBusImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			busA, busB := newBuses()
+			defer busA.Close()
+			defer busB.Close()
+
+			// the receiver is connected to the instance B only
+			hubB, url := setupHub(t, utils.NewDefaultWebSocketConfig())
+			assert.Nil(t, busB.Subscribe(hubB.SendDataToUser))
+
+			receiverId := uuid.New()
+			conn := dial(t, url, receiverId)
+			assert.Eventually(t, isRegistered(hubB, receiverId), time.Second, 10*time.Millisecond)
+
+			assert.Nil(t, busA.Publish(receiverId, "hi"))
+
+			var message string
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			assert.Nil(t, conn.ReadJSON(&message))
+			assert.Equal(t, "hi", message)
+		})
+	}
+}
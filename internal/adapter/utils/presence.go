@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Presence struct {
+	UserId   uuid.UUID
+	Online   bool
+	LastSeen time.Time // zero if the user has never connected
+}
+
+// store of the online connections of the users, a connection expires if it
+// is not touched by the heartbeats within the ttl, so the connections of a
+// crashed instance go offline eventually
+type PresenceStore interface {
+	// mark the connection of the user online until the ttl passes
+	Touch(userId uuid.UUID, connectionId uuid.UUID, ttl time.Duration) error
+	// mark the connection of the user offline
+	Remove(userId uuid.UUID, connectionId uuid.UUID) error
+	Get(userId uuid.UUID) (*Presence, error)
+}
+
+type memPresenceStore struct {
+	mu          sync.Mutex
+	connections map[uuid.UUID]map[uuid.UUID]time.Time // user id to the expiry time by connection id
+	lastSeen    map[uuid.UUID]time.Time
+}
+
+func (s *memPresenceStore) Touch(userId uuid.UUID, connectionId uuid.UUID, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := s.connections[userId]; !ok {
+		s.connections[userId] = map[uuid.UUID]time.Time{}
+	}
+	s.connections[userId][connectionId] = now.Add(ttl)
+	s.lastSeen[userId] = now
+
+	return nil
+}
+
+func (s *memPresenceStore) Remove(userId uuid.UUID, connectionId uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.connections[userId][connectionId]; !ok {
+		return nil
+	}
+	delete(s.connections[userId], connectionId)
+	if len(s.connections[userId]) == 0 {
+		delete(s.connections, userId)
+	}
+	s.lastSeen[userId] = time.Now()
+
+	return nil
+}
+
+func (s *memPresenceStore) Get(userId uuid.UUID) (*Presence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	online := false
+	for connectionId, expiresAt := range s.connections[userId] {
+		if expiresAt.After(now) {
+			online = true
+			continue
+		}
+		delete(s.connections[userId], connectionId)
+	}
+
+	return &Presence{UserId: userId, Online: online, LastSeen: s.lastSeen[userId]}, nil
+}
+
+func NewMemPresenceStore() PresenceStore {
+	return &memPresenceStore{
+		connections: map[uuid.UUID]map[uuid.UUID]time.Time{},
+		lastSeen:    map[uuid.UUID]time.Time{},
+	}
+}
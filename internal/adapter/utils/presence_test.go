@@ -0,0 +1,73 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/adapter/utils"
+)
+
+func presenceStoreImpls(t *testing.T) map[string]func() utils.PresenceStore {
+	return map[string]func() utils.PresenceStore{
+		"memory": utils.NewMemPresenceStore,
+		"redis": func() utils.PresenceStore {
+			server := miniredis.RunT(t)
+			return utils.NewRedisPresenceStore(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+		},
+	}
+}
+
+func TestPresenceOfMultipleConnections(t *testing.T) {
+	for name, newStore := range presenceStoreImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			userId, phone, laptop := uuid.New(), uuid.New(), uuid.New()
+
+			presence, err := store.Get(userId)
+			assert.Nil(t, err)
+			assert.False(t, presence.Online)
+			assert.True(t, presence.LastSeen.IsZero())
+
+			assert.Nil(t, store.Touch(userId, phone, time.Minute))
+			assert.Nil(t, store.Touch(userId, laptop, time.Minute))
+
+			// online until the last connection is removed
+			assert.Nil(t, store.Remove(userId, phone))
+			presence, err = store.Get(userId)
+			assert.Nil(t, err)
+			assert.True(t, presence.Online)
+
+			assert.Nil(t, store.Remove(userId, laptop))
+			presence, err = store.Get(userId)
+			assert.Nil(t, err)
+			assert.Equal(t, userId, presence.UserId)
+			assert.False(t, presence.Online)
+			assert.WithinDuration(t, time.Now(), presence.LastSeen, time.Second)
+		})
+	}
+}
+
+func TestPresenceExpiry(t *testing.T) {
+	for name, newStore := range presenceStoreImpls(t) {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			userId, connectionId := uuid.New(), uuid.New()
+
+			assert.Nil(t, store.Touch(userId, connectionId, 50*time.Millisecond))
+			presence, err := store.Get(userId)
+			assert.Nil(t, err)
+			assert.True(t, presence.Online)
+
+			// the connection expires without the heartbeats
+			time.Sleep(100 * time.Millisecond)
+			presence, err = store.Get(userId)
+			assert.Nil(t, err)
+			assert.False(t, presence.Online)
+			assert.False(t, presence.LastSeen.IsZero())
+		})
+	}
+}
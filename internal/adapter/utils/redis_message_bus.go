@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// the default redis channel of the websocket messages
+const DefaultMessageBusChannel = "ws:messages"
+
+// message published to the redis channel
+type redisBusMessage struct {
+	UserId uuid.UUID       `json:"userId"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// message bus over redis pub/sub, so the instances behind a load balancer can
+// deliver the messages to the sockets held by each other
+type redisMessageBus struct {
+	db      *redis.Client
+	channel string
+
+	mu      sync.Mutex
+	pubsubs []*redis.PubSub
+}
+
+func (b *redisMessageBus) Publish(userId uuid.UUID, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	busMessage, err := json.Marshal(redisBusMessage{userId, data})
+	if err != nil {
+		return err
+	}
+
+	return b.db.Publish(context.Background(), b.channel, busMessage).Err()
+}
+
+func (b *redisMessageBus) Subscribe(handler MessageBusHandler) error {
+	ctx := context.Background()
+
+	pubsub := b.db.Subscribe(ctx, b.channel)
+	// wait for the confirmation, so no message published afterwards is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return err
+	}
+
+	b.mu.Lock()
+	b.pubsubs = append(b.pubsubs, pubsub)
+	b.mu.Unlock()
+
+	go func() {
+		for message := range pubsub.Channel() {
+			busMessage := redisBusMessage{}
+			if err := json.Unmarshal([]byte(message.Payload), &busMessage); err != nil {
+				logrus.Error("invalid message bus message: ", err)
+				continue
+			}
+			handler(busMessage.UserId, busMessage.Data)
+		}
+	}()
+
+	return nil
+}
+
+func (b *redisMessageBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var err error
+	for _, pubsub := range b.pubsubs {
+		if closeErr := pubsub.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	b.pubsubs = nil
+
+	return err
+}
+
+func NewRedisMessageBus(db *redis.Client, channel string) MessageBus {
+	return &redisMessageBus{db: db, channel: channel}
+}
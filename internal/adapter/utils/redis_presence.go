@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// redis keys:
+// - presence:{userId}            sorted set of connection ids scored by the expiry time in ms
+// - presence:{userId}:last_seen  last seen time of the user in ms
+
+func redisPresenceKey(userId uuid.UUID) string {
+	return fmt.Sprintf("presence:%s", userId)
+}
+
+func redisLastSeenKey(userId uuid.UUID) string {
+	return fmt.Sprintf("presence:%s:last_seen", userId)
+}
+
+type redisPresenceStore struct {
+	db *redis.Client
+}
+
+func (s *redisPresenceStore) Touch(userId uuid.UUID, connectionId uuid.UUID, ttl time.Duration) error {
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := s.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, redisPresenceKey(userId), &redis.Z{
+			Score:  float64(now.Add(ttl).UnixMilli()),
+			Member: connectionId.String(),
+		})
+		pipe.Set(ctx, redisLastSeenKey(userId), now.UnixMilli(), 0)
+		return nil
+	})
+
+	return err
+}
+
+func (s *redisPresenceStore) Remove(userId uuid.UUID, connectionId uuid.UUID) error {
+	ctx := context.Background()
+
+	removed, err := s.db.ZRem(ctx, redisPresenceKey(userId), connectionId.String()).Result()
+	if err != nil || removed == 0 {
+		return err
+	}
+
+	return s.db.Set(ctx, redisLastSeenKey(userId), time.Now().UnixMilli(), 0).Err()
+}
+
+func (s *redisPresenceStore) Get(userId uuid.UUID) (*Presence, error) {
+	ctx := context.Background()
+
+	// drop the expired connections first
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if err := s.db.ZRemRangeByScore(ctx, redisPresenceKey(userId), "-inf", now).Err(); err != nil {
+		return nil, err
+	}
+	count, err := s.db.ZCard(ctx, redisPresenceKey(userId)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	presence := &Presence{UserId: userId, Online: count > 0}
+	lastSeen, err := s.db.Get(ctx, redisLastSeenKey(userId)).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	if err == nil {
+		presence.LastSeen = time.UnixMilli(lastSeen)
+	}
+
+	return presence, nil
+}
+
+func NewRedisPresenceStore(db *redis.Client) PresenceStore {
+	return &redisPresenceStore{db}
+}
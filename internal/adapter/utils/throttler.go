@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// throttler to allow an action of each key at most once in the interval
+type Throttler struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func (t *Throttler) Allow(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if last, ok := t.last[key]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+	t.last[key] = now
+
+	// drop the stale keys, so the map does not grow with the idle keys
+	for k, last := range t.last {
+		if now.Sub(last) >= t.interval {
+			delete(t.last, k)
+		}
+	}
+
+	return true
+}
+
+func NewThrottler(interval time.Duration) *Throttler {
+	return &Throttler{interval: interval, last: map[string]time.Time{}}
+}
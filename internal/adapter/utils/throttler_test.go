@@ -0,0 +1,21 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/adapter/utils"
+)
+
+func TestThrottler(t *testing.T) {
+	throttler := utils.NewThrottler(50 * time.Millisecond)
+
+	assert.True(t, throttler.Allow("a"))
+	assert.False(t, throttler.Allow("a"))
+	// the keys are throttled separately
+	assert.True(t, throttler.Allow("b"))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, throttler.Allow("a"))
+}
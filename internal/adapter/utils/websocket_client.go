@@ -1,15 +1,151 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
+var (
+	ErrClientClosed = errors.New("websocket client is closed")
+	ErrSlowConsumer = errors.New("websocket client can't keep up with the outbound messages")
+)
+
+// websocket client of a connected device of the user, a user can hold multiple
+// clients identified by their connection ids
+//
+// gorilla/websocket allows one concurrent writer only, so the messages are
+// queued by `Send` and written by the `WritePump` goroutine of the client
 type WebSocketClient struct {
+	ID     uuid.UUID // connection id
 	UserId uuid.UUID
 	Conn   *websocket.Conn
+
+	hub         *WebSocketHub
+	mu          sync.Mutex
+	send        chan []byte
+	closed      bool
+	onHeartbeat func()
+}
+
+// encode the message as json and queue it to be written to the connection,
+// the client is evicted from the hub if its outbound queue is full
+func (c *WebSocketClient) Send(message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return c.SendData(data)
+}
+
+// queue the encoded message to be written to the connection
+func (c *WebSocketClient) SendData(data []byte) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	select {
+	case c.send <- data:
+		c.mu.Unlock()
+		return nil
+	default:
+		c.mu.Unlock()
+	}
+
+	c.hub.Unregister(c)
+	return ErrSlowConsumer
+}
+
+// set the handler called on every heartbeat of the connection, it must be
+// set before `ReadPump` is started
+func (c *WebSocketClient) OnHeartbeat(handler func()) {
+	c.onHeartbeat = handler
+}
+
+// read the incoming messages until the connection is closed or the heartbeat
+// times out, the client is unregistered from the hub once it returns
+func (c *WebSocketClient) ReadPump(handle func(data []byte)) {
+	defer func() {
+		c.hub.Unregister(c)
+		c.Conn.Close()
+	}()
+
+	config := c.hub.config
+	c.Conn.SetReadLimit(config.MaxMessageSize)
+	c.Conn.SetReadDeadline(time.Now().Add(config.PongWait))
+	c.Conn.SetPongHandler(func(string) error {
+		if c.onHeartbeat != nil {
+			c.onHeartbeat()
+		}
+		return c.Conn.SetReadDeadline(time.Now().Add(config.PongWait))
+	})
+
+	for {
+		_, data, err := c.Conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		handle(data)
+	}
+}
+
+// write the queued messages and the heartbeat pings to the connection until
+// the client is closed
+func (c *WebSocketClient) WritePump() {
+	config := c.hub.config
+	ticker := time.NewTicker(config.PingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.Conn.Close()
+	}()
+
+	for {
+		select {
+		case data, ok := <-c.send:
+			c.Conn.SetWriteDeadline(time.Now().Add(config.WriteWait))
+			if !ok {
+				// the client is closed by the hub
+				c.Conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.Conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.hub.Unregister(c)
+				return
+			}
+		case <-ticker.C:
+			c.Conn.SetWriteDeadline(time.Now().Add(config.WriteWait))
+			if err := c.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.hub.Unregister(c)
+				return
+			}
+		}
+	}
+}
+
+// close the outbound queue, the writer closes the connection after it
+func (c *WebSocketClient) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	close(c.send)
 }
 
-func NewWebSocketClient(userId uuid.UUID, conn *websocket.Conn) *WebSocketClient {
-	return &WebSocketClient{userId, conn}
+func NewWebSocketClient(hub *WebSocketHub, userId uuid.UUID, conn *websocket.Conn) *WebSocketClient {
+	return &WebSocketClient{
+		ID:     uuid.New(),
+		UserId: userId,
+		Conn:   conn,
+		hub:    hub,
+		send:   make(chan []byte, hub.config.SendBufferSize),
+	}
 }
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebSocketConfig struct {
+	SendBufferSize int           // max number of queued outbound messages of a client
+	WriteWait      time.Duration // time allowed to write a message
+	PongWait       time.Duration // time allowed to read the next pong message
+	PingPeriod     time.Duration // period to send the pings, must be less than `PongWait`
+	MaxMessageSize int64         // max size of an incoming message
+}
+
+func NewDefaultWebSocketConfig() WebSocketConfig {
+	return WebSocketConfig{
+		SendBufferSize: 256,
+		WriteWait:      10 * time.Second,
+		PongWait:       60 * time.Second,
+		PingPeriod:     54 * time.Second,
+		MaxMessageSize: 64 * 1024,
+	}
+}
+
+type hubRequest struct {
+	client *WebSocketClient
+	done   chan struct{}
+}
+
+// hub of the connected websocket clients
+//
+// the clients are registered and unregistered by the `Run` loop only, the
+// other goroutines look up the clients by `GetClient` and `GetClients`
+type WebSocketHub struct {
+	config WebSocketConfig
+
+	mu      sync.RWMutex
+	clients map[uuid.UUID]map[uuid.UUID]*WebSocketClient // user id to the clients of the user by connection id
+
+	register   chan hubRequest
+	unregister chan hubRequest
+	stop       chan struct{}
+	stopOnce   sync.Once
+}
+
+// handle the registration until the hub is closed
+func (h *WebSocketHub) Run() {
+	for {
+		select {
+		case req := <-h.register:
+			h.mu.Lock()
+			if _, ok := h.clients[req.client.UserId]; !ok {
+				h.clients[req.client.UserId] = map[uuid.UUID]*WebSocketClient{}
+			}
+			h.clients[req.client.UserId][req.client.ID] = req.client
+			h.mu.Unlock()
+			close(req.done)
+		case req := <-h.unregister:
+			// only the connection of the client is removed, the other devices
+			// of the user stay connected
+			h.mu.Lock()
+			if clients, ok := h.clients[req.client.UserId]; ok {
+				delete(clients, req.client.ID)
+				if len(clients) == 0 {
+					delete(h.clients, req.client.UserId)
+				}
+			}
+			h.mu.Unlock()
+			req.client.close()
+			close(req.done)
+		case <-h.stop:
+			h.mu.Lock()
+			for userId, clients := range h.clients {
+				for _, client := range clients {
+					client.close()
+				}
+				delete(h.clients, userId)
+			}
+			h.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (h *WebSocketHub) Config() WebSocketConfig {
+	return h.config
+}
+
+// register the client and wait until it can be looked up
+func (h *WebSocketHub) Register(client *WebSocketClient) {
+	h.do(h.register, client)
+}
+
+// unregister and close the client, it is safe to call it more than once
+func (h *WebSocketHub) Unregister(client *WebSocketClient) {
+	h.do(h.unregister, client)
+}
+
+func (h *WebSocketHub) GetClient(userId uuid.UUID, connectionId uuid.UUID) (*WebSocketClient, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	client, ok := h.clients[userId][connectionId]
+	return client, ok
+}
+
+// get the clients of all the connected devices of the user
+func (h *WebSocketHub) GetClients(userId uuid.UUID) []*WebSocketClient {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	clients := []*WebSocketClient{}
+	for _, client := range h.clients[userId] {
+		clients = append(clients, client)
+	}
+
+	return clients
+}
+
+// send the message to all the connected devices of the user
+func (h *WebSocketHub) SendToUser(userId uuid.UUID, message interface{}) {
+	for _, client := range h.GetClients(userId) {
+		client.Send(message)
+	}
+}
+
+// send the encoded message to all the connected devices of the user, it can
+// be used as the `MessageBusHandler` to deliver the messages from the bus
+func (h *WebSocketHub) SendDataToUser(userId uuid.UUID, data []byte) {
+	for _, client := range h.GetClients(userId) {
+		client.SendData(data)
+	}
+}
+
+// stop the `Run` loop and close all the clients
+func (h *WebSocketHub) Close() {
+	h.stopOnce.Do(func() { close(h.stop) })
+}
+
+func (h *WebSocketHub) do(requests chan hubRequest, client *WebSocketClient) {
+	req := hubRequest{client, make(chan struct{})}
+	select {
+	case requests <- req:
+		<-req.done
+	case <-h.stop:
+		client.close()
+	}
+}
+
+func NewWebSocketHub(config WebSocketConfig) *WebSocketHub {
+	return &WebSocketHub{
+		config:     config,
+		clients:    map[uuid.UUID]map[uuid.UUID]*WebSocketClient{},
+		register:   make(chan hubRequest),
+		unregister: make(chan hubRequest),
+		stop:       make(chan struct{}),
+	}
+}
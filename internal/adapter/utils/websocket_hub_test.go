@@ -0,0 +1,213 @@
+package utils_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/adapter/utils"
+	"mashu.example/pkg"
+)
+
+func newTestConfig() utils.WebSocketConfig {
+	config := utils.NewDefaultWebSocketConfig()
+	config.WriteWait = time.Second
+	config.PongWait = 200 * time.Millisecond
+	config.PingPeriod = 50 * time.Millisecond
+	return config
+}
+
+// run a hub behind a test server, the user id is passed by the `userId` query
+// parameter and the incoming messages are echoed back
+func setupHub(t *testing.T, config utils.WebSocketConfig) (*utils.WebSocketHub, string) {
+	hub := utils.NewWebSocketHub(config)
+	go hub.Run()
+	t.Cleanup(hub.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := pkg.NewWebSocketUpgrader().Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		client := utils.NewWebSocketClient(hub, uuid.MustParse(r.URL.Query().Get("userId")), conn)
+		hub.Register(client)
+		go client.WritePump()
+		client.ReadPump(func(data []byte) {
+			var message string
+			json.Unmarshal(data, &message)
+			client.Send(message)
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return hub, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, url string, userId uuid.UUID) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?userId="+userId.String(), nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func isRegistered(hub *utils.WebSocketHub, userId uuid.UUID) func() bool {
+	return hasClients(hub, userId, 1)
+}
+
+func hasClients(hub *utils.WebSocketHub, userId uuid.UUID, count int) func() bool {
+	return func() bool {
+		return len(hub.GetClients(userId)) == count
+	}
+}
+
+func TestRegisterAndUnregisterClient(t *testing.T) {
+	hub, url := setupHub(t, utils.NewDefaultWebSocketConfig())
+
+	userId := uuid.New()
+	conn := dial(t, url, userId)
+	assert.Eventually(t, isRegistered(hub, userId), time.Second, 10*time.Millisecond)
+
+	// echo
+	assert.Nil(t, conn.WriteJSON("hello"))
+	var message string
+	assert.Nil(t, conn.ReadJSON(&message))
+	assert.Equal(t, "hello", message)
+
+	conn.Close()
+	assert.Eventually(t, hasClients(hub, userId, 0), time.Second, 10*time.Millisecond)
+}
+
+func TestSendConcurrently(t *testing.T) {
+	hub, url := setupHub(t, utils.NewDefaultWebSocketConfig())
+
+	userId := uuid.New()
+	conn := dial(t, url, userId)
+	assert.Eventually(t, isRegistered(hub, userId), time.Second, 10*time.Millisecond)
+	client := hub.GetClients(userId)[0]
+
+	const senders, messagesPerSender = 10, 20
+	wg := sync.WaitGroup{}
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < messagesPerSender; j++ {
+				assert.Nil(t, client.Send("message"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < senders*messagesPerSender; i++ {
+		var message string
+		assert.Nil(t, conn.ReadJSON(&message))
+		assert.Equal(t, "message", message)
+	}
+}
+
+func TestMultipleDevicesOfSameUser(t *testing.T) {
+	hub, url := setupHub(t, utils.NewDefaultWebSocketConfig())
+
+	userId := uuid.New()
+	phone := dial(t, url, userId)
+	laptop := dial(t, url, userId)
+	assert.Eventually(t, hasClients(hub, userId, 2), time.Second, 10*time.Millisecond)
+
+	clients := hub.GetClients(userId)
+	assert.NotEqual(t, clients[0].ID, clients[1].ID)
+	client, ok := hub.GetClient(userId, clients[0].ID)
+	assert.True(t, ok)
+	assert.Equal(t, clients[0], client)
+
+	// every device receives the message
+	hub.SendToUser(userId, "message")
+	for _, conn := range []*websocket.Conn{phone, laptop} {
+		var message string
+		assert.Nil(t, conn.ReadJSON(&message))
+		assert.Equal(t, "message", message)
+	}
+
+	// only the disconnected device is removed
+	phone.Close()
+	assert.Eventually(t, hasClients(hub, userId, 1), time.Second, 10*time.Millisecond)
+
+	hub.SendToUser(userId, "another message")
+	var message string
+	assert.Nil(t, laptop.ReadJSON(&message))
+	assert.Equal(t, "another message", message)
+}
+
+func TestHeartbeat(t *testing.T) {
+	hub, url := setupHub(t, newTestConfig())
+
+	// the pings are answered while the connection is read
+	userId := uuid.New()
+	conn := dial(t, url, userId)
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	// the connection without reading can't answer the pings
+	idleUserId := uuid.New()
+	dial(t, url, idleUserId)
+
+	assert.Eventually(t, isRegistered(hub, userId), time.Second, 10*time.Millisecond)
+	assert.Eventually(t, isRegistered(hub, idleUserId), time.Second, 10*time.Millisecond)
+
+	// wait for more than the pong wait
+	time.Sleep(500 * time.Millisecond)
+	assert.True(t, hasClients(hub, userId, 1)())
+	assert.True(t, hasClients(hub, idleUserId, 0)())
+}
+
+func TestEvictSlowConsumer(t *testing.T) {
+	config := utils.NewDefaultWebSocketConfig()
+	config.SendBufferSize = 2
+	hub := utils.NewWebSocketHub(config)
+	go hub.Run()
+	defer hub.Close()
+
+	// the writer is not started, so the messages stay in the queue
+	userId := uuid.New()
+	client := utils.NewWebSocketClient(hub, userId, nil)
+	hub.Register(client)
+
+	assert.Nil(t, client.Send("message 1"))
+	assert.Nil(t, client.Send("message 2"))
+	assert.ErrorIs(t, client.Send("message 3"), utils.ErrSlowConsumer)
+
+	_, ok := hub.GetClient(userId, client.ID)
+	assert.False(t, ok)
+	assert.ErrorIs(t, client.Send("message 4"), utils.ErrClientClosed)
+}
+
+func TestCloseHub(t *testing.T) {
+	hub := utils.NewWebSocketHub(utils.NewDefaultWebSocketConfig())
+	go hub.Run()
+
+	client := utils.NewWebSocketClient(hub, uuid.New(), nil)
+	hub.Register(client)
+	hub.Close()
+
+	assert.Eventually(t, func() bool {
+		return client.Send("message") == utils.ErrClientClosed
+	}, time.Second, 10*time.Millisecond)
+
+	// registering to a closed hub does not block
+	another := utils.NewWebSocketClient(hub, uuid.New(), nil)
+	hub.Register(another)
+	assert.ErrorIs(t, another.Send("message"), utils.ErrClientClosed)
+}
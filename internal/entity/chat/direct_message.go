@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 	"mashu.example/internal/entity"
 )
 
@@ -12,6 +13,84 @@ type Message struct {
 	OwnerId   uuid.UUID
 	Content   string
 	Timestamp time.Time
+	EditedAt  time.Time              // zero if the message is never edited
+	DeletedAt time.Time              // zero if the message is not deleted
+	Reactions map[uuid.UUID][]string // user id to the emojis reacted by the user
+}
+
+// add the emoji reaction of the user, it returns false if the user has reacted
+// with the emoji already
+func (m *Message) AddReaction(userId uuid.UUID, emoji string) bool {
+	if m.HasReaction(userId, emoji) {
+		return false
+	}
+	if m.Reactions == nil {
+		m.Reactions = map[uuid.UUID][]string{}
+	}
+	m.Reactions[userId] = append(m.Reactions[userId], emoji)
+
+	return true
+}
+
+// remove the emoji reaction of the user, it returns false if the user has not
+// reacted with the emoji
+func (m *Message) RemoveReaction(userId uuid.UUID, emoji string) bool {
+	idx := slices.Index(m.Reactions[userId], emoji)
+	if idx == -1 {
+		return false
+	}
+
+	m.Reactions[userId] = slices.Delete(m.Reactions[userId], idx, idx+1)
+	if len(m.Reactions[userId]) == 0 {
+		delete(m.Reactions, userId)
+	}
+
+	return true
+}
+
+func (m *Message) HasReaction(userId uuid.UUID, emoji string) bool {
+	return slices.Contains(m.Reactions[userId], emoji)
+}
+
+func (m *Message) Edit(content string) {
+	m.Content = content
+	m.EditedAt = time.Now()
+}
+
+// the deleted message is kept as a tombstone without the content
+func (m *Message) Delete() {
+	m.Content = ""
+	m.DeletedAt = time.Now()
+}
+
+func (m *Message) IsEdited() bool {
+	return !m.EditedAt.IsZero()
+}
+
+func (m *Message) IsDeleted() bool {
+	return !m.DeletedAt.IsZero()
+}
+
+// deep copy of the message, the copy does not share the reactions
+func (m *Message) Clone() *Message {
+	message := *m
+	message.Reactions = CloneReactions(m.Reactions)
+
+	return &message
+}
+
+// deep copy of the reactions, nil is kept as nil
+func CloneReactions(reactions map[uuid.UUID][]string) map[uuid.UUID][]string {
+	if reactions == nil {
+		return nil
+	}
+
+	cloned := make(map[uuid.UUID][]string, len(reactions))
+	for userId, emojis := range reactions {
+		cloned[userId] = slices.Clone(emojis)
+	}
+
+	return cloned
 }
 
 func NewMessageWithTime(
@@ -20,7 +99,7 @@ func NewMessageWithTime(
 	content string,
 	time time.Time,
 ) *Message {
-	return &Message{id, ownerId, content, time}
+	return &Message{ID: id, OwnerId: ownerId, Content: content, Timestamp: time}
 }
 
 func NewMessage(
@@ -28,7 +107,17 @@ func NewMessage(
 	ownerId uuid.UUID,
 	content string,
 ) *Message {
-	return &Message{id, ownerId, content, time.Now()}
+	return &Message{ID: id, OwnerId: ownerId, Content: content, Timestamp: time.Now()}
+}
+
+// message waiting to be delivered to a user who may be offline
+type PendingMessage struct {
+	DMId    uuid.UUID
+	Message *Message
+}
+
+func NewPendingMessage(dmId uuid.UUID, message *Message) *PendingMessage {
+	return &PendingMessage{dmId, message}
 }
 
 type DirectMessage struct {
@@ -39,9 +128,15 @@ type DirectMessage struct {
 	CreatedAt time.Time
 }
 
-func (dm *DirectMessage) AddMessage(senderId uuid.UUID, content string) {
-	messageId := uuid.New()
-	dm.Messages = append(dm.Messages, NewMessage(messageId, senderId, content))
+func (dm *DirectMessage) AddMessage(senderId uuid.UUID, content string) *Message {
+	return dm.AddMessageWithTime(senderId, content, time.Now())
+}
+
+func (dm *DirectMessage) AddMessageWithTime(senderId uuid.UUID, content string, timestamp time.Time) *Message {
+	message := NewMessageWithTime(uuid.New(), senderId, content, timestamp)
+	dm.Messages = append(dm.Messages, message)
+
+	return message
 }
 
 func NewDirectMessage(
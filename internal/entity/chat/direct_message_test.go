@@ -0,0 +1,45 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	chat "mashu.example/internal/entity/chat"
+)
+
+func TestMessageReactions(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	message := chat.NewMessage(uuid.New(), alice, "hi")
+
+	assert.True(t, message.AddReaction(alice, "👍"))
+	assert.True(t, message.AddReaction(bob, "👍"))
+	assert.True(t, message.AddReaction(bob, "🎉"))
+	// the same reaction is added once only
+	assert.False(t, message.AddReaction(bob, "🎉"))
+	assert.Equal(t, []string{"👍", "🎉"}, message.Reactions[bob])
+
+	assert.True(t, message.RemoveReaction(bob, "👍"))
+	assert.False(t, message.RemoveReaction(bob, "👍"))
+	assert.True(t, message.HasReaction(bob, "🎉"))
+	assert.False(t, message.HasReaction(bob, "👍"))
+
+	// the user without reactions is removed
+	assert.True(t, message.RemoveReaction(alice, "👍"))
+	assert.NotContains(t, message.Reactions, alice)
+}
+
+func TestCloneMessage(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	message := chat.NewMessage(uuid.New(), alice, "hi")
+	message.AddReaction(bob, "👍")
+
+	cloned := message.Clone()
+	cloned.Edit("hello")
+	cloned.AddReaction(bob, "🎉")
+	cloned.AddReaction(alice, "👍")
+
+	assert.Equal(t, "hi", message.Content)
+	assert.Equal(t, map[uuid.UUID][]string{bob: {"👍"}}, message.Reactions)
+	assert.Equal(t, []string{"👍", "🎉"}, cloned.Reactions[bob])
+}
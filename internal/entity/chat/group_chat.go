@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// chat room shared by the members of a group, the membership is not kept in
+// the chat but follows the members of the group
+type GroupChat struct {
+	ID        uuid.UUID
+	GroupId   uuid.UUID
+	Messages  []*Message
+	CreatedAt time.Time
+}
+
+func (gc *GroupChat) AddMessage(senderId uuid.UUID, content string) *Message {
+	return gc.AddMessageWithTime(senderId, content, time.Now())
+}
+
+func (gc *GroupChat) AddMessageWithTime(senderId uuid.UUID, content string, timestamp time.Time) *Message {
+	message := NewMessageWithTime(uuid.New(), senderId, content, timestamp)
+	gc.Messages = append(gc.Messages, message)
+
+	return message
+}
+
+func NewGroupChat(id uuid.UUID, groupId uuid.UUID) *GroupChat {
+	return &GroupChat{id, groupId, []*Message{}, time.Now()}
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	entity_enums "mashu.example/internal/entity/enums"
+)
+
+// the latest message a participant of the dm has received or read, the
+// messages sent at or before it are covered by the receipt as well
+type Receipt struct {
+	DMId             uuid.UUID
+	UserId           uuid.UUID
+	MessageId        uuid.UUID
+	MessageTimestamp time.Time
+	Status           entity_enums.MessageStatus // MESSAGE_DELIVERED or MESSAGE_READ
+	CreatedAt        time.Time
+}
+
+// check if the receipt is ahead of the other one of the same participant and status
+func (r *Receipt) IsNewerThan(other *Receipt) bool {
+	return r.MessageTimestamp.After(other.MessageTimestamp)
+}
+
+func NewReceipt(
+	dmId uuid.UUID,
+	userId uuid.UUID,
+	message *Message,
+	status entity_enums.MessageStatus,
+) *Receipt {
+	return &Receipt{dmId, userId, message.ID, message.Timestamp, status, time.Now()}
+}
+
+// status of the message judged by the receipts of the participants other than its owner
+func GetMessageStatus(message *Message, receipts []*Receipt) entity_enums.MessageStatus {
+	status := entity_enums.MESSAGE_SENT
+	for _, receipt := range receipts {
+		if receipt.UserId == message.OwnerId || receipt.MessageTimestamp.Before(message.Timestamp) {
+			continue
+		}
+		if receipt.Status > status {
+			status = receipt.Status
+		}
+	}
+
+	return status
+}
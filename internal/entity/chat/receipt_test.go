@@ -0,0 +1,33 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+)
+
+func TestGetMessageStatus(t *testing.T) {
+	dmId, sender, receiver := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	first := chat.NewMessageWithTime(uuid.New(), sender, "first", now)
+	second := chat.NewMessageWithTime(uuid.New(), sender, "second", now.Add(time.Second))
+	third := chat.NewMessageWithTime(uuid.New(), sender, "third", now.Add(2*time.Second))
+	reply := chat.NewMessageWithTime(uuid.New(), receiver, "reply", now.Add(3*time.Second))
+
+	receipts := []*chat.Receipt{
+		chat.NewReceipt(dmId, receiver, first, entity_enums.MESSAGE_READ),
+		chat.NewReceipt(dmId, receiver, second, entity_enums.MESSAGE_DELIVERED),
+		// the receipt of the sender covers the third message, but the receipts
+		// of the owner do not count
+		chat.NewReceipt(dmId, sender, reply, entity_enums.MESSAGE_READ),
+	}
+
+	assert.Equal(t, entity_enums.MESSAGE_READ, chat.GetMessageStatus(first, receipts))
+	assert.Equal(t, entity_enums.MESSAGE_DELIVERED, chat.GetMessageStatus(second, receipts))
+	assert.Equal(t, entity_enums.MESSAGE_SENT, chat.GetMessageStatus(third, receipts))
+	assert.Equal(t, entity_enums.MESSAGE_READ, chat.GetMessageStatus(reply, receipts))
+}
@@ -0,0 +1,9 @@
+package entity_enums
+
+type MessageStatus int
+
+const (
+	MESSAGE_SENT      MessageStatus = iota
+	MESSAGE_DELIVERED MessageStatus = iota
+	MESSAGE_READ      MessageStatus = iota
+)
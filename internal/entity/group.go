@@ -10,13 +10,14 @@ import (
 )
 
 type JoinRequest struct {
-	Requester uuid.UUID
-	timestamp time.Time
+	Requester   uuid.UUID
+	RequestedAt time.Time
 }
 
 type InviteRequest struct {
-	Invitee uuid.UUID
-	Inviter uuid.UUID
+	Invitee   uuid.UUID
+	Inviter   uuid.UUID
+	InvitedAt time.Time
 }
 
 // struct to represent an admin in group
@@ -123,6 +124,25 @@ func (g *Group) IsOwner(userId uuid.UUID) bool {
 	return userId == g.Owner.ID
 }
 
+// the owner is not in `Members` but is treated as a member of the group
+func (g *Group) IsMember(userId uuid.UUID) bool {
+	return g.IsOwner(userId) || slices.IndexFunc(g.Members, func(member *GroupMember) bool {
+		return member.UserId == userId
+	}) != -1
+}
+
+// ids of the owner and the members of the group
+func (g *Group) MemberIds() []uuid.UUID {
+	ids := []uuid.UUID{g.Owner.ID}
+	for _, member := range g.Members {
+		if member.UserId != g.Owner.ID {
+			ids = append(ids, member.UserId)
+		}
+	}
+
+	return ids
+}
+
 func (g *Group) AddJoinRequest(requesterId uuid.UUID) {
 	g.JoinRequests = append(g.JoinRequests, &JoinRequest{requesterId, time.Now()})
 }
@@ -147,7 +167,7 @@ func (g *Group) RemoveJoinRequest(requesterId uuid.UUID) {
 }
 
 func (g *Group) AddInviteRequest(invitee uuid.UUID, inviter uuid.UUID) {
-	g.InviteRequests = append(g.InviteRequests, &InviteRequest{invitee, inviter})
+	g.InviteRequests = append(g.InviteRequests, &InviteRequest{invitee, inviter, time.Now()})
 }
 
 func (g *Group) FindInvitationByInvitee(inviteeId uuid.UUID) *InviteRequest {
@@ -110,3 +110,14 @@ func TestRemoveAdminFromGroup(t *testing.T) {
 	assert.Equal(t, member3.ID, group.Admins[0].UserId)
 	assert.Equal(t, member2.ID, group.Admins[1].UserId)
 }
+
+func TestGroupMemberIds(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	member := entity.NewUser(uuid.New(), "member", "Member", "member@email.com", true)
+
+	group := entity.NewGroup(uuid.New(), "new group", user, entity_enums.GROUP_PUBLIC)
+	assert.Equal(t, []uuid.UUID{user.ID}, group.MemberIds())
+
+	group.AddMember(member.ID, member.ID, uuid.Nil)
+	assert.Equal(t, []uuid.UUID{user.ID, member.ID}, group.MemberIds())
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/slices"
 	entity_enums "mashu.example/internal/entity/enums"
 )
 
@@ -42,6 +43,30 @@ func (p *Post) Group() *Group {
 	return p.group
 }
 
+// check whether the user can read this post
+// rules:
+// - the owner can always read the post
+// - public post can be read by anyone
+// - follower-only post can only be read by the followers of the owner
+// - private post can only be read by the owner
+func (p *Post) IsVisibleTo(userId uuid.UUID) bool {
+	if p.Owner.ID == userId {
+		return true
+	}
+	if p.Owner.HasBlocked(userId) {
+		return false
+	}
+
+	switch p.Permission {
+	case entity_enums.POST_PUBLIC:
+		return true
+	case entity_enums.POST_FOLLOWER_ONLY:
+		return slices.Contains(p.Owner.Followers, userId)
+	default:
+		return false
+	}
+}
+
 func NewPost(
 	id uuid.UUID,
 	title string,
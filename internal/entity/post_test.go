@@ -0,0 +1,52 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+)
+
+func TestPublicPostVisibility(t *testing.T) {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_PUBLIC)
+
+	assert.True(t, post.IsVisibleTo(owner.ID))
+	assert.True(t, post.IsVisibleTo(uuid.New()))
+}
+
+func TestFollowerOnlyPostVisibility(t *testing.T) {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", false)
+	owner.AddFollower(follower.ID)
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_FOLLOWER_ONLY)
+
+	assert.True(t, post.IsVisibleTo(owner.ID))
+	assert.True(t, post.IsVisibleTo(follower.ID))
+	assert.False(t, post.IsVisibleTo(uuid.New()))
+}
+
+func TestPrivatePostVisibility(t *testing.T) {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", false)
+	owner.AddFollower(follower.ID)
+	post := entity.NewPost(uuid.New(), "title", "content", owner, nil, entity_enums.POST_PRIVATE)
+
+	assert.True(t, post.IsVisibleTo(owner.ID))
+	assert.False(t, post.IsVisibleTo(follower.ID))
+}
+
+func TestPostVisibilityToBlockedUser(t *testing.T) {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", false)
+	owner.AddFollower(follower.ID)
+	owner.Block(follower.ID)
+
+	for _, permission := range []entity_enums.PostPermission{entity_enums.POST_PUBLIC, entity_enums.POST_FOLLOWER_ONLY} {
+		post := entity.NewPost(uuid.New(), "title", "content", owner, nil, permission)
+		assert.True(t, post.IsVisibleTo(owner.ID))
+		assert.False(t, post.IsVisibleTo(follower.ID))
+	}
+}
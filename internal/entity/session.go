@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// struct to represent a login session of a user
+// note:
+// - only the hash of the refresh token is kept
+// - the refresh token is rotated every time it is used
+// - a revoked session can't be used anymore
+type Session struct {
+	ID               uuid.UUID
+	UserId           uuid.UUID
+	RefreshTokenHash string
+	ExpiredAt        time.Time
+	RevokedAt        *time.Time
+	CreatedAt        time.Time
+}
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiredAt)
+}
+
+func (s *Session) Rotate(refreshTokenHash string, expiredAt time.Time) {
+	s.RefreshTokenHash = refreshTokenHash
+	s.ExpiredAt = expiredAt
+}
+
+func (s *Session) Revoke() {
+	if s.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	s.RevokedAt = &now
+}
+
+func NewSession(
+	id uuid.UUID,
+	userId uuid.UUID,
+	refreshTokenHash string,
+	expiredAt time.Time,
+) *Session {
+	return &Session{
+		ID:               id,
+		UserId:           userId,
+		RefreshTokenHash: refreshTokenHash,
+		ExpiredAt:        expiredAt,
+		CreatedAt:        time.Now(),
+	}
+}
@@ -2,11 +2,15 @@ package entity
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 )
 
+// the date layout of the birthday
+const BirthdayLayout = "2006-01-02"
+
 type FollowRequest struct {
 	From uuid.UUID
 	To   uuid.UUID
@@ -17,11 +21,22 @@ type User struct {
 	UserName    string
 	DisplayName string
 	Email       string
+	Password    string // hashed password, empty if not set
 	Public      bool
 
+	// profile shown to the other users, empty if not set
+	Bio       string
+	AvatarURL string
+	Location  string
+	Website   string
+	Birthday  *time.Time // nil if not set
+
 	Followers      []uuid.UUID
 	Followings     []uuid.UUID
 	FollowRequests []*FollowRequest
+
+	BlockedUsers []uuid.UUID // users who can not follow, comment or message the user
+	MutedUsers   []uuid.UUID // users whose messages don't notify the user
 }
 
 func (u *User) Inspect() {
@@ -32,26 +47,168 @@ func (u *User) AddFollower(userId uuid.UUID) {
 	u.Followers = append(u.Followers, userId)
 }
 
-func (u *User) RemoveFollower(userId uuid.UUID) {
+// remove the follower, return false if the user is not a follower
+func (u *User) RemoveFollower(userId uuid.UUID) bool {
 	idx := slices.Index(u.Followers, userId)
+	if idx == -1 {
+		return false
+	}
 
 	u.Followers = slices.Delete(u.Followers, idx, idx+1)
+	return true
+}
+
+func (u *User) IsFollowedBy(userId uuid.UUID) bool {
+	return slices.Contains(u.Followers, userId)
 }
 
 func (u *User) AddFollowing(userId uuid.UUID) {
 	u.Followings = append(u.Followings, userId)
 }
 
-func (u *User) RemoveFollowing(userId uuid.UUID) {
+// remove the following user, return false if the user is not followed
+func (u *User) RemoveFollowing(userId uuid.UUID) bool {
 	idx := slices.Index(u.Followings, userId)
+	if idx == -1 {
+		return false
+	}
 
 	u.Followings = slices.Delete(u.Followings, idx, idx+1)
+	return true
+}
+
+func (u *User) IsFollowing(userId uuid.UUID) bool {
+	return slices.Contains(u.Followings, userId)
 }
 
 func (u *User) AddFollowRequest(req *FollowRequest) {
 	u.FollowRequests = append(u.FollowRequests, req)
 }
 
+// remove the follow request sent from `from` to `to`, return false if not found
+func (u *User) RemoveFollowRequest(from, to uuid.UUID) bool {
+	idx := slices.IndexFunc(u.FollowRequests, func(req *FollowRequest) bool {
+		return req.From == from && req.To == to
+	})
+	if idx == -1 {
+		return false
+	}
+
+	u.FollowRequests = slices.Delete(u.FollowRequests, idx, idx+1)
+	return true
+}
+
+// follow requests sent to the user
+func (u *User) IncomingFollowRequests() []*FollowRequest {
+	reqs := []*FollowRequest{}
+	for _, req := range u.FollowRequests {
+		if req.To == u.ID {
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs
+}
+
+// follow requests sent by the user
+func (u *User) OutgoingFollowRequests() []*FollowRequest {
+	reqs := []*FollowRequest{}
+	for _, req := range u.FollowRequests {
+		if req.From == u.ID {
+			reqs = append(reqs, req)
+		}
+	}
+
+	return reqs
+}
+
+// accept the follow request sent from the follower, the request is moved to
+// the follow relation in both users, return false if the request is not found
+func (u *User) AcceptFollowRequest(follower *User) bool {
+	if !u.RejectFollowRequest(follower) {
+		return false
+	}
+
+	u.AddFollower(follower.ID)
+	follower.AddFollowing(u.ID)
+	return true
+}
+
+// reject the follow request sent from the follower, the request is removed
+// from both users, return false if the request is not found
+func (u *User) RejectFollowRequest(follower *User) bool {
+	if !u.RemoveFollowRequest(follower.ID, u.ID) {
+		return false
+	}
+
+	follower.RemoveFollowRequest(follower.ID, u.ID)
+	return true
+}
+
+// remove the follow relations and the follow requests in both directions
+// between the user and the other user held by the user
+func (u *User) RemoveFollowRelationsWith(userId uuid.UUID) {
+	u.RemoveFollower(userId)
+	u.RemoveFollowing(userId)
+	u.RemoveFollowRequest(u.ID, userId)
+	u.RemoveFollowRequest(userId, u.ID)
+}
+
+// block the user, return false if the user is already blocked
+func (u *User) Block(userId uuid.UUID) bool {
+	if u.HasBlocked(userId) {
+		return false
+	}
+
+	u.BlockedUsers = append(u.BlockedUsers, userId)
+	return true
+}
+
+// unblock the user, return false if the user is not blocked
+func (u *User) Unblock(userId uuid.UUID) bool {
+	idx := slices.Index(u.BlockedUsers, userId)
+	if idx == -1 {
+		return false
+	}
+
+	u.BlockedUsers = slices.Delete(u.BlockedUsers, idx, idx+1)
+	return true
+}
+
+func (u *User) HasBlocked(userId uuid.UUID) bool {
+	return slices.Contains(u.BlockedUsers, userId)
+}
+
+// check if either of the users blocks the other one
+func (u *User) IsBlockedWith(other *User) bool {
+	return u.HasBlocked(other.ID) || other.HasBlocked(u.ID)
+}
+
+// mute the user, return false if the user is already muted
+func (u *User) Mute(userId uuid.UUID) bool {
+	if u.HasMuted(userId) {
+		return false
+	}
+
+	u.MutedUsers = append(u.MutedUsers, userId)
+	return true
+}
+
+// unmute the user, return false if the user is not muted
+func (u *User) Unmute(userId uuid.UUID) bool {
+	idx := slices.Index(u.MutedUsers, userId)
+	if idx == -1 {
+		return false
+	}
+
+	u.MutedUsers = slices.Delete(u.MutedUsers, idx, idx+1)
+	return true
+}
+
+func (u *User) HasMuted(userId uuid.UUID) bool {
+	return slices.Contains(u.MutedUsers, userId)
+}
+
 func NewUser(id uuid.UUID, userName, displayName, email string, public bool) *User {
 	return &User{
 		ID:             id,
@@ -62,5 +219,7 @@ func NewUser(id uuid.UUID, userName, displayName, email string, public bool) *Us
 		Followers:      []uuid.UUID{},
 		Followings:     []uuid.UUID{},
 		FollowRequests: []*FollowRequest{},
+		BlockedUsers:   []uuid.UUID{},
+		MutedUsers:     []uuid.UUID{},
 	}
 }
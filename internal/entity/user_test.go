@@ -0,0 +1,117 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+)
+
+func TestRemoveFollowRelations(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	followerId := uuid.New()
+	followingId := uuid.New()
+	user.AddFollower(followerId)
+	user.AddFollowing(followingId)
+
+	assert.True(t, user.IsFollowedBy(followerId))
+	assert.True(t, user.IsFollowing(followingId))
+
+	// removing an unknown user should not touch the other relations
+	assert.False(t, user.RemoveFollower(uuid.New()))
+	assert.False(t, user.RemoveFollowing(uuid.New()))
+	assert.Len(t, user.Followers, 1)
+	assert.Len(t, user.Followings, 1)
+
+	assert.True(t, user.RemoveFollower(followerId))
+	assert.True(t, user.RemoveFollowing(followingId))
+	assert.Len(t, user.Followers, 0)
+	assert.Len(t, user.Followings, 0)
+	assert.False(t, user.IsFollowedBy(followerId))
+	assert.False(t, user.IsFollowing(followingId))
+}
+
+func TestRemoveFollowRequest(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	fromId := uuid.New()
+	user.AddFollowRequest(&entity.FollowRequest{From: fromId, To: user.ID})
+
+	// the direction of the request matters
+	assert.False(t, user.RemoveFollowRequest(user.ID, fromId))
+	assert.Len(t, user.FollowRequests, 1)
+
+	assert.True(t, user.RemoveFollowRequest(fromId, user.ID))
+	assert.Len(t, user.FollowRequests, 0)
+	assert.False(t, user.RemoveFollowRequest(fromId, user.ID))
+}
+
+func TestBlockUser(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	other := entity.NewUser(uuid.New(), "other", "Other", "other@email.com", true)
+	user.AddFollower(other.ID)
+	user.AddFollowing(other.ID)
+	user.AddFollowRequest(&entity.FollowRequest{From: other.ID, To: user.ID})
+
+	assert.True(t, user.Block(other.ID))
+	assert.False(t, user.Block(other.ID))
+	assert.Len(t, user.BlockedUsers, 1)
+	assert.True(t, user.IsBlockedWith(other))
+	assert.True(t, other.IsBlockedWith(user))
+
+	user.RemoveFollowRelationsWith(other.ID)
+	assert.Len(t, user.Followers, 0)
+	assert.Len(t, user.Followings, 0)
+	assert.Len(t, user.FollowRequests, 0)
+
+	assert.True(t, user.Unblock(other.ID))
+	assert.False(t, user.Unblock(other.ID))
+	assert.False(t, user.IsBlockedWith(other))
+}
+
+func TestMuteUser(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	otherId := uuid.New()
+
+	assert.True(t, user.Mute(otherId))
+	assert.False(t, user.Mute(otherId))
+	assert.True(t, user.HasMuted(otherId))
+
+	assert.True(t, user.Unmute(otherId))
+	assert.False(t, user.Unmute(otherId))
+	assert.False(t, user.HasMuted(otherId))
+}
+
+func TestHandleFollowRequests(t *testing.T) {
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", false)
+	rejected := entity.NewUser(uuid.New(), "rejected", "Rejected", "rejected@email.com", false)
+	followee := entity.NewUser(uuid.New(), "followee", "Followee", "followee@email.com", false)
+
+	for _, from := range []*entity.User{follower, rejected} {
+		req := &entity.FollowRequest{From: from.ID, To: user.ID}
+		user.AddFollowRequest(req)
+		from.AddFollowRequest(req)
+	}
+	outgoingReq := &entity.FollowRequest{From: user.ID, To: followee.ID}
+	user.AddFollowRequest(outgoingReq)
+	followee.AddFollowRequest(outgoingReq)
+
+	assert.Len(t, user.IncomingFollowRequests(), 2)
+	assert.Equal(t, []*entity.FollowRequest{outgoingReq}, user.OutgoingFollowRequests())
+
+	assert.True(t, user.AcceptFollowRequest(follower))
+	assert.False(t, user.AcceptFollowRequest(follower))
+	assert.Equal(t, []uuid.UUID{follower.ID}, user.Followers)
+	assert.Equal(t, []uuid.UUID{user.ID}, follower.Followings)
+	assert.Len(t, follower.FollowRequests, 0)
+
+	assert.True(t, user.RejectFollowRequest(rejected))
+	assert.Len(t, rejected.FollowRequests, 0)
+	assert.Len(t, rejected.Followings, 0)
+
+	// the request sent by the user can only be handled by the followee
+	assert.False(t, user.RejectFollowRequest(followee))
+	assert.Len(t, user.IncomingFollowRequests(), 0)
+	assert.Len(t, user.OutgoingFollowRequests(), 1)
+}
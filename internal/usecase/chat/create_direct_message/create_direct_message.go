@@ -14,6 +14,7 @@ import (
 var (
 	ErrChatRoomAlreadyExist             = errors.New("chatroom already exist")
 	ErrSenderDoNotFollowPrivateReceiver = errors.New("sender don't follow the private receiver")
+	ErrBlockedUser                      = errors.New("can not chat with the user because of blocking")
 )
 
 type CreateDirectMessageUseCaseReq struct {
@@ -48,6 +49,12 @@ func (uc *CreateDirectMessageUseCase) Execute() {
 		return
 	}
 
+	if sender.IsBlockedWith(receiver) {
+		uc.res.Err = ErrBlockedUser
+		logrus.Error(ErrBlockedUser.Error())
+		return
+	}
+
 	dm, err := uc.chatRepo.GetDMByUserId(sender.ID, receiver.ID)
 	if dm != nil {
 		uc.res.Err = ErrChatRoomAlreadyExist
@@ -133,3 +133,22 @@ func TestCreateDirectMessageButUserNotExist(t *testing.T) {
 	assert.NotNil(t, res.Err)
 	assert.Equal(t, res.Err.Error(), (&repository.ErrUserNotFound{UserId: user2Id}).Error())
 }
+
+func TestCreateDirectMessageToBlockedUser(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	receiver.Block(sender.ID)
+
+	userRepo.EXPECT().GetUserById(sender.ID).Return(sender, nil)
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+
+	req := usecase.NewCreateDirectMessageUseCaseReq(sender.ID, receiver.ID)
+	res := usecase.NewCreateDirectMessageUseCaseRes()
+	uc := usecase.NewCreateDirectMessageUseCase(chatRepo, userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrBlockedUser)
+}
@@ -0,0 +1,80 @@
+package create_group_chat
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	chat "mashu.example/internal/entity/chat"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotGroupMember        = errors.New("only the members can create the chat of the group")
+	ErrGroupChatAlreadyExist = errors.New("the chat of the group already exists")
+)
+
+type CreateGroupChatUseCaseReq struct {
+	userId  uuid.UUID
+	groupId uuid.UUID
+}
+
+type CreateGroupChatUseCaseRes struct {
+	GroupChatId uuid.UUID
+	Err         error
+}
+
+// create the chat room shared by the members of the group
+type CreateGroupChatUseCase struct {
+	groupRepo repository.GroupRepo
+	chatRepo  repository.ChatRepo
+	req       *CreateGroupChatUseCaseReq
+	res       *CreateGroupChatUseCaseRes
+}
+
+func (uc *CreateGroupChatUseCase) Execute() {
+	group, err := uc.groupRepo.GetGroupById(uc.req.groupId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	if !group.IsMember(uc.req.userId) {
+		uc.res.Err = ErrNotGroupMember
+		return
+	}
+
+	if _, err := uc.chatRepo.GetGroupChatByGroupId(group.ID); err == nil {
+		uc.res.Err = ErrGroupChatAlreadyExist
+		return
+	}
+
+	groupChat := chat.NewGroupChat(uuid.New(), group.ID)
+	if err := uc.chatRepo.SaveGroupChat(groupChat); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.GroupChatId = groupChat.ID
+	uc.res.Err = nil
+}
+
+func NewCreateGroupChatUseCase(
+	groupRepo repository.GroupRepo,
+	chatRepo repository.ChatRepo,
+	req *CreateGroupChatUseCaseReq,
+	res *CreateGroupChatUseCaseRes,
+) usecase.UseCase {
+	return &CreateGroupChatUseCase{groupRepo, chatRepo, req, res}
+}
+
+func NewCreateGroupChatUseCaseReq(userId uuid.UUID, groupId uuid.UUID) *CreateGroupChatUseCaseReq {
+	return &CreateGroupChatUseCaseReq{userId, groupId}
+}
+
+func NewCreateGroupChatUseCaseRes() *CreateGroupChatUseCaseRes {
+	return &CreateGroupChatUseCaseRes{}
+}
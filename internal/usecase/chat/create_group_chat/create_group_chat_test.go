@@ -0,0 +1,71 @@
+package create_group_chat_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	usecase "mashu.example/internal/usecase/chat/create_group_chat"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestCreateGroupChat(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	member := entity.NewUser(uuid.New(), "member", "Member", "member@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+	group.AddMember(member.ID, member.ID, owner.ID)
+
+	var groupChat *chat.GroupChat
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	chatRepo.EXPECT().GetGroupChatByGroupId(group.ID).Return(nil, &repository.ErrGroupChatNotFound{GroupId: group.ID})
+	chatRepo.
+		EXPECT().
+		SaveGroupChat(gomock.AssignableToTypeOf(&chat.GroupChat{})).
+		Do(func(arg *chat.GroupChat) { groupChat = arg })
+
+	req := usecase.NewCreateGroupChatUseCaseReq(member.ID, group.ID)
+	res := usecase.NewCreateGroupChatUseCaseRes()
+	usecase.NewCreateGroupChatUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, groupChat.ID, res.GroupChatId)
+	assert.Equal(t, group.ID, groupChat.GroupId)
+}
+
+func TestCreateGroupChatByNonMember(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+
+	req := usecase.NewCreateGroupChatUseCaseReq(uuid.New(), group.ID)
+	res := usecase.NewCreateGroupChatUseCaseRes()
+	usecase.NewCreateGroupChatUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotGroupMember)
+}
+
+func TestCreateExistingGroupChat(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	chatRepo.EXPECT().GetGroupChatByGroupId(group.ID).Return(chat.NewGroupChat(uuid.New(), group.ID), nil)
+
+	req := usecase.NewCreateGroupChatUseCaseReq(owner.ID, group.ID)
+	res := usecase.NewCreateGroupChatUseCaseRes()
+	usecase.NewCreateGroupChatUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrGroupChatAlreadyExist)
+}
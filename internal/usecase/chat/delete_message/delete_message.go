@@ -0,0 +1,95 @@
+package delete_message
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotMessageOwner = errors.New("only the author can delete the message")
+	ErrMessageDeleted  = errors.New("the message is already deleted")
+)
+
+type DeleteMessageUseCaseReq struct {
+	userId    uuid.UUID
+	dmId      uuid.UUID
+	messageId uuid.UUID
+}
+
+type DeleteMessageUseCaseRes struct {
+	PartnerId uuid.UUID // the other participant of the dm to be notified
+	DeletedAt time.Time
+	Err       error
+}
+
+// delete the message sent by the user, the message is kept as a tombstone
+// so that the history of the dm stays in order
+type DeleteMessageUseCase struct {
+	chatRepo repository.ChatRepo
+	req      *DeleteMessageUseCaseReq
+	res      *DeleteMessageUseCaseRes
+}
+
+func (uc *DeleteMessageUseCase) Execute() {
+	// the dm is loaded for the participants only, without the messages
+	dm, err := uc.chatRepo.GetDirectMessage(uc.req.dmId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	message, err := uc.chatRepo.GetMessage(dm.ID, uc.req.messageId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if message.OwnerId != uc.req.userId {
+		uc.res.Err = ErrNotMessageOwner
+		return
+	}
+	if message.IsDeleted() {
+		uc.res.Err = ErrMessageDeleted
+		return
+	}
+
+	message.Delete()
+	if err := uc.chatRepo.UpdateMessage(dm.ID, message); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.PartnerId = dm.Creator.ID
+	if uc.req.userId == dm.Creator.ID {
+		uc.res.PartnerId = dm.Receiver.ID
+	}
+	uc.res.DeletedAt = message.DeletedAt
+	uc.res.Err = nil
+}
+
+func NewDeleteMessageUseCase(
+	chatRepo repository.ChatRepo,
+	req *DeleteMessageUseCaseReq,
+	res *DeleteMessageUseCaseRes,
+) usecase.UseCase {
+	return &DeleteMessageUseCase{chatRepo, req, res}
+}
+
+func NewDeleteMessageUseCaseReq(
+	userId uuid.UUID,
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+) *DeleteMessageUseCaseReq {
+	return &DeleteMessageUseCaseReq{userId, dmId, messageId}
+}
+
+func NewDeleteMessageUseCaseRes() *DeleteMessageUseCaseRes {
+	return &DeleteMessageUseCaseRes{}
+}
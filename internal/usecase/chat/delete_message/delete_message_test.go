@@ -0,0 +1,72 @@
+package delete_message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	usecase "mashu.example/internal/usecase/chat/delete_message"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestDeleteMessage(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), receiver.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.EXPECT().UpdateMessage(dm.ID, message)
+
+	req := usecase.NewDeleteMessageUseCaseReq(receiver.ID, dm.ID, message.ID)
+	res := usecase.NewDeleteMessageUseCaseRes()
+	usecase.NewDeleteMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, sender.ID, res.PartnerId)
+	assert.True(t, message.IsDeleted())
+	assert.Empty(t, message.Content)
+	assert.Equal(t, message.DeletedAt, res.DeletedAt)
+}
+
+func TestDeleteMessageByNonOwner(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+
+	req := usecase.NewDeleteMessageUseCaseReq(receiver.ID, dm.ID, message.ID)
+	res := usecase.NewDeleteMessageUseCaseRes()
+	usecase.NewDeleteMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotMessageOwner)
+	assert.False(t, message.IsDeleted())
+}
+
+func TestDeleteMessageWithStoreError(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	dmId := uuid.New()
+	errStore := errors.New("connection refused")
+	chatRepo.EXPECT().GetDirectMessage(dmId).Return(nil, errStore)
+
+	req := usecase.NewDeleteMessageUseCaseReq(uuid.New(), dmId, uuid.New())
+	res := usecase.NewDeleteMessageUseCaseRes()
+	usecase.NewDeleteMessageUseCase(chatRepo, req, res).Execute()
+
+	var errDMNotFound *repository.ErrDMNotFound
+	assert.ErrorIs(t, res.Err, errStore)
+	assert.False(t, errors.As(res.Err, &errDMNotFound))
+}
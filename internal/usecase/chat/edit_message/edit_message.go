@@ -0,0 +1,98 @@
+package edit_message
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotMessageOwner = errors.New("only the author can edit the message")
+	ErrMessageDeleted  = errors.New("the deleted message can't be edited")
+)
+
+type EditMessageUseCaseReq struct {
+	userId    uuid.UUID
+	dmId      uuid.UUID
+	messageId uuid.UUID
+	content   string
+}
+
+type EditMessageUseCaseRes struct {
+	PartnerId uuid.UUID // the other participant of the dm to be notified
+	Content   string
+	EditedAt  time.Time
+	Err       error
+}
+
+// edit the content of the message sent by the user
+type EditMessageUseCase struct {
+	chatRepo repository.ChatRepo
+	req      *EditMessageUseCaseReq
+	res      *EditMessageUseCaseRes
+}
+
+func (uc *EditMessageUseCase) Execute() {
+	// the dm is loaded for the participants only, without the messages
+	dm, err := uc.chatRepo.GetDirectMessage(uc.req.dmId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	message, err := uc.chatRepo.GetMessage(dm.ID, uc.req.messageId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if message.OwnerId != uc.req.userId {
+		uc.res.Err = ErrNotMessageOwner
+		return
+	}
+	if message.IsDeleted() {
+		uc.res.Err = ErrMessageDeleted
+		return
+	}
+
+	message.Edit(uc.req.content)
+	if err := uc.chatRepo.UpdateMessage(dm.ID, message); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.PartnerId = dm.Creator.ID
+	if uc.req.userId == dm.Creator.ID {
+		uc.res.PartnerId = dm.Receiver.ID
+	}
+	uc.res.Content = message.Content
+	uc.res.EditedAt = message.EditedAt
+	uc.res.Err = nil
+}
+
+func NewEditMessageUseCase(
+	chatRepo repository.ChatRepo,
+	req *EditMessageUseCaseReq,
+	res *EditMessageUseCaseRes,
+) usecase.UseCase {
+	return &EditMessageUseCase{chatRepo, req, res}
+}
+
+func NewEditMessageUseCaseReq(
+	userId uuid.UUID,
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	content string,
+) *EditMessageUseCaseReq {
+	return &EditMessageUseCaseReq{userId, dmId, messageId, content}
+}
+
+func NewEditMessageUseCaseRes() *EditMessageUseCaseRes {
+	return &EditMessageUseCaseRes{}
+}
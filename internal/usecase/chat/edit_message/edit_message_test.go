@@ -0,0 +1,92 @@
+package edit_message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	usecase "mashu.example/internal/usecase/chat/edit_message"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestEditMessage(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.EXPECT().UpdateMessage(dm.ID, message)
+
+	req := usecase.NewEditMessageUseCaseReq(sender.ID, dm.ID, message.ID, "hello")
+	res := usecase.NewEditMessageUseCaseRes()
+	usecase.NewEditMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, receiver.ID, res.PartnerId)
+	assert.Equal(t, "hello", res.Content)
+	assert.Equal(t, "hello", message.Content)
+	assert.True(t, message.IsEdited())
+	assert.Equal(t, message.EditedAt, res.EditedAt)
+}
+
+func TestEditMessageByNonOwner(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+
+	req := usecase.NewEditMessageUseCaseReq(receiver.ID, dm.ID, message.ID, "hello")
+	res := usecase.NewEditMessageUseCaseRes()
+	usecase.NewEditMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotMessageOwner)
+	assert.Equal(t, "hi", message.Content)
+}
+
+func TestEditDeletedMessage(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+	message.Delete()
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+
+	req := usecase.NewEditMessageUseCaseReq(sender.ID, dm.ID, message.ID, "hello")
+	res := usecase.NewEditMessageUseCaseRes()
+	usecase.NewEditMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrMessageDeleted)
+}
+
+func TestEditMessageWithStoreError(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	dmId := uuid.New()
+	errStore := errors.New("connection refused")
+	chatRepo.EXPECT().GetDirectMessage(dmId).Return(nil, errStore)
+
+	req := usecase.NewEditMessageUseCaseReq(uuid.New(), dmId, uuid.New(), "hello")
+	res := usecase.NewEditMessageUseCaseRes()
+	usecase.NewEditMessageUseCase(chatRepo, req, res).Execute()
+
+	var errDMNotFound *repository.ErrDMNotFound
+	assert.ErrorIs(t, res.Err, errStore)
+	assert.False(t, errors.As(res.Err, &errDMNotFound))
+}
@@ -0,0 +1,112 @@
+package load_group_messages
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	chat "mashu.example/internal/entity/chat"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var ErrNotGroupMember = errors.New("only the members can load the messages of the group chat")
+
+type GroupMessageDTO struct {
+	ID        uuid.UUID
+	OwnerId   uuid.UUID
+	Content   string
+	Timestamp time.Time
+	EditedAt  time.Time              // zero if the message is never edited
+	DeletedAt time.Time              // zero if the message is not deleted
+	Reactions map[uuid.UUID][]string // user id to the emojis reacted by the user
+}
+
+func NewGroupMessageDTO(m *chat.Message) GroupMessageDTO {
+	return GroupMessageDTO{
+		ID:        m.ID,
+		OwnerId:   m.OwnerId,
+		Content:   m.Content,
+		Timestamp: m.Timestamp,
+		EditedAt:  m.EditedAt,
+		DeletedAt: m.DeletedAt,
+		Reactions: chat.CloneReactions(m.Reactions),
+	}
+}
+
+type LoadGroupMessagesUseCaseReq struct {
+	userId  uuid.UUID
+	groupId uuid.UUID
+	before  time.Time // load the messages sent before it, zero for the latest messages
+	limit   int       // max number of messages loaded
+}
+
+type LoadGroupMessagesUseCaseRes struct {
+	GroupChatId uuid.UUID
+	Messages    []GroupMessageDTO
+	Err         error
+}
+
+type LoadGroupMessagesUseCase struct {
+	groupRepo repository.GroupRepo
+	chatRepo  repository.ChatRepo
+	req       *LoadGroupMessagesUseCaseReq
+	res       *LoadGroupMessagesUseCaseRes
+}
+
+func (uc *LoadGroupMessagesUseCase) Execute() {
+	group, err := uc.groupRepo.GetGroupById(uc.req.groupId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	if !group.IsMember(uc.req.userId) {
+		uc.res.Err = ErrNotGroupMember
+		return
+	}
+
+	groupChat, err := uc.chatRepo.GetGroupChatByGroupId(group.ID)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	messages, err := uc.chatRepo.ListGroupMessages(groupChat.ID, uc.req.before, uc.req.limit)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.GroupChatId = groupChat.ID
+	for _, message := range messages {
+		uc.res.Messages = append(uc.res.Messages, NewGroupMessageDTO(message))
+	}
+	uc.res.Err = nil
+}
+
+func NewLoadGroupMessagesUseCase(
+	groupRepo repository.GroupRepo,
+	chatRepo repository.ChatRepo,
+	req *LoadGroupMessagesUseCaseReq,
+	res *LoadGroupMessagesUseCaseRes,
+) usecase.UseCase {
+	return &LoadGroupMessagesUseCase{groupRepo, chatRepo, req, res}
+}
+
+func NewLoadGroupMessagesUseCaseReq(
+	userId uuid.UUID,
+	groupId uuid.UUID,
+	before time.Time,
+	limit int,
+) *LoadGroupMessagesUseCaseReq {
+	return &LoadGroupMessagesUseCaseReq{userId, groupId, before, limit}
+}
+
+func NewLoadGroupMessagesUseCaseRes() *LoadGroupMessagesUseCaseRes {
+	return &LoadGroupMessagesUseCaseRes{Messages: []GroupMessageDTO{}}
+}
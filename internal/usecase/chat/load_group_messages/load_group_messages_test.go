@@ -0,0 +1,53 @@
+package load_group_messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	usecase "mashu.example/internal/usecase/chat/load_group_messages"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestLoadGroupMessages(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+	groupChat := chat.NewGroupChat(uuid.New(), group.ID)
+	message := groupChat.AddMessage(owner.ID, "hi")
+
+	before := time.Now()
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	chatRepo.EXPECT().GetGroupChatByGroupId(group.ID).Return(groupChat, nil)
+	chatRepo.EXPECT().ListGroupMessages(groupChat.ID, before, 10).Return([]*chat.Message{message}, nil)
+
+	req := usecase.NewLoadGroupMessagesUseCaseReq(owner.ID, group.ID, before, 10)
+	res := usecase.NewLoadGroupMessagesUseCaseRes()
+	usecase.NewLoadGroupMessagesUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, groupChat.ID, res.GroupChatId)
+	assert.Len(t, res.Messages, 1)
+	assert.Equal(t, message.ID, res.Messages[0].ID)
+	assert.Equal(t, "hi", res.Messages[0].Content)
+}
+
+func TestLoadGroupMessagesByNonMember(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+
+	req := usecase.NewLoadGroupMessagesUseCaseReq(uuid.New(), group.ID, time.Time{}, 10)
+	res := usecase.NewLoadGroupMessagesUseCaseRes()
+	usecase.NewLoadGroupMessagesUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotGroupMember)
+}
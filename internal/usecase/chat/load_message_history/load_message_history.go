@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	entity "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
 )
@@ -16,24 +17,35 @@ type MessageDTO struct {
 	OwnerId   uuid.UUID
 	Content   string
 	Timestamp time.Time
+	EditedAt  time.Time // zero if the message is never edited
+	DeletedAt time.Time // zero if the message is not deleted
+	Status    entity_enums.MessageStatus
+	Reactions map[uuid.UUID][]string // user id to the emojis reacted by the user
 }
 
-func NewMessageDTO(m entity.Message) MessageDTO {
+func NewMessageDTO(m entity.Message, receipts []*entity.Receipt) MessageDTO {
 	return MessageDTO{
 		ID:        m.ID,
 		OwnerId:   m.OwnerId,
 		Content:   m.Content,
 		Timestamp: m.Timestamp,
+		EditedAt:  m.EditedAt,
+		DeletedAt: m.DeletedAt,
+		Status:    entity.GetMessageStatus(&m, receipts),
+		Reactions: entity.CloneReactions(m.Reactions),
 	}
 }
 
 type LoadMessageHistoryUseCaseReq struct {
 	userId uuid.UUID
+	before time.Time // load the messages sent before it, zero for the latest messages
+	limit  int       // max number of messages loaded for each dm
 }
 
 type LoadMessageHistoryUseCaseRes struct {
-	MessageMap map[string][]MessageDTO // map the dm id to a list of message
-	Err        error
+	MessageMap   map[string][]MessageDTO // map the dm id to a list of message
+	UnreadCounts map[string]int          // map the dm id to the number of unread messages
+	Err          error
 }
 
 type LoadMessageHistoryUseCase struct {
@@ -58,10 +70,33 @@ func (uc *LoadMessageHistoryUseCase) Execute() {
 		return
 	}
 
+	unreadCounts, err := uc.chatRepo.GetUnreadCounts(user.ID)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
 	for _, dm := range dms {
+		uc.res.UnreadCounts[dm.ID.String()] = unreadCounts[dm.ID]
+
+		messages, err := uc.chatRepo.ListMessages(dm.ID, uc.req.before, uc.req.limit)
+		if err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+
+		receipts, err := uc.chatRepo.GetReceipts(dm.ID)
+		if err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+
 		msgDTOs := []MessageDTO{}
-		for _, msg := range dm.Messages {
-			msgDTOs = append(msgDTOs, NewMessageDTO(*msg))
+		for _, msg := range messages {
+			msgDTOs = append(msgDTOs, NewMessageDTO(*msg, receipts))
 		}
 		uc.res.MessageMap[dm.ID.String()] = msgDTOs
 	}
@@ -80,12 +115,15 @@ func NewLoadMessageHistoryUseCase(
 
 func NewLoadMessageHistoryUseCaseReq(
 	userId uuid.UUID,
+	before time.Time,
+	limit int,
 ) *LoadMessageHistoryUseCaseReq {
-	return &LoadMessageHistoryUseCaseReq{userId}
+	return &LoadMessageHistoryUseCaseReq{userId, before, limit}
 }
 
 func NewLoadMessageHistoryUseCaseRes() *LoadMessageHistoryUseCaseRes {
 	return &LoadMessageHistoryUseCaseRes{
-		MessageMap: map[string][]MessageDTO{},
+		MessageMap:   map[string][]MessageDTO{},
+		UnreadCounts: map[string]int{},
 	}
 }
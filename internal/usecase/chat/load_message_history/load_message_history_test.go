@@ -2,11 +2,13 @@ package load_message_history_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"mashu.example/internal/entity"
 	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
 	usecase "mashu.example/internal/usecase/chat/load_message_history"
 	"mashu.example/internal/usecase/tests"
 )
@@ -31,8 +33,13 @@ func TestLoadMessageHistory(t *testing.T) {
 
 	userRepo.EXPECT().GetUserById(user1.ID).Return(user1, nil)
 	chatRepo.EXPECT().GetDMsByPartUserId(user1.ID).Return(dms, nil)
+	chatRepo.EXPECT().GetUnreadCounts(user1.ID).Return(map[uuid.UUID]int{dms[0].ID: 1, dms[2].ID: 2}, nil)
+	for _, dm := range dms {
+		chatRepo.EXPECT().ListMessages(dm.ID, time.Time{}, 50).Return(dm.Messages, nil)
+		chatRepo.EXPECT().GetReceipts(dm.ID).Return([]*chat.Receipt{}, nil)
+	}
 
-	req := usecase.NewLoadMessageHistoryUseCaseReq(user1.ID)
+	req := usecase.NewLoadMessageHistoryUseCaseReq(user1.ID, time.Time{}, 50)
 	res := usecase.NewLoadMessageHistoryUseCaseRes()
 	uc := usecase.NewLoadMessageHistoryUseCase(userRepo, chatRepo, req, res)
 
@@ -43,4 +50,36 @@ func TestLoadMessageHistory(t *testing.T) {
 	assert.Len(t, res.MessageMap[dms[0].ID.String()], 2)
 	assert.Len(t, res.MessageMap[dms[1].ID.String()], 1)
 	assert.Len(t, res.MessageMap[dms[2].ID.String()], 2)
+	assert.Equal(t, map[string]int{
+		dms[0].ID.String(): 1,
+		dms[1].ID.String(): 0,
+		dms[2].ID.String(): 2,
+	}, res.UnreadCounts)
+}
+
+func TestLoadMessageHistoryBefore(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	user1 := entity.NewUser(uuid.New(), "user1", "User1", "user1@email.com", true)
+	user2 := entity.NewUser(uuid.New(), "user2", "User2", "user2@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), user1, user2)
+	before := time.Now()
+	message := chat.NewMessageWithTime(uuid.New(), user2.ID, "hello, user1", before.Add(-time.Minute))
+
+	userRepo.EXPECT().GetUserById(user1.ID).Return(user1, nil)
+	chatRepo.EXPECT().GetDMsByPartUserId(user1.ID).Return([]*chat.DirectMessage{dm}, nil)
+	chatRepo.EXPECT().GetUnreadCounts(user1.ID).Return(map[uuid.UUID]int{}, nil)
+	chatRepo.EXPECT().ListMessages(dm.ID, before, 1).Return([]*chat.Message{message}, nil)
+	chatRepo.EXPECT().GetReceipts(dm.ID).Return([]*chat.Receipt{
+		chat.NewReceipt(dm.ID, user1.ID, message, entity_enums.MESSAGE_READ),
+	}, nil)
+
+	req := usecase.NewLoadMessageHistoryUseCaseReq(user1.ID, before, 1)
+	res := usecase.NewLoadMessageHistoryUseCaseRes()
+	usecase.NewLoadMessageHistoryUseCase(userRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.MessageMap[dm.ID.String()], 1)
+	assert.Equal(t, message.ID, res.MessageMap[dm.ID.String()][0].ID)
+	assert.Equal(t, entity_enums.MESSAGE_READ, res.MessageMap[dm.ID.String()][0].Status)
 }
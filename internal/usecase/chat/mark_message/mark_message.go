@@ -0,0 +1,117 @@
+package mark_message
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrInvalidMessageStatus = errors.New("the message can only be marked as delivered or read")
+	ErrNotParticipant       = errors.New("only the participants can mark the messages of the dm")
+)
+
+type MarkMessageUseCaseReq struct {
+	userId    uuid.UUID
+	dmId      uuid.UUID
+	messageId uuid.UUID
+	status    entity_enums.MessageStatus
+}
+
+type MarkMessageUseCaseRes struct {
+	PartnerId        uuid.UUID // the other participant of the dm to be notified
+	MessageTimestamp time.Time
+	Err              error
+}
+
+// mark the messages up to the given one as delivered or read by the user
+type MarkMessageUseCase struct {
+	chatRepo repository.ChatRepo
+	req      *MarkMessageUseCaseReq
+	res      *MarkMessageUseCaseRes
+}
+
+func (uc *MarkMessageUseCase) Execute() {
+	if uc.req.status != entity_enums.MESSAGE_DELIVERED && uc.req.status != entity_enums.MESSAGE_READ {
+		uc.res.Err = ErrInvalidMessageStatus
+		return
+	}
+
+	dm, err := uc.chatRepo.GetDirectMessage(uc.req.dmId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	var partnerId uuid.UUID
+	switch uc.req.userId {
+	case dm.Creator.ID:
+		partnerId = dm.Receiver.ID
+	case dm.Receiver.ID:
+		partnerId = dm.Creator.ID
+	default:
+		uc.res.Err = ErrNotParticipant
+		return
+	}
+
+	message, err := uc.chatRepo.GetMessage(dm.ID, uc.req.messageId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	// the read message is delivered as well
+	statuses := []entity_enums.MessageStatus{uc.req.status}
+	if uc.req.status == entity_enums.MESSAGE_READ {
+		statuses = append(statuses, entity_enums.MESSAGE_DELIVERED)
+	}
+	for _, status := range statuses {
+		if err := uc.chatRepo.SaveReceipt(chat.NewReceipt(dm.ID, uc.req.userId, message, status)); err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+	}
+
+	if uc.req.status == entity_enums.MESSAGE_READ {
+		// the messages sent after the read message are still unread
+		if err := uc.chatRepo.RecountUnreadCount(uc.req.userId, dm.ID, message.Timestamp); err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+	}
+
+	uc.res.PartnerId = partnerId
+	uc.res.MessageTimestamp = message.Timestamp
+	uc.res.Err = nil
+}
+
+func NewMarkMessageUseCase(
+	chatRepo repository.ChatRepo,
+	req *MarkMessageUseCaseReq,
+	res *MarkMessageUseCaseRes,
+) usecase.UseCase {
+	return &MarkMessageUseCase{chatRepo, req, res}
+}
+
+func NewMarkMessageUseCaseReq(
+	userId uuid.UUID,
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	status entity_enums.MessageStatus,
+) *MarkMessageUseCaseReq {
+	return &MarkMessageUseCaseReq{userId, dmId, messageId, status}
+}
+
+func NewMarkMessageUseCaseRes() *MarkMessageUseCaseRes {
+	return &MarkMessageUseCaseRes{}
+}
@@ -0,0 +1,116 @@
+package mark_message_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	usecase "mashu.example/internal/usecase/chat/mark_message"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestMarkMessageAsRead(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := dm.AddMessage(sender.ID, "hi")
+
+	receipts := []*chat.Receipt{}
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.
+		EXPECT().
+		SaveReceipt(gomock.AssignableToTypeOf(&chat.Receipt{})).
+		Do(func(arg *chat.Receipt) { receipts = append(receipts, arg) }).
+		Times(2)
+	chatRepo.EXPECT().RecountUnreadCount(receiver.ID, dm.ID, message.Timestamp)
+
+	req := usecase.NewMarkMessageUseCaseReq(receiver.ID, dm.ID, message.ID, entity_enums.MESSAGE_READ)
+	res := usecase.NewMarkMessageUseCaseRes()
+	usecase.NewMarkMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, sender.ID, res.PartnerId)
+	assert.Equal(t, message.Timestamp, res.MessageTimestamp)
+	assert.Len(t, receipts, 2)
+	for _, receipt := range receipts {
+		assert.Equal(t, receiver.ID, receipt.UserId)
+		assert.Equal(t, message.ID, receipt.MessageId)
+	}
+	assert.Equal(t, entity_enums.MESSAGE_READ, chat.GetMessageStatus(message, receipts))
+}
+
+func TestMarkMessageAsDelivered(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := dm.AddMessage(sender.ID, "hi")
+
+	var receipt *chat.Receipt
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.
+		EXPECT().
+		SaveReceipt(gomock.AssignableToTypeOf(&chat.Receipt{})).
+		Do(func(arg *chat.Receipt) { receipt = arg })
+
+	req := usecase.NewMarkMessageUseCaseReq(receiver.ID, dm.ID, message.ID, entity_enums.MESSAGE_DELIVERED)
+	res := usecase.NewMarkMessageUseCaseRes()
+	usecase.NewMarkMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, entity_enums.MESSAGE_DELIVERED, receipt.Status)
+}
+
+func TestMarkMessageByNonParticipant(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := dm.AddMessage(sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+
+	req := usecase.NewMarkMessageUseCaseReq(uuid.New(), dm.ID, message.ID, entity_enums.MESSAGE_READ)
+	res := usecase.NewMarkMessageUseCaseRes()
+	usecase.NewMarkMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotParticipant)
+}
+
+func TestMarkMessageWithInvalidStatus(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	req := usecase.NewMarkMessageUseCaseReq(uuid.New(), uuid.New(), uuid.New(), entity_enums.MESSAGE_SENT)
+	res := usecase.NewMarkMessageUseCaseRes()
+	usecase.NewMarkMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrInvalidMessageStatus)
+}
+
+func TestMarkMessageWithStoreError(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	dmId := uuid.New()
+	errStore := errors.New("connection refused")
+	chatRepo.EXPECT().GetDirectMessage(dmId).Return(nil, errStore)
+
+	req := usecase.NewMarkMessageUseCaseReq(uuid.New(), dmId, uuid.New(), entity_enums.MESSAGE_READ)
+	res := usecase.NewMarkMessageUseCaseRes()
+	usecase.NewMarkMessageUseCase(chatRepo, req, res).Execute()
+
+	var errDMNotFound *repository.ErrDMNotFound
+	assert.ErrorIs(t, res.Err, errStore)
+	assert.False(t, errors.As(res.Err, &errDMNotFound))
+}
@@ -0,0 +1,113 @@
+package react_message
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+// max number of characters of an emoji, it allows the sequences joined by ZWJ
+const maxEmojiLength = 16
+
+var (
+	ErrInvalidEmoji   = errors.New("the reaction must be an emoji")
+	ErrNotParticipant = errors.New("only the participants can react to the messages of the dm")
+	ErrMessageDeleted = errors.New("the deleted message can't be reacted to")
+)
+
+type ReactMessageUseCaseReq struct {
+	userId    uuid.UUID
+	dmId      uuid.UUID
+	messageId uuid.UUID
+	emoji     string
+	add       bool // add the reaction if true, otherwise remove it
+}
+
+type ReactMessageUseCaseRes struct {
+	PartnerId uuid.UUID // the other participant of the dm to be notified
+	Err       error
+}
+
+// add or remove the emoji reaction of the user to a message of the dm
+type ReactMessageUseCase struct {
+	chatRepo repository.ChatRepo
+	req      *ReactMessageUseCaseReq
+	res      *ReactMessageUseCaseRes
+}
+
+func (uc *ReactMessageUseCase) Execute() {
+	if uc.req.emoji == "" || utf8.RuneCountInString(uc.req.emoji) > maxEmojiLength {
+		uc.res.Err = ErrInvalidEmoji
+		return
+	}
+
+	// the dm is loaded for the participants only, without the messages
+	dm, err := uc.chatRepo.GetDirectMessage(uc.req.dmId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	var partnerId uuid.UUID
+	switch uc.req.userId {
+	case dm.Creator.ID:
+		partnerId = dm.Receiver.ID
+	case dm.Receiver.ID:
+		partnerId = dm.Creator.ID
+	default:
+		uc.res.Err = ErrNotParticipant
+		return
+	}
+
+	message, err := uc.chatRepo.GetMessage(dm.ID, uc.req.messageId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if uc.req.add && message.IsDeleted() {
+		uc.res.Err = ErrMessageDeleted
+		return
+	}
+
+	if uc.req.add {
+		err = uc.chatRepo.AddReaction(dm.ID, message.ID, uc.req.userId, uc.req.emoji)
+	} else {
+		err = uc.chatRepo.RemoveReaction(dm.ID, message.ID, uc.req.userId, uc.req.emoji)
+	}
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.PartnerId = partnerId
+	uc.res.Err = nil
+}
+
+func NewReactMessageUseCase(
+	chatRepo repository.ChatRepo,
+	req *ReactMessageUseCaseReq,
+	res *ReactMessageUseCaseRes,
+) usecase.UseCase {
+	return &ReactMessageUseCase{chatRepo, req, res}
+}
+
+func NewReactMessageUseCaseReq(
+	userId uuid.UUID,
+	dmId uuid.UUID,
+	messageId uuid.UUID,
+	emoji string,
+	add bool,
+) *ReactMessageUseCaseReq {
+	return &ReactMessageUseCaseReq{userId, dmId, messageId, emoji, add}
+}
+
+func NewReactMessageUseCaseRes() *ReactMessageUseCaseRes {
+	return &ReactMessageUseCaseRes{}
+}
@@ -0,0 +1,100 @@
+package react_message_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	usecase "mashu.example/internal/usecase/chat/react_message"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestAddReaction(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.EXPECT().AddReaction(dm.ID, message.ID, receiver.ID, "👍")
+
+	req := usecase.NewReactMessageUseCaseReq(receiver.ID, dm.ID, message.ID, "👍", true)
+	res := usecase.NewReactMessageUseCaseRes()
+	usecase.NewReactMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, sender.ID, res.PartnerId)
+}
+
+func TestRemoveReaction(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+	chatRepo.EXPECT().GetMessage(dm.ID, message.ID).Return(message, nil)
+	chatRepo.EXPECT().RemoveReaction(dm.ID, message.ID, sender.ID, "👍")
+
+	req := usecase.NewReactMessageUseCaseReq(sender.ID, dm.ID, message.ID, "👍", false)
+	res := usecase.NewReactMessageUseCaseRes()
+	usecase.NewReactMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, receiver.ID, res.PartnerId)
+}
+
+func TestReactByNonParticipant(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	message := chat.NewMessage(uuid.New(), sender.ID, "hi")
+
+	chatRepo.EXPECT().GetDirectMessage(dm.ID).Return(dm, nil)
+
+	req := usecase.NewReactMessageUseCaseReq(uuid.New(), dm.ID, message.ID, "👍", true)
+	res := usecase.NewReactMessageUseCaseRes()
+	usecase.NewReactMessageUseCase(chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotParticipant)
+}
+
+func TestReactWithInvalidEmoji(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	for _, emoji := range []string{"", strings.Repeat("a", 17)} {
+		req := usecase.NewReactMessageUseCaseReq(uuid.New(), uuid.New(), uuid.New(), emoji, true)
+		res := usecase.NewReactMessageUseCaseRes()
+		usecase.NewReactMessageUseCase(chatRepo, req, res).Execute()
+
+		assert.ErrorIs(t, res.Err, usecase.ErrInvalidEmoji)
+	}
+}
+
+func TestReactWithStoreError(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	dmId := uuid.New()
+	errStore := errors.New("connection refused")
+	chatRepo.EXPECT().GetDirectMessage(dmId).Return(nil, errStore)
+
+	req := usecase.NewReactMessageUseCaseReq(uuid.New(), dmId, uuid.New(), "👍", true)
+	res := usecase.NewReactMessageUseCaseRes()
+	usecase.NewReactMessageUseCase(chatRepo, req, res).Execute()
+
+	var errDMNotFound *repository.ErrDMNotFound
+	assert.ErrorIs(t, res.Err, errStore)
+	assert.False(t, errors.As(res.Err, &errDMNotFound))
+}
@@ -0,0 +1,118 @@
+package search_messages
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrEmptyKeyword     = errors.New("the keyword to search can't be empty")
+	ErrInvalidDateRange = errors.New("the start of the date range must be before the end")
+)
+
+type SearchResultDTO struct {
+	DMId      uuid.UUID
+	MessageId uuid.UUID
+	OwnerId   uuid.UUID
+	Snippet   string
+	Timestamp time.Time
+	EditedAt  time.Time // zero if the message is never edited
+}
+
+type SearchMessagesUseCaseReq struct {
+	userId    uuid.UUID
+	keyword   string
+	partnerId uuid.UUID // nil to search all the dms of the user
+	since     time.Time // zero for no lower bound
+	until     time.Time // zero for no upper bound
+	offset    int
+	limit     int
+}
+
+type SearchMessagesUseCaseRes struct {
+	Results    []SearchResultDTO
+	NextOffset int // offset of the next page, -1 if there is no more result
+	Err        error
+}
+
+// search the messages of the dms the user takes part in by the keyword
+type SearchMessagesUseCase struct {
+	chatRepo repository.ChatRepo
+	req      *SearchMessagesUseCaseReq
+	res      *SearchMessagesUseCaseRes
+}
+
+func (uc *SearchMessagesUseCase) Execute() {
+	keyword := strings.TrimSpace(uc.req.keyword)
+	if keyword == "" {
+		uc.res.Err = ErrEmptyKeyword
+		return
+	}
+	if !uc.req.since.IsZero() && !uc.req.until.IsZero() && !uc.req.since.Before(uc.req.until) {
+		uc.res.Err = ErrInvalidDateRange
+		return
+	}
+
+	// query one more result to know if there is a next page
+	results, err := uc.chatRepo.SearchMessages(&repository.MessageSearchQuery{
+		UserId:    uc.req.userId,
+		Keyword:   keyword,
+		PartnerId: uc.req.partnerId,
+		Since:     uc.req.since,
+		Until:     uc.req.until,
+		Offset:    uc.req.offset,
+		Limit:     uc.req.limit + 1,
+	})
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.NextOffset = -1
+	if len(results) > uc.req.limit {
+		results = results[:uc.req.limit]
+		uc.res.NextOffset = uc.req.offset + uc.req.limit
+	}
+	for _, result := range results {
+		uc.res.Results = append(uc.res.Results, SearchResultDTO{
+			DMId:      result.DMId,
+			MessageId: result.Message.ID,
+			OwnerId:   result.Message.OwnerId,
+			Snippet:   result.Snippet,
+			Timestamp: result.Message.Timestamp,
+			EditedAt:  result.Message.EditedAt,
+		})
+	}
+	uc.res.Err = nil
+}
+
+func NewSearchMessagesUseCase(
+	chatRepo repository.ChatRepo,
+	req *SearchMessagesUseCaseReq,
+	res *SearchMessagesUseCaseRes,
+) usecase.UseCase {
+	return &SearchMessagesUseCase{chatRepo, req, res}
+}
+
+func NewSearchMessagesUseCaseReq(
+	userId uuid.UUID,
+	keyword string,
+	partnerId uuid.UUID,
+	since time.Time,
+	until time.Time,
+	offset int,
+	limit int,
+) *SearchMessagesUseCaseReq {
+	return &SearchMessagesUseCaseReq{userId, keyword, partnerId, since, until, offset, limit}
+}
+
+func NewSearchMessagesUseCaseRes() *SearchMessagesUseCaseRes {
+	return &SearchMessagesUseCaseRes{Results: []SearchResultDTO{}}
+}
@@ -0,0 +1,79 @@
+package search_messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	chat "mashu.example/internal/entity/chat"
+	usecase "mashu.example/internal/usecase/chat/search_messages"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestSearchMessages(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	userId, dmId := uuid.New(), uuid.New()
+	results := []*repository.MessageSearchResult{}
+	for i := 0; i < 3; i++ {
+		results = append(results, &repository.MessageSearchResult{
+			DMId:    dmId,
+			Message: chat.NewMessage(uuid.New(), userId, "lunch"),
+			Snippet: "<mark>lunch</mark>",
+		})
+	}
+
+	// one more result is queried to know the next page
+	chatRepo.EXPECT().SearchMessages(&repository.MessageSearchQuery{
+		UserId:  userId,
+		Keyword: "lunch",
+		Offset:  2,
+		Limit:   3,
+	}).Return(results, nil)
+
+	req := usecase.NewSearchMessagesUseCaseReq(userId, " lunch ", uuid.Nil, time.Time{}, time.Time{}, 2, 2)
+	res := usecase.NewSearchMessagesUseCaseRes()
+	usecase.NewSearchMessagesUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, results[0].Message.ID, res.Results[0].MessageId)
+	assert.Equal(t, "<mark>lunch</mark>", res.Results[0].Snippet)
+	assert.Equal(t, 4, res.NextOffset)
+}
+
+func TestSearchMessagesOnLastPage(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	userId := uuid.New()
+	chatRepo.EXPECT().SearchMessages(&repository.MessageSearchQuery{
+		UserId:  userId,
+		Keyword: "lunch",
+		Limit:   11,
+	}).Return([]*repository.MessageSearchResult{}, nil)
+
+	req := usecase.NewSearchMessagesUseCaseReq(userId, "lunch", uuid.Nil, time.Time{}, time.Time{}, 0, 10)
+	res := usecase.NewSearchMessagesUseCaseRes()
+	usecase.NewSearchMessagesUseCase(chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Empty(t, res.Results)
+	assert.Equal(t, -1, res.NextOffset)
+}
+
+func TestSearchMessagesWithInvalidQuery(t *testing.T) {
+	_, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	now := time.Now()
+	req := usecase.NewSearchMessagesUseCaseReq(uuid.New(), " ", uuid.Nil, time.Time{}, time.Time{}, 0, 10)
+	res := usecase.NewSearchMessagesUseCaseRes()
+	usecase.NewSearchMessagesUseCase(chatRepo, req, res).Execute()
+	assert.ErrorIs(t, res.Err, usecase.ErrEmptyKeyword)
+
+	req = usecase.NewSearchMessagesUseCaseReq(uuid.New(), "lunch", uuid.Nil, now, now.Add(-time.Hour), 0, 10)
+	res = usecase.NewSearchMessagesUseCaseRes()
+	usecase.NewSearchMessagesUseCase(chatRepo, req, res).Execute()
+	assert.ErrorIs(t, res.Err, usecase.ErrInvalidDateRange)
+}
@@ -0,0 +1,97 @@
+package send_group_message
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotGroupMember    = errors.New("only the members can send messages to the group chat")
+	ErrChatRoomNotExist  = errors.New("chatroom not exist")
+	ErrSaveMessageFailed = errors.New("failed to save message")
+)
+
+type SendGroupMessageUseCaseReq struct {
+	senderId  uuid.UUID
+	groupId   uuid.UUID
+	message   string
+	timestamp time.Time
+}
+
+type SendGroupMessageUseCaseRes struct {
+	GroupChatId uuid.UUID
+	MessageId   uuid.UUID
+	Timestamp   time.Time
+	MemberIds   []uuid.UUID // the members of the group to receive the message
+	Err         error
+}
+
+type SendGroupMessageUseCase struct {
+	groupRepo repository.GroupRepo
+	chatRepo  repository.ChatRepo
+	req       *SendGroupMessageUseCaseReq
+	res       *SendGroupMessageUseCaseRes
+}
+
+func (uc *SendGroupMessageUseCase) Execute() {
+	group, err := uc.groupRepo.GetGroupById(uc.req.groupId)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	if !group.IsMember(uc.req.senderId) {
+		uc.res.Err = ErrNotGroupMember
+		return
+	}
+
+	groupChat, err := uc.chatRepo.GetGroupChatByGroupId(group.ID)
+	if err != nil {
+		uc.res.Err = ErrChatRoomNotExist
+		logrus.Error(ErrChatRoomNotExist.Error())
+		return
+	}
+
+	// only the new message is saved, the history of the chat is not loaded
+	message := groupChat.AddMessageWithTime(uc.req.senderId, uc.req.message, uc.req.timestamp)
+
+	if err := uc.chatRepo.AddGroupMessage(groupChat.ID, message); err != nil {
+		uc.res.Err = ErrSaveMessageFailed
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	uc.res.GroupChatId = groupChat.ID
+	uc.res.MessageId = message.ID
+	uc.res.Timestamp = message.Timestamp
+	uc.res.MemberIds = group.MemberIds()
+	uc.res.Err = nil
+}
+
+func NewSendGroupMessageUseCase(
+	groupRepo repository.GroupRepo,
+	chatRepo repository.ChatRepo,
+	req *SendGroupMessageUseCaseReq,
+	res *SendGroupMessageUseCaseRes,
+) usecase.UseCase {
+	return &SendGroupMessageUseCase{groupRepo, chatRepo, req, res}
+}
+
+func NewSendGroupMessageUseCaseReq(
+	senderId uuid.UUID,
+	groupId uuid.UUID,
+	message string,
+	timestamp time.Time,
+) *SendGroupMessageUseCaseReq {
+	return &SendGroupMessageUseCaseReq{senderId, groupId, message, timestamp}
+}
+
+func NewSendGroupMessageUseCaseRes() *SendGroupMessageUseCaseRes {
+	return &SendGroupMessageUseCaseRes{}
+}
@@ -0,0 +1,61 @@
+package send_group_message_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	entity_enums "mashu.example/internal/entity/enums"
+	usecase "mashu.example/internal/usecase/chat/send_group_message"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestSendGroupMessage(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	member := entity.NewUser(uuid.New(), "member", "Member", "member@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+	group.AddMember(member.ID, member.ID, owner.ID)
+	groupChat := chat.NewGroupChat(uuid.New(), group.ID)
+
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	chatRepo.EXPECT().GetGroupChatByGroupId(group.ID).Return(groupChat, nil)
+	var savedMessage *chat.Message
+	chatRepo.
+		EXPECT().
+		AddGroupMessage(groupChat.ID, gomock.AssignableToTypeOf(&chat.Message{})).
+		Do(func(_ uuid.UUID, arg *chat.Message) { savedMessage = arg })
+
+	timestamp := time.Now()
+	req := usecase.NewSendGroupMessageUseCaseReq(member.ID, group.ID, "hi", timestamp)
+	res := usecase.NewSendGroupMessageUseCaseRes()
+	usecase.NewSendGroupMessageUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, groupChat.ID, res.GroupChatId)
+	assert.Equal(t, savedMessage.ID, res.MessageId)
+	assert.Equal(t, member.ID, savedMessage.OwnerId)
+	assert.Equal(t, "hi", savedMessage.Content)
+	assert.Equal(t, timestamp, res.Timestamp)
+	assert.ElementsMatch(t, []uuid.UUID{owner.ID, member.ID}, res.MemberIds)
+}
+
+func TestSendGroupMessageByNonMember(t *testing.T) {
+	_, _, groupRepo, chatRepo := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+
+	req := usecase.NewSendGroupMessageUseCaseReq(uuid.New(), group.ID, "hi", time.Now())
+	res := usecase.NewSendGroupMessageUseCaseRes()
+	usecase.NewSendGroupMessageUseCase(groupRepo, chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNotGroupMember)
+}
@@ -14,6 +14,7 @@ import (
 var (
 	ErrChatRoomNotExist  = errors.New("chatroom not exist")
 	ErrSaveMessageFailed = errors.New("failed to save message")
+	ErrBlockedUser       = errors.New("can not send message to the user because of blocking")
 )
 
 type SendMessageUseCaseReq struct {
@@ -24,7 +25,10 @@ type SendMessageUseCaseReq struct {
 }
 
 type SendMessageUseCaseRes struct {
-	Err error
+	DirectMessageId uuid.UUID
+	MessageId       uuid.UUID
+	Timestamp       time.Time
+	Err             error
 }
 
 type SendMessageUseCase struct {
@@ -43,7 +47,13 @@ func (uc *SendMessageUseCase) Execute() {
 	}
 	receiver, err := uc.userRepo.GetUserById(uc.req.receiverId)
 	if err != nil {
-		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.senderId}
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.receiverId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if sender.IsBlockedWith(receiver) {
+		uc.res.Err = ErrBlockedUser
 		logrus.Error(uc.res.Err)
 		return
 	}
@@ -55,18 +65,31 @@ func (uc *SendMessageUseCase) Execute() {
 		return
 	}
 
-	dm.Messages = append(dm.Messages, chat.NewMessageWithTime(
-		uuid.New(),
-		sender.ID,
-		uc.req.message,
-		uc.req.timestamp,
-	))
+	// only the new message is saved, the others may be edited or deleted meanwhile
+	message := dm.AddMessageWithTime(sender.ID, uc.req.message, uc.req.timestamp)
 
-	if err := uc.chatRepo.SaveDirectMessage(dm); err != nil {
+	if err := uc.chatRepo.AddMessage(dm.ID, message); err != nil {
 		uc.res.Err = ErrSaveMessageFailed
 		logrus.Error(uc.res.Err)
 		return
 	}
+
+	// queue the message for the receiver who may be offline, the message is
+	// already saved, so it is not failed by the delivery bookkeeping
+	if err := uc.chatRepo.AddPendingMessage(receiver.ID, chat.NewPendingMessage(dm.ID, message)); err != nil {
+		logrus.Error(err)
+	}
+	// the message from a muted sender is still delivered but not counted as unread
+	if !receiver.HasMuted(sender.ID) {
+		if err := uc.chatRepo.IncrUnreadCount(receiver.ID, dm.ID); err != nil {
+			logrus.Error(err)
+		}
+	}
+
+	uc.res.DirectMessageId = dm.ID
+	uc.res.MessageId = message.ID
+	uc.res.Timestamp = message.Timestamp
+	uc.res.Err = nil
 }
 
 func NewSendMessageUseCase(
@@ -1,7 +1,6 @@
 package send_message_test
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"mashu.example/internal/entity"
 	chat "mashu.example/internal/entity/chat"
 	usecase "mashu.example/internal/usecase/chat/send_message"
+	"mashu.example/internal/usecase/repository"
 	"mashu.example/internal/usecase/tests"
 )
 
@@ -26,12 +26,20 @@ func TestSendMessage(t *testing.T) {
 	dmId := uuid.New()
 	dm := chat.NewDirectMessage(dmId, sender, receiver)
 
-	var updatedDM *chat.DirectMessage
+	var savedMessage *chat.Message
 	chatRepo.EXPECT().GetDMByUserId(sender.ID, receiver.ID).Return(dm, nil)
 	chatRepo.
 		EXPECT().
-		SaveDirectMessage(gomock.AssignableToTypeOf(&chat.DirectMessage{})).
-		Do(func(arg *chat.DirectMessage) { updatedDM = arg })
+		AddMessage(dmId, gomock.AssignableToTypeOf(&chat.Message{})).
+		Do(func(_ uuid.UUID, arg *chat.Message) { savedMessage = arg })
+	chatRepo.
+		EXPECT().
+		AddPendingMessage(receiver.ID, gomock.AssignableToTypeOf(&chat.PendingMessage{})).
+		Do(func(_ uuid.UUID, arg *chat.PendingMessage) {
+			assert.Equal(t, dmId, arg.DMId)
+			assert.Equal(t, "Hi! How are you?", arg.Message.Content)
+		})
+	chatRepo.EXPECT().IncrUnreadCount(receiver.ID, dmId)
 
 	now := time.Now()
 	req := usecase.NewSendMessageUseCaseReq(
@@ -46,8 +54,72 @@ func TestSendMessage(t *testing.T) {
 	uc.Execute()
 
 	assert.Nil(t, res.Err)
-	assert.Len(t, updatedDM.Messages, 1)
-	fmt.Println(updatedDM.Messages[0].Content)
-	assert.Equal(t, "Hi! How are you?", updatedDM.Messages[0].Content)
-	assert.Equal(t, now, updatedDM.Messages[0].Timestamp)
+	assert.Equal(t, dmId, res.DirectMessageId)
+	assert.Equal(t, savedMessage.ID, res.MessageId)
+	assert.Equal(t, now, res.Timestamp)
+	assert.Equal(t, sender.ID, savedMessage.OwnerId)
+	assert.Equal(t, "Hi! How are you?", savedMessage.Content)
+	assert.Equal(t, now, savedMessage.Timestamp)
+}
+
+func TestSendMessageWithoutDM(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+
+	userRepo.EXPECT().GetUserById(sender.ID).Return(sender, nil)
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+	chatRepo.EXPECT().GetDMByUserId(sender.ID, receiver.ID).Return(nil, &repository.ErrDMNotFound{})
+
+	req := usecase.NewSendMessageUseCaseReq(sender.ID, receiver.ID, "Hi! How are you?", time.Now())
+	res := usecase.NewSendMessageUseCaseRes()
+	usecase.NewSendMessageUseCase(userRepo, chatRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrChatRoomNotExist)
+}
+
+func TestSendMessageToBlockedUser(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	sender.Block(receiver.ID)
+
+	userRepo.EXPECT().GetUserById(sender.ID).Return(sender, nil)
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+
+	req := usecase.NewSendMessageUseCaseReq(sender.ID, receiver.ID, "Hi! How are you?", time.Now())
+	res := usecase.NewSendMessageUseCaseRes()
+	uc := usecase.NewSendMessageUseCase(userRepo, chatRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrBlockedUser)
+}
+
+func TestSendMessageToUserMutingSender(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	receiver.Mute(sender.ID)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+
+	userRepo.EXPECT().GetUserById(sender.ID).Return(sender, nil)
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+	chatRepo.EXPECT().GetDMByUserId(sender.ID, receiver.ID).Return(dm, nil)
+	chatRepo.EXPECT().AddMessage(dm.ID, gomock.AssignableToTypeOf(&chat.Message{}))
+	chatRepo.EXPECT().AddPendingMessage(receiver.ID, gomock.AssignableToTypeOf(&chat.PendingMessage{}))
+	// the message is delivered without being counted as unread
+	chatRepo.EXPECT().IncrUnreadCount(gomock.Any(), gomock.Any()).Times(0)
+
+	req := usecase.NewSendMessageUseCaseReq(sender.ID, receiver.ID, "Hi! How are you?", time.Now())
+	res := usecase.NewSendMessageUseCaseRes()
+	uc := usecase.NewSendMessageUseCase(userRepo, chatRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, dm.Messages, 1)
 }
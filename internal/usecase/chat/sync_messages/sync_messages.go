@@ -0,0 +1,100 @@
+package sync_messages
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+// DTO for entity PendingMessage
+type PendingMessageDTO struct {
+	DMId      uuid.UUID
+	ID        uuid.UUID
+	OwnerId   uuid.UUID
+	Content   string
+	Timestamp time.Time
+	EditedAt  time.Time // zero if the message is never edited
+	DeletedAt time.Time // zero if the message is not deleted
+}
+
+type SyncMessagesUseCaseReq struct {
+	userId uuid.UUID
+	cursor time.Time // sent time of the last received message, zero for the first sync
+}
+
+type SyncMessagesUseCaseRes struct {
+	Messages []PendingMessageDTO
+	Cursor   time.Time // cursor of the next sync
+	Err      error
+}
+
+// get the messages missed since the cursor, the messages at or before the
+// cursor are received by the client, so they are removed from the queue
+type SyncMessagesUseCase struct {
+	userRepo repository.UserRepo
+	chatRepo repository.ChatRepo
+	req      *SyncMessagesUseCaseReq
+	res      *SyncMessagesUseCaseRes
+}
+
+func (uc *SyncMessagesUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !uc.req.cursor.IsZero() {
+		if err := uc.chatRepo.RemovePendingMessages(user.ID, uc.req.cursor); err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+	}
+
+	pendingMessages, err := uc.chatRepo.GetPendingMessages(user.ID, uc.req.cursor)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Cursor = uc.req.cursor
+	for _, pending := range pendingMessages {
+		uc.res.Messages = append(uc.res.Messages, PendingMessageDTO{
+			DMId:      pending.DMId,
+			ID:        pending.Message.ID,
+			OwnerId:   pending.Message.OwnerId,
+			Content:   pending.Message.Content,
+			Timestamp: pending.Message.Timestamp,
+			EditedAt:  pending.Message.EditedAt,
+			DeletedAt: pending.Message.DeletedAt,
+		})
+		uc.res.Cursor = pending.Message.Timestamp
+	}
+
+	uc.res.Err = nil
+}
+
+func NewSyncMessagesUseCase(
+	userRepo repository.UserRepo,
+	chatRepo repository.ChatRepo,
+	req *SyncMessagesUseCaseReq,
+	res *SyncMessagesUseCaseRes,
+) usecase.UseCase {
+	return &SyncMessagesUseCase{userRepo, chatRepo, req, res}
+}
+
+func NewSyncMessagesUseCaseReq(userId uuid.UUID, cursor time.Time) *SyncMessagesUseCaseReq {
+	return &SyncMessagesUseCaseReq{userId, cursor}
+}
+
+func NewSyncMessagesUseCaseRes() *SyncMessagesUseCaseRes {
+	return &SyncMessagesUseCaseRes{
+		Messages: []PendingMessageDTO{},
+	}
+}
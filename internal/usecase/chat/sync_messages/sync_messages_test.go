@@ -0,0 +1,68 @@
+package sync_messages_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	chat "mashu.example/internal/entity/chat"
+	usecase "mashu.example/internal/usecase/chat/sync_messages"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestFirstSync(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	sender := entity.NewUser(uuid.New(), "sender", "Sender", "sender@email.com", true)
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	dm := chat.NewDirectMessage(uuid.New(), sender, receiver)
+	now := time.Now()
+	pendingMessages := []*chat.PendingMessage{
+		chat.NewPendingMessage(dm.ID, dm.AddMessageWithTime(sender.ID, "hi", now)),
+		chat.NewPendingMessage(dm.ID, dm.AddMessageWithTime(sender.ID, "are you there?", now.Add(time.Second))),
+		chat.NewPendingMessage(dm.ID, dm.AddMessageWithTime(sender.ID, "oops", now.Add(2*time.Second))),
+	}
+	// the messages are changed after they are queued
+	pendingMessages[1].Message.Edit("still there?")
+	pendingMessages[2].Message.Delete()
+
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+	chatRepo.EXPECT().GetPendingMessages(receiver.ID, time.Time{}).Return(pendingMessages, nil)
+
+	req := usecase.NewSyncMessagesUseCaseReq(receiver.ID, time.Time{})
+	res := usecase.NewSyncMessagesUseCaseRes()
+	usecase.NewSyncMessagesUseCase(userRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.Messages, 3)
+	assert.Equal(t, dm.ID, res.Messages[0].DMId)
+	assert.Equal(t, "hi", res.Messages[0].Content)
+	assert.True(t, res.Messages[0].EditedAt.IsZero())
+	assert.Equal(t, "still there?", res.Messages[1].Content)
+	assert.False(t, res.Messages[1].EditedAt.IsZero())
+	assert.Empty(t, res.Messages[2].Content)
+	assert.False(t, res.Messages[2].DeletedAt.IsZero())
+	assert.Equal(t, now.Add(2*time.Second), res.Cursor)
+}
+
+func TestSyncSinceCursor(t *testing.T) {
+	userRepo, _, _, chatRepo := tests.SetupTestRepositories(t)
+
+	receiver := entity.NewUser(uuid.New(), "receiver", "Receiver", "receiver@email.com", true)
+	cursor := time.Now()
+
+	// the messages received before the cursor are removed
+	userRepo.EXPECT().GetUserById(receiver.ID).Return(receiver, nil)
+	chatRepo.EXPECT().RemovePendingMessages(receiver.ID, cursor)
+	chatRepo.EXPECT().GetPendingMessages(receiver.ID, cursor).Return([]*chat.PendingMessage{}, nil)
+
+	req := usecase.NewSyncMessagesUseCaseReq(receiver.ID, cursor)
+	res := usecase.NewSyncMessagesUseCaseRes()
+	usecase.NewSyncMessagesUseCase(userRepo, chatRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Empty(t, res.Messages)
+	assert.Equal(t, cursor, res.Cursor)
+}
@@ -14,6 +14,7 @@ import (
 var (
 	ErrAddCommentUnderPrivatePost     = errors.New("can not add comment under private post")
 	ErrAddCommentUnderFollowrOnlyPost = errors.New("only the follower can comment under the follower-only post")
+	ErrAddCommentBlocked              = errors.New("can not add comment under the post because of blocking")
 )
 
 type AddCommentUseCaseReq struct {
@@ -23,7 +24,8 @@ type AddCommentUseCaseReq struct {
 }
 
 type AddCommentUseCaseRes struct {
-	Err error
+	CommentId uuid.UUID
+	Err       error
 }
 
 type AddCommentUseCase struct {
@@ -54,6 +56,12 @@ func (uc *AddCommentUseCase) Execute() {
 		return
 	}
 
+	if commentOwner.IsBlockedWith(post.Owner) {
+		uc.res.Err = ErrAddCommentBlocked
+		logrus.Error(uc.res.Err)
+		return
+	}
+
 	if post.Permission == entity_enums.POST_FOLLOWER_ONLY {
 		isFollower := false
 		for _, followerID := range post.Owner.Followers {
@@ -70,14 +78,15 @@ func (uc *AddCommentUseCase) Execute() {
 		}
 	}
 
-	post.Comments = append(post.Comments, entity.NewComment(
-		uuid.New(),
-		commentOwner,
-		post,
-		uc.req.content,
-	))
+	comment := entity.NewComment(uuid.New(), commentOwner, post, uc.req.content)
+	if err := uc.postRepo.AddComment(comment); err != nil {
+		logrus.Errorf("failed to add comment (postId: %s)", post.ID)
+		uc.res.Err = err
+		return
+	}
 
-	uc.postRepo.Save(post)
+	uc.res.CommentId = comment.ID
+	uc.res.Err = nil
 }
 
 func NewAddCommentUseCase(
@@ -30,8 +30,8 @@ func TestAddCommentUnderMyOwnPost(t *testing.T) {
 
 	postRepo.EXPECT().GetPostById(postId).Return(post, nil)
 	userRepo.EXPECT().GetUserById(ownerId).Return(commentOwner, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
+	postRepo.EXPECT().AddComment(gomock.AssignableToTypeOf(&entity.Comment{})).Do(
+		func(arg *entity.Comment) { post.Comments = append(post.Comments, arg) },
 	)
 
 	req := usecase.NewAddCommentUseCaseReq(ownerId, postId, "Good!")
@@ -45,6 +45,7 @@ func TestAddCommentUnderMyOwnPost(t *testing.T) {
 	}
 
 	assert.Nil(t, res.Err)
+	assert.Equal(t, res.CommentId, post.Comments[0].ID)
 	assert.Equal(t, "Good!", post.Comments[0].Content)
 	assert.Equal(t, post.ID, post.Comments[0].Post.ID)
 	assert.Equal(t, commentOwner.ID, post.Comments[0].Owner.ID)
@@ -60,8 +61,8 @@ func TestAddMultipleCommentUnderPost(t *testing.T) {
 	// first comment
 	userRepo.EXPECT().GetUserById(commentOwner.ID).Return(commentOwner, nil)
 	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
+	postRepo.EXPECT().AddComment(gomock.AssignableToTypeOf(&entity.Comment{})).Do(
+		func(arg *entity.Comment) { post.Comments = append(post.Comments, arg) },
 	)
 
 	req := usecase.NewAddCommentUseCaseReq(commentOwner.ID, post.ID, "good article!")
@@ -77,8 +78,8 @@ func TestAddMultipleCommentUnderPost(t *testing.T) {
 	// second comment
 	userRepo.EXPECT().GetUserById(postOwner.ID).Return(postOwner, nil)
 	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
+	postRepo.EXPECT().AddComment(gomock.AssignableToTypeOf(&entity.Comment{})).Do(
+		func(arg *entity.Comment) { post.Comments = append(post.Comments, arg) },
 	)
 
 	req = usecase.NewAddCommentUseCaseReq(postOwner.ID, post.ID, "thanks!")
@@ -101,8 +102,8 @@ func TestAddCommentUnderPublicPost(t *testing.T) {
 
 	userRepo.EXPECT().GetUserById(commentOwner.ID).Return(commentOwner, nil)
 	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
+	postRepo.EXPECT().AddComment(gomock.AssignableToTypeOf(&entity.Comment{})).Do(
+		func(arg *entity.Comment) { post.Comments = append(post.Comments, arg) },
 	)
 
 	req := usecase.NewAddCommentUseCaseReq(commentOwner.ID, post.ID, "good article!")
@@ -149,8 +150,8 @@ func TestAddCommentUnderFollowerOnlyPostWithFollow(t *testing.T) {
 
 	userRepo.EXPECT().GetUserById(commentOwner.ID).Return(commentOwner, nil)
 	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
+	postRepo.EXPECT().AddComment(gomock.AssignableToTypeOf(&entity.Comment{})).Do(
+		func(arg *entity.Comment) { post.Comments = append(post.Comments, arg) },
 	)
 
 	req := usecase.NewAddCommentUseCaseReq(commentOwner.ID, post.ID, "good article!")
@@ -186,3 +187,23 @@ func TestAddCommentUnderPrivatePost(t *testing.T) {
 	assert.Error(t, res.Err)
 	assert.Equal(t, 0, len(post.Comments))
 }
+
+func TestAddCommentUnderPostOfBlockingUser(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	commentOwner := entity.NewUser(uuid.New(), "comment_owner", "comment owner", "comment_owner@email.com", false)
+	postOwner := entity.NewUser(uuid.New(), "post_owner", "post owner", "post_owner@email.com", false)
+	postOwner.Block(commentOwner.ID)
+	post := entity.NewPost(uuid.New(), "Learning Domain Driven Design", "...", postOwner, nil, entity_enums.POST_PUBLIC)
+
+	userRepo.EXPECT().GetUserById(commentOwner.ID).Return(commentOwner, nil)
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+
+	req := usecase.NewAddCommentUseCaseReq(commentOwner.ID, post.ID, "good article!")
+	res := usecase.NewAddCommentUseCaseRes()
+	uc := usecase.NewAddCommentUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+	assert.ErrorIs(t, res.Err, usecase.ErrAddCommentBlocked)
+	assert.Equal(t, 0, len(post.Comments))
+}
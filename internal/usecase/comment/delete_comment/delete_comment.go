@@ -5,15 +5,16 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/exp/slices"
-	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
 )
 
+var (
+	ErrNotOwnerOfComment = errors.New("only the comment owner can delete this comment")
+)
+
 type DeleteCommentUseCaseReq struct {
 	ownerId   uuid.UUID
-	postId    uuid.UUID
 	commentId uuid.UUID
 }
 
@@ -29,27 +30,24 @@ type DeleteCommentUseCase struct {
 }
 
 func (uc *DeleteCommentUseCase) Execute() {
-	post, err := uc.postRepo.GetPostById(uc.req.postId)
+	comment, err := uc.postRepo.GetCommentById(uc.req.commentId)
 	if err != nil {
-		logrus.Errorf("failed to get post (postId: %s)", uc.req.postId)
+		logrus.Errorf("failed to get comment (commentId: %s)", uc.req.commentId)
 		uc.res.Err = err
 		return
 	}
 
-	idx := slices.IndexFunc(post.Comments, func(comment *entity.Comment) bool {
-		return comment.ID == uc.req.commentId
-	})
-
-	if post.Comments[idx].Owner.ID != uc.req.ownerId {
-		errMsg := "only the comment owner can delete this comment"
-		logrus.Errorf(errMsg)
-		uc.res.Err = errors.New(errMsg)
+	if comment.Owner.ID != uc.req.ownerId {
+		uc.res.Err = ErrNotOwnerOfComment
+		logrus.Error(ErrNotOwnerOfComment.Error())
 		return
 	}
 
-	post.Comments = slices.Delete(post.Comments, idx, idx+1)
-
-	uc.postRepo.Save(post)
+	if err := uc.postRepo.DeleteComment(comment.ID); err != nil {
+		logrus.Errorf("failed to delete comment (commentId: %s)", comment.ID)
+		uc.res.Err = err
+		return
+	}
 }
 
 func NewDeletePoseUseCase(
@@ -61,12 +59,8 @@ func NewDeletePoseUseCase(
 	return &DeleteCommentUseCase{userRepo, postRepo, req, res}
 }
 
-func NewDeletePostUseCaseReq(
-	ownerId uuid.UUID,
-	postId uuid.UUID,
-	commentId uuid.UUID,
-) *DeleteCommentUseCaseReq {
-	return &DeleteCommentUseCaseReq{ownerId, postId, commentId}
+func NewDeletePostUseCaseReq(ownerId uuid.UUID, commentId uuid.UUID) *DeleteCommentUseCaseReq {
+	return &DeleteCommentUseCaseReq{ownerId, commentId}
 }
 
 func NewDeletePostUseCaseRes() *DeleteCommentUseCaseRes {
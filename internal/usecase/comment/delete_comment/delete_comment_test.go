@@ -3,12 +3,12 @@ package comment_test
 import (
 	"testing"
 
-	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"mashu.example/internal/entity"
 	entity_enums "mashu.example/internal/entity/enums"
 	usecase "mashu.example/internal/usecase/comment/delete_comment"
+	"mashu.example/internal/usecase/repository"
 	"mashu.example/internal/usecase/tests"
 )
 
@@ -28,15 +28,14 @@ func TestDeleteComment(t *testing.T) {
 		entity_enums.POST_PUBLIC,
 	)
 	commentId := uuid.New()
-	post.Comments = append(post.Comments, entity.NewComment(commentId, owner, post, "Good!"))
+	comment := entity.NewComment(commentId, owner, post, "Good!")
+	post.Comments = append(post.Comments, comment)
 	assert.Equal(t, 1, len(post.Comments))
 
-	postRepo.EXPECT().GetPostById(postId).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
-	)
+	postRepo.EXPECT().GetCommentById(commentId).Return(comment, nil)
+	postRepo.EXPECT().DeleteComment(commentId).Return(nil)
 
-	req := usecase.NewDeletePostUseCaseReq(ownerId, postId, commentId)
+	req := usecase.NewDeletePostUseCaseReq(ownerId, commentId)
 	res := usecase.NewDeletePostUseCaseRes()
 	uc := usecase.NewDeletePoseUseCase(userRepo, postRepo, req, res)
 
@@ -47,7 +46,6 @@ func TestDeleteComment(t *testing.T) {
 	}
 
 	assert.Nil(t, res.Err)
-	assert.Equal(t, 0, len(post.Comments))
 }
 
 func TestDeleteNotMyOwnComment(t *testing.T) {
@@ -66,15 +64,13 @@ func TestDeleteNotMyOwnComment(t *testing.T) {
 		entity_enums.POST_PUBLIC,
 	)
 	commentId := uuid.New()
-	post.Comments = append(post.Comments, entity.NewComment(commentId, owner, post, "Good!"))
+	comment := entity.NewComment(commentId, owner, post, "Good!")
+	post.Comments = append(post.Comments, comment)
 	assert.Equal(t, 1, len(post.Comments))
 
-	postRepo.EXPECT().GetPostById(postId).Return(post, nil)
-	postRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Post{})).Do(
-		func(arg *entity.Post) { post = arg },
-	)
+	postRepo.EXPECT().GetCommentById(commentId).Return(comment, nil)
 
-	req := usecase.NewDeletePostUseCaseReq(uuid.New(), postId, commentId)
+	req := usecase.NewDeletePostUseCaseReq(uuid.New(), commentId)
 	res := usecase.NewDeletePostUseCaseRes()
 	uc := usecase.NewDeletePoseUseCase(userRepo, postRepo, req, res)
 
@@ -84,5 +80,23 @@ func TestDeleteNotMyOwnComment(t *testing.T) {
 		t.Errorf("failed to execute usecase")
 	}
 
+	assert.ErrorIs(t, res.Err, usecase.ErrNotOwnerOfComment)
 	assert.Equal(t, "only the comment owner can delete this comment", res.Err.Error())
+	assert.Equal(t, 1, len(post.Comments))
+}
+
+func TestDeleteNonExistComment(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	commentId := uuid.New()
+	postRepo.EXPECT().GetCommentById(commentId).Return(nil, &repository.ErrCommentNotFound{CommentId: commentId})
+
+	req := usecase.NewDeletePostUseCaseReq(uuid.New(), commentId)
+	res := usecase.NewDeletePostUseCaseRes()
+	uc := usecase.NewDeletePoseUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	var errCommentNotFound *repository.ErrCommentNotFound
+	assert.ErrorAs(t, res.Err, &errCommentNotFound)
 }
@@ -0,0 +1,86 @@
+package comment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+var (
+	ErrNoPermissionToViewComments = errors.New("no permission to view the comments of the post")
+)
+
+type GetCommentsUseCaseReq struct {
+	postId   uuid.UUID
+	viewerId uuid.UUID
+	offset   int
+	limit    int
+}
+
+type GetCommentsUseCaseRes struct {
+	Comments []*types.CommentInfo
+	Total    int64 // number of all comments under the post
+	Err      error
+}
+
+type GetCommentsUseCase struct {
+	postRepo repository.PostRepo
+	req      *GetCommentsUseCaseReq
+	res      *GetCommentsUseCaseRes
+}
+
+func (uc *GetCommentsUseCase) Execute() {
+	post, err := uc.postRepo.GetPostById(uc.req.postId)
+	if err != nil {
+		uc.res.Err = &repository.ErrPostNotFound{PostId: uc.req.postId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !post.IsVisibleTo(uc.req.viewerId) {
+		uc.res.Err = ErrNoPermissionToViewComments
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	comments, total, err := uc.postRepo.GetCommentsByPostId(post.ID, uc.req.offset, uc.req.limit)
+	if err != nil {
+		logrus.Errorf("failed to get comments (postId: %s)", post.ID)
+		uc.res.Err = err
+		return
+	}
+
+	commentInfos := []*types.CommentInfo{}
+	for _, comment := range comments {
+		commentInfos = append(commentInfos, types.NewCommentInfo(comment))
+	}
+
+	uc.res.Comments = commentInfos
+	uc.res.Total = total
+	uc.res.Err = nil
+}
+
+func NewGetCommentsUseCase(
+	postRepo repository.PostRepo,
+	req *GetCommentsUseCaseReq,
+	res *GetCommentsUseCaseRes,
+) usecase.UseCase {
+	return &GetCommentsUseCase{postRepo, req, res}
+}
+
+func NewGetCommentsUseCaseReq(
+	postId uuid.UUID,
+	viewerId uuid.UUID,
+	offset int,
+	limit int,
+) *GetCommentsUseCaseReq {
+	return &GetCommentsUseCaseReq{postId, viewerId, offset, limit}
+}
+
+func NewGetCommentsUseCaseRes() *GetCommentsUseCaseRes {
+	return &GetCommentsUseCaseRes{}
+}
@@ -0,0 +1,77 @@
+package comment_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+	usecase "mashu.example/internal/usecase/comment/get_comments"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestGetComments(t *testing.T) {
+	_, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	postOwner := entity.NewUser(uuid.New(), "post_owner", "post owner", "post_owner@email.com", true)
+	commentOwner := entity.NewUser(uuid.New(), "comment_owner", "comment owner", "comment_owner@email.com", true)
+	post := entity.NewPost(uuid.New(), "Learning Domain Driven Design", "...", postOwner, nil, entity_enums.POST_PUBLIC)
+	comments := []*entity.Comment{
+		entity.NewComment(uuid.New(), commentOwner, post, "good article!"),
+		entity.NewComment(uuid.New(), postOwner, post, "thanks!"),
+	}
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+	postRepo.EXPECT().GetCommentsByPostId(post.ID, 0, 2).Return(comments, int64(3), nil)
+
+	req := usecase.NewGetCommentsUseCaseReq(post.ID, commentOwner.ID, 0, 2)
+	res := usecase.NewGetCommentsUseCaseRes()
+	uc := usecase.NewGetCommentsUseCase(postRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, int64(3), res.Total)
+	assert.Len(t, res.Comments, 2)
+	assert.Equal(t, "good article!", res.Comments[0].Content)
+	assert.Equal(t, commentOwner.ID, res.Comments[0].OwnerId)
+	assert.Equal(t, post.ID, res.Comments[0].PostId)
+	assert.Equal(t, "thanks!", res.Comments[1].Content)
+}
+
+func TestGetCommentsUnderFollowerOnlyPostWithoutFollow(t *testing.T) {
+	_, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	postOwner := entity.NewUser(uuid.New(), "post_owner", "post owner", "post_owner@email.com", false)
+	post := entity.NewPost(uuid.New(), "Learning Domain Driven Design", "...", postOwner, nil, entity_enums.POST_FOLLOWER_ONLY)
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+
+	req := usecase.NewGetCommentsUseCaseReq(post.ID, uuid.New(), 0, 20)
+	res := usecase.NewGetCommentsUseCaseRes()
+	uc := usecase.NewGetCommentsUseCase(postRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, usecase.ErrNoPermissionToViewComments)
+	assert.Nil(t, res.Comments)
+}
+
+func TestGetCommentsUnderNonExistPost(t *testing.T) {
+	_, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	postId := uuid.New()
+	postRepo.EXPECT().GetPostById(postId).Return(nil, gorm.ErrRecordNotFound)
+
+	req := usecase.NewGetCommentsUseCaseReq(postId, uuid.New(), 0, 20)
+	res := usecase.NewGetCommentsUseCaseRes()
+	uc := usecase.NewGetCommentsUseCase(postRepo, req, res)
+
+	uc.Execute()
+
+	var errPostNotFound *repository.ErrPostNotFound
+	assert.ErrorAs(t, res.Err, &errPostNotFound)
+}
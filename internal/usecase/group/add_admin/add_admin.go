@@ -36,11 +36,20 @@ type AddAdminUseCase struct {
 }
 
 func (uc *AddAdminUseCase) Execute() {
-	_, err := uc.userRepo.GetUserById(uc.req.memberId)
+	if _, err := uc.userRepo.GetUserById(uc.req.memberId); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
 	group, err := uc.groupRepo.GetGroupById(uc.req.groupId)
-	_, err = uc.userRepo.GetUserById(uc.req.adminId)
 	if err != nil {
 		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if _, err := uc.userRepo.GetUserById(uc.req.adminId); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
 		return
 	}
 
@@ -73,7 +82,11 @@ func (uc *AddAdminUseCase) Execute() {
 	}
 
 	group.AddAdmin(uc.req.memberId, uc.req.adminId)
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.res.Err = err
+		return
+	}
+
 	uc.res.Err = nil
 }
 
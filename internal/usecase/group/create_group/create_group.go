@@ -15,7 +15,8 @@ type CreateGroupUseCaseReq struct {
 }
 
 type CreateGroupUseCaseRes struct {
-	Err error
+	GroupId uuid.UUID
+	Err     error
 }
 
 type CreateGroupUseCase struct {
@@ -34,8 +35,12 @@ func (gc *CreateGroupUseCase) Execute() {
 	}
 
 	group := entity.NewGroup(uuid.New(), gc.req.name, owner, gc.req.permission)
-	gc.groupRepo.Save(group)
+	if err := gc.groupRepo.Save(group); err != nil {
+		gc.res.Err = err
+		return
+	}
 
+	gc.res.GroupId = group.ID
 	gc.res.Err = nil
 }
 
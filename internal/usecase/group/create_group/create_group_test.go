@@ -49,6 +49,7 @@ func TestCreatePublicGroup(t *testing.T) {
 		t.Errorf("failed to execute usecase")
 	}
 
+	assert.Equal(t, res.GroupId, resultGroup.ID)
 	assert.Equal(t, resultGroup.Name, "First Group")
 	assert.Equal(t, resultGroup.Owner, owner)
 	assert.Equal(t, resultGroup.Permission, entity_enums.GROUP_PUBLIC)
@@ -74,7 +74,11 @@ func (uc *DeleteAdminUseCase) Execute() {
 
 	group.Admins = slices.Delete(group.Admins, idx, idx+1)
 
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.res.Err = err
+		return
+	}
+
 	uc.res.Err = nil
 }
 
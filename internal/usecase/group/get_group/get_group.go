@@ -0,0 +1,97 @@
+package get_group
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+type GetGroupUseCaseReq struct {
+	groupId  uuid.UUID
+	viewerId uuid.UUID
+}
+
+type GetGroupUseCaseRes struct {
+	Group *types.GroupInfo
+	Err   error
+}
+
+type GetGroupUseCase struct {
+	groupRepo repository.GroupRepo
+	req       *GetGroupUseCaseReq
+	res       *GetGroupUseCaseRes
+}
+
+// rules:
+// - the basic information of the group can be viewed by anyone
+// - the members and admins of the public group can be viewed by anyone
+// - the members and admins of the private group can only be viewed by the members
+// - the join requests and invitations can only be viewed by the owner/admins
+func (uc *GetGroupUseCase) Execute() {
+	group, err := uc.groupRepo.GetGroupById(uc.req.groupId)
+	if err != nil {
+		logrus.Errorf("failed to get group (groupId: %s)", uc.req.groupId)
+		uc.res.Err = err
+		return
+	}
+
+	groupInfo := types.NewGroupInfo(group)
+
+	if group.Permission == entity_enums.GROUP_PUBLIC || group.IsMember(uc.req.viewerId) {
+		groupInfo.Members = []*types.GroupMemberInfo{}
+		for _, member := range group.Members {
+			groupInfo.Members = append(groupInfo.Members, &types.GroupMemberInfo{
+				UserId:     member.UserId,
+				InvitedBy:  member.InvitedBy,
+				ApprovedBy: member.ApprovedBy,
+				JoinAt:     member.JoinAt,
+			})
+		}
+
+		groupInfo.Admins = []*types.GroupAdminInfo{}
+		for _, admin := range group.Admins {
+			groupInfo.Admins = append(groupInfo.Admins, &types.GroupAdminInfo{
+				UserId:     admin.UserId,
+				PromotedBy: admin.PromotedBy,
+				PromotedAt: admin.PromotedAt,
+			})
+		}
+	}
+
+	if group.IsOwner(uc.req.viewerId) || group.IsAdmin(uc.req.viewerId) {
+		groupInfo.JoinRequests = []uuid.UUID{}
+		for _, joinRequest := range group.JoinRequests {
+			groupInfo.JoinRequests = append(groupInfo.JoinRequests, joinRequest.Requester)
+		}
+
+		groupInfo.Invitations = []*types.GroupInvitationInfo{}
+		for _, invitation := range group.InviteRequests {
+			groupInfo.Invitations = append(groupInfo.Invitations, &types.GroupInvitationInfo{
+				Invitee: invitation.Invitee,
+				Inviter: invitation.Inviter,
+			})
+		}
+	}
+
+	uc.res.Group = groupInfo
+	uc.res.Err = nil
+}
+
+func NewGetGroupUseCase(
+	groupRepo repository.GroupRepo,
+	req *GetGroupUseCaseReq,
+	res *GetGroupUseCaseRes,
+) usecase.UseCase {
+	return &GetGroupUseCase{groupRepo, req, res}
+}
+
+func NewGetGroupUseCaseReq(groupId uuid.UUID, viewerId uuid.UUID) *GetGroupUseCaseReq {
+	return &GetGroupUseCaseReq{groupId, viewerId}
+}
+
+func NewGetGroupUseCaseRes() *GetGroupUseCaseRes {
+	return &GetGroupUseCaseRes{}
+}
@@ -0,0 +1,123 @@
+package get_group_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/group/get_group"
+	"mashu.example/internal/usecase/tests"
+)
+
+func newTestGroup(permission entity_enums.GroupPrivacy) (*entity.Group, uuid.UUID, uuid.UUID) {
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	memberId := uuid.New()
+	adminId := uuid.New()
+
+	group := entity.NewGroup(uuid.New(), "my group", owner, permission)
+	group.AddMember(memberId, uuid.Nil, owner.ID)
+	group.AddMember(adminId, uuid.Nil, owner.ID)
+	group.AddAdmin(adminId, owner.ID)
+	group.AddJoinRequest(uuid.New())
+	group.AddInviteRequest(uuid.New(), memberId)
+
+	return group, memberId, adminId
+}
+
+func TestGetPublicGroup(t *testing.T) {
+	_, _, groupRepo, _ := tests.SetupTestRepositories(t)
+
+	group, memberId, adminId := newTestGroup(entity_enums.GROUP_PUBLIC)
+	groupRepo.EXPECT().GetGroupById(group.ID).AnyTimes().Return(group, nil)
+
+	t.Run("stranger", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, uuid.New())
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Equal(t, group.ID, res.Group.ID)
+		assert.Equal(t, "my group", res.Group.Name)
+		assert.Equal(t, group.Owner.ID, res.Group.OwnerId)
+		assert.Len(t, res.Group.Members, 2)
+		assert.Len(t, res.Group.Admins, 1)
+		assert.Nil(t, res.Group.JoinRequests)
+		assert.Nil(t, res.Group.Invitations)
+	})
+
+	t.Run("member", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, memberId)
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Nil(t, res.Group.JoinRequests)
+		assert.Nil(t, res.Group.Invitations)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, adminId)
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Group.JoinRequests, 1)
+		assert.Len(t, res.Group.Invitations, 1)
+		assert.Equal(t, memberId, res.Group.Invitations[0].Inviter)
+	})
+}
+
+func TestGetPrivateGroup(t *testing.T) {
+	_, _, groupRepo, _ := tests.SetupTestRepositories(t)
+
+	group, memberId, _ := newTestGroup(entity_enums.GROUP_PRIVATE)
+	groupRepo.EXPECT().GetGroupById(group.ID).AnyTimes().Return(group, nil)
+
+	t.Run("stranger", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, uuid.New())
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Equal(t, "my group", res.Group.Name)
+		assert.Nil(t, res.Group.Members)
+		assert.Nil(t, res.Group.Admins)
+	})
+
+	t.Run("member", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, memberId)
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Group.Members, 2)
+		assert.Len(t, res.Group.Admins, 1)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		req := get_group.NewGetGroupUseCaseReq(group.ID, group.Owner.ID)
+		res := get_group.NewGetGroupUseCaseRes()
+		get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Group.Members, 2)
+		assert.Len(t, res.Group.JoinRequests, 1)
+	})
+}
+
+func TestGetNonExistGroup(t *testing.T) {
+	_, _, groupRepo, _ := tests.SetupTestRepositories(t)
+
+	groupId := uuid.New()
+	groupRepo.EXPECT().GetGroupById(groupId).Return(nil, gorm.ErrRecordNotFound)
+
+	req := get_group.NewGetGroupUseCaseReq(groupId, uuid.New())
+	res := get_group.NewGetGroupUseCaseRes()
+	get_group.NewGetGroupUseCase(groupRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, gorm.ErrRecordNotFound)
+	assert.Nil(t, res.Group)
+}
@@ -23,7 +23,6 @@ const (
 
 type HandleInviteRequestUseCaseReq struct {
 	inviteeId  uuid.UUID
-	inviterId  uuid.UUID
 	groupId    uuid.UUID
 	action     HandleInviteRequestAction
 	approverId uuid.UUID
@@ -45,10 +44,6 @@ func (uc *HandleInviteRequestUseCase) Execute() {
 		uc.res.Err = err
 		return
 	}
-	if _, err := uc.userRepo.GetUserById(uc.req.inviterId); err != nil {
-		uc.res.Err = err
-		return
-	}
 	if _, err := uc.userRepo.GetUserById(uc.req.approverId); err != nil {
 		uc.res.Err = err
 		return
@@ -73,12 +68,16 @@ func (uc *HandleInviteRequestUseCase) Execute() {
 	}
 
 	if uc.req.action == ACCEPT_INVITE_REQUEST {
-		group.AddMember(uc.req.inviteeId, uc.req.inviterId, uc.req.approverId)
+		group.AddMember(uc.req.inviteeId, invitation.Inviter, uc.req.approverId)
 	}
 
 	group.RemoveInvitation(invitation.Invitee)
 
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.res.Err = err
+		return
+	}
+
 	uc.res.Err = nil
 }
 
@@ -93,12 +92,11 @@ func NewHandleInviteRequestUseCase(
 
 func NewHandleInviteRequestUseCaseReq(
 	inviteeId uuid.UUID,
-	inviterId uuid.UUID,
 	groupId uuid.UUID,
 	action HandleInviteRequestAction,
 	approverId uuid.UUID,
 ) HandleInviteRequestUseCaseReq {
-	return HandleInviteRequestUseCaseReq{inviteeId, inviterId, groupId, action, approverId}
+	return HandleInviteRequestUseCaseReq{inviteeId, groupId, action, approverId}
 }
 
 func NewHandleInviteRequestUseCaseRes() HandleInviteRequestUseCaseRes {
@@ -28,13 +28,11 @@ func TestAcceptInvitationRequestByGroupOwner(t *testing.T) {
 	groupId := uuid.New()
 
 	invitee := entity.NewUser(inviteeId, "invitee", "Invitee", "invitee@email.com", false)
-	inviter := entity.NewUser(inviterId, "inviter", "Inviter", "inviter@email.com", false)
 	owner := entity.NewUser(ownerId, "owner", "Owner", "owner@email.com", false)
 	group := entity.NewGroup(groupId, "my group", owner, entity_enums.GROUP_PUBLIC)
 	group.AddInviteRequest(inviteeId, inviterId)
 
 	userRepo.EXPECT().GetUserById(inviteeId).Return(invitee, nil)
-	userRepo.EXPECT().GetUserById(inviterId).Return(inviter, nil)
 	userRepo.EXPECT().GetUserById(ownerId).Return(owner, nil)
 	groupRepo.EXPECT().GetGroupById(groupId).Return(group, nil)
 	groupRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Group{})).Do(
@@ -43,7 +41,6 @@ func TestAcceptInvitationRequestByGroupOwner(t *testing.T) {
 
 	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
 		inviteeId,
-		inviterId,
 		groupId,
 		handle_invite_request.ACCEPT_INVITE_REQUEST,
 		ownerId,
@@ -55,6 +52,7 @@ func TestAcceptInvitationRequestByGroupOwner(t *testing.T) {
 	assert.Nil(t, res.Err)
 	assert.Len(t, group.InviteRequests, 0)
 	assert.Len(t, group.Members, 1)
+	assert.Equal(t, group.Members[0].InvitedBy, inviterId)
 }
 
 func TestAcceptInvitationByGroupAdmin(t *testing.T) {
@@ -67,7 +65,6 @@ func TestAcceptInvitationByGroupAdmin(t *testing.T) {
 	groupId := uuid.New()
 
 	invitee := entity.NewUser(inviteeId, "invitee", "Invitee", "invitee@email.com", false)
-	inviter := entity.NewUser(inviterId, "inviter", "Inviter", "inviter@email.com", false)
 	admin := entity.NewUser(adminId, "admin", "Admin", "admin@email.com", false)
 	owner := entity.NewUser(ownerId, "owner", "Owner", "owner@email.com", false)
 	group := entity.NewGroup(groupId, "my group", owner, entity_enums.GROUP_PUBLIC)
@@ -75,7 +72,6 @@ func TestAcceptInvitationByGroupAdmin(t *testing.T) {
 	group.AddInviteRequest(inviteeId, inviterId)
 
 	userRepo.EXPECT().GetUserById(inviteeId).Return(invitee, nil)
-	userRepo.EXPECT().GetUserById(inviterId).Return(inviter, nil)
 	userRepo.EXPECT().GetUserById(adminId).Return(admin, nil)
 	userRepo.EXPECT().GetUserById(ownerId).Return(owner, nil)
 	groupRepo.EXPECT().GetGroupById(groupId).Return(group, nil)
@@ -85,7 +81,6 @@ func TestAcceptInvitationByGroupAdmin(t *testing.T) {
 
 	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
 		inviteeId,
-		inviterId,
 		groupId,
 		handle_invite_request.ACCEPT_INVITE_REQUEST,
 		adminId,
@@ -108,13 +103,11 @@ func TestRejectInvitationRequestByGroupOwner(t *testing.T) {
 	groupId := uuid.New()
 
 	invitee := entity.NewUser(inviteeId, "invitee", "Invitee", "invitee@email.com", false)
-	inviter := entity.NewUser(inviterId, "inviter", "Inviter", "inviter@email.com", false)
 	owner := entity.NewUser(ownerId, "owner", "Owner", "owner@email.com", false)
 	group := entity.NewGroup(groupId, "my group", owner, entity_enums.GROUP_PUBLIC)
 	group.AddInviteRequest(inviteeId, inviterId)
 
 	userRepo.EXPECT().GetUserById(inviteeId).Return(invitee, nil)
-	userRepo.EXPECT().GetUserById(inviterId).Return(inviter, nil)
 	userRepo.EXPECT().GetUserById(ownerId).Return(owner, nil)
 	groupRepo.EXPECT().GetGroupById(groupId).Return(group, nil)
 	groupRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Group{})).Do(
@@ -123,7 +116,6 @@ func TestRejectInvitationRequestByGroupOwner(t *testing.T) {
 
 	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
 		inviteeId,
-		inviterId,
 		groupId,
 		handle_invite_request.REJECT_INVITE_REQUEST,
 		ownerId,
@@ -147,7 +139,6 @@ func TestRejectInvitationByGroupAdmin(t *testing.T) {
 	groupId := uuid.New()
 
 	invitee := entity.NewUser(inviteeId, "invitee", "Invitee", "invitee@email.com", false)
-	inviter := entity.NewUser(inviterId, "inviter", "Inviter", "inviter@email.com", false)
 	admin := entity.NewUser(adminId, "admin", "Admin", "admin@email.com", false)
 	owner := entity.NewUser(ownerId, "owner", "Owner", "owner@email.com", false)
 	group := entity.NewGroup(groupId, "my group", owner, entity_enums.GROUP_PUBLIC)
@@ -155,7 +146,6 @@ func TestRejectInvitationByGroupAdmin(t *testing.T) {
 	group.AddInviteRequest(inviteeId, inviterId)
 
 	userRepo.EXPECT().GetUserById(inviteeId).Return(invitee, nil)
-	userRepo.EXPECT().GetUserById(inviterId).Return(inviter, nil)
 	userRepo.EXPECT().GetUserById(adminId).Return(admin, nil)
 	userRepo.EXPECT().GetUserById(ownerId).Return(owner, nil)
 	groupRepo.EXPECT().GetGroupById(groupId).Return(group, nil)
@@ -165,7 +155,6 @@ func TestRejectInvitationByGroupAdmin(t *testing.T) {
 
 	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
 		inviteeId,
-		inviterId,
 		groupId,
 		handle_invite_request.REJECT_INVITE_REQUEST,
 		adminId,
@@ -203,7 +192,6 @@ func TestTryToAcceptInvitationWithoutPermission(t *testing.T) {
 
 	req := handle_invite_request.NewHandleInviteRequestUseCaseReq(
 		inviteeId,
-		inviterId,
 		groupId,
 		handle_invite_request.ACCEPT_INVITE_REQUEST,
 		inviterId,
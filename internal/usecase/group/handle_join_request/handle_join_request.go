@@ -75,7 +75,11 @@ func (uc *HandleJoinRequestUseCase) Execute() {
 
 	group.RemoveJoinRequest(uc.req.requesterId)
 
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.res.Err = err
+		return
+	}
+
 	uc.res.Err = nil
 }
 
@@ -72,7 +72,11 @@ func (uc *InviteJoinGroupUseCase) Execute() {
 
 	group.AddInviteRequest(uc.req.invitee, uc.req.inviter)
 
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.res.Err = err
+		return
+	}
+
 	uc.res.Err = nil
 }
 
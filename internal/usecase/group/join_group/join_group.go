@@ -1,11 +1,21 @@
 package join_group
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	entity_enums "mashu.example/internal/entity/enums"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
 )
 
+var (
+	ErrGroupIsPrivate     = errors.New("user can only be invited into the private group")
+	ErrIsAlreadyMember    = errors.New("the user is already a member of the group")
+	ErrIsAlreadyRequested = errors.New("the user has sent the join request")
+)
+
 type JoinGroupUseCaseReq struct {
 	userId  uuid.UUID
 	groupId uuid.UUID
@@ -35,9 +45,31 @@ func (uc *JoinGroupUseCase) Execute() {
 		return
 	}
 
+	if group.Permission == entity_enums.GROUP_PRIVATE {
+		uc.Res.Err = ErrGroupIsPrivate
+		logrus.Error(uc.Res.Err)
+		return
+	}
+
+	if group.IsMember(user.ID) {
+		uc.Res.Err = ErrIsAlreadyMember
+		logrus.Error(uc.Res.Err)
+		return
+	}
+
+	if group.FindJoinRequest(user.ID) != nil {
+		uc.Res.Err = ErrIsAlreadyRequested
+		logrus.Error(uc.Res.Err)
+		return
+	}
+
 	group.AddJoinRequest(user.ID)
 
-	uc.groupRepo.Save(group)
+	if err := uc.groupRepo.Save(group); err != nil {
+		uc.Res.Err = err
+		return
+	}
+
 	uc.Res.Err = nil
 }
 
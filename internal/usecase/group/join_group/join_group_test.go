@@ -1,6 +1,7 @@
 package join_group_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -40,3 +41,86 @@ func TestJoinPublicGroup(t *testing.T) {
 	assert.Len(t, group.Admins, 0)
 	assert.Equal(t, group.Owner, owner)
 }
+
+func TestJoinGroupWithSaveError(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+
+	errSave := errors.New("failed to save group")
+	groupRepo := mock.NewMockGroupRepo(mockCtrl)
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	groupRepo.EXPECT().Save(gomock.AssignableToTypeOf(group)).Return(errSave)
+
+	req := join_group.NewJoinGroupUseCaseReq(user.ID, group.ID)
+	res := join_group.NewJoinGroupUseCaseRes()
+	gc := join_group.NewJoinGroupUseCase(userRepo, groupRepo, &req, &res)
+
+	gc.Execute()
+
+	assert.ErrorIs(t, res.Err, errSave)
+}
+
+func TestJoinPrivateGroup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PRIVATE)
+
+	groupRepo := mock.NewMockGroupRepo(mockCtrl)
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	req := join_group.NewJoinGroupUseCaseReq(user.ID, group.ID)
+	res := join_group.NewJoinGroupUseCaseRes()
+	gc := join_group.NewJoinGroupUseCase(userRepo, groupRepo, &req, &res)
+
+	gc.Execute()
+
+	assert.ErrorIs(t, res.Err, join_group.ErrGroupIsPrivate)
+	assert.Len(t, group.JoinRequests, 0)
+}
+
+func TestJoinGroupTwice(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", false)
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	member := entity.NewUser(uuid.New(), "member", "Member", "member@email.com", false)
+	group := entity.NewGroup(uuid.New(), "group", owner, entity_enums.GROUP_PUBLIC)
+	group.AddJoinRequest(user.ID)
+	group.AddMember(member.ID, uuid.Nil, owner.ID)
+
+	groupRepo := mock.NewMockGroupRepo(mockCtrl)
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	groupRepo.EXPECT().GetGroupById(group.ID).Return(group, nil).Times(3)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(member.ID).Return(member, nil)
+	userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+
+	for _, testCase := range []struct {
+		userId uuid.UUID
+		err    error
+	}{
+		{user.ID, join_group.ErrIsAlreadyRequested},
+		{member.ID, join_group.ErrIsAlreadyMember},
+		{owner.ID, join_group.ErrIsAlreadyMember},
+	} {
+		req := join_group.NewJoinGroupUseCaseReq(testCase.userId, group.ID)
+		res := join_group.NewJoinGroupUseCaseRes()
+		join_group.NewJoinGroupUseCase(userRepo, groupRepo, &req, &res).Execute()
+
+		assert.ErrorIs(t, res.Err, testCase.err)
+	}
+	assert.Len(t, group.JoinRequests, 1)
+	assert.Len(t, group.Members, 1)
+}
@@ -26,7 +26,8 @@ type CreatePostUseCaseReq struct {
 }
 
 type CreatePostUseCaseRes struct {
-	Err error
+	PostId uuid.UUID
+	Err    error
 }
 
 type CreatePostUseCase struct {
@@ -61,8 +62,13 @@ func (uc *CreatePostUseCase) Execute() {
 		logrus.Error(uc.res.Err)
 		return
 	}
-	uc.postRepo.Save(post)
+	if err := uc.postRepo.Save(post); err != nil {
+		logrus.Errorf("failed to save post (postId: %s)", post.ID)
+		uc.res.Err = err
+		return
+	}
 
+	uc.res.PostId = post.ID
 	uc.res.Err = nil
 }
 
@@ -38,6 +38,7 @@ func TestCreatePost(t *testing.T) {
 	uc.Execute()
 
 	assert.Nil(t, res.Err)
+	assert.Equal(t, res.PostId, resultPost.ID)
 	assert.Equal(t, resultPost.Title, "Hi, Golang")
 	assert.Equal(t, resultPost.Content, "Hello world!\nHello Clean Architecture!\nHello Domain Driven Design!")
 	assert.Equal(t, resultPost.Owner, owner)
@@ -9,6 +9,10 @@ import (
 	"mashu.example/internal/usecase/repository"
 )
 
+var (
+	ErrNotOwnerOfPost = errors.New("only the post owner can delete the post")
+)
+
 type DeletePostUseCaseReq struct {
 	postId  uuid.UUID
 	ownerId uuid.UUID
@@ -33,9 +37,8 @@ func (uc *DeletePostUseCase) Execute() {
 	}
 
 	if post.Owner.ID != uc.req.ownerId {
-		message := "only the post owner can delete the post"
-		logrus.Error(message)
-		uc.res.Err = errors.New(message)
+		uc.res.Err = ErrNotOwnerOfPost
+		logrus.Error(ErrNotOwnerOfPost.Error())
 		return
 	}
 
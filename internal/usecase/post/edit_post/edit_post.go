@@ -49,7 +49,11 @@ func (uc *EditPostUseCase) Execute() {
 	post.Content = uc.req.newContent
 	post.UpdatedAt = time.Now()
 
-	uc.postRepo.Save(post)
+	if err := uc.postRepo.Save(post); err != nil {
+		logrus.Errorf("failed to save post (postId: %s)", post.ID)
+		uc.res.Err = err
+		return
+	}
 }
 
 func NewEditPostUseCase(
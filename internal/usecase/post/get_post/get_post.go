@@ -0,0 +1,76 @@
+package get_post
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+var (
+	ErrNoPermissionToViewPost = errors.New("no permission to view the post")
+)
+
+type GetPostUseCaseReq struct {
+	postId   uuid.UUID
+	viewerId uuid.UUID
+}
+
+type GetPostUseCaseRes struct {
+	Post *types.PostInfo
+	Err  error
+}
+
+type GetPostUseCase struct {
+	userRepo repository.UserRepo
+	postRepo repository.PostRepo
+	req      *GetPostUseCaseReq
+	res      *GetPostUseCaseRes
+}
+
+func (uc *GetPostUseCase) Execute() {
+	post, err := uc.postRepo.GetPostById(uc.req.postId)
+	if err != nil {
+		uc.res.Err = &repository.ErrPostNotFound{PostId: uc.req.postId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	// load the owner with the follow relation to check the permission
+	owner, err := uc.userRepo.GetUserById(post.Owner.ID)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: post.Owner.ID}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	post.Owner = owner
+
+	if !post.IsVisibleTo(uc.req.viewerId) {
+		uc.res.Err = ErrNoPermissionToViewPost
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	uc.res.Post = types.NewPostInfo(post)
+	uc.res.Err = nil
+}
+
+func NewGetPostUseCase(
+	userRepo repository.UserRepo,
+	postRepo repository.PostRepo,
+	req *GetPostUseCaseReq,
+	res *GetPostUseCaseRes,
+) usecase.UseCase {
+	return &GetPostUseCase{userRepo, postRepo, req, res}
+}
+
+func NewGetPostUseCaseReq(postId uuid.UUID, viewerId uuid.UUID) *GetPostUseCaseReq {
+	return &GetPostUseCaseReq{postId, viewerId}
+}
+
+func NewGetPostUseCaseRes() *GetPostUseCaseRes {
+	return &GetPostUseCaseRes{}
+}
@@ -0,0 +1,125 @@
+package get_post_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/post/get_post"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestGetPublicPost(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	post := entity.NewPost(uuid.New(), "Hi, Golang", "Hello world!", owner, nil, entity_enums.POST_PUBLIC)
+	viewerId := uuid.New()
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+	userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+
+	req := get_post.NewGetPostUseCaseReq(post.ID, viewerId)
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, res.Post.ID, post.ID)
+	assert.Equal(t, res.Post.Title, "Hi, Golang")
+	assert.Equal(t, res.Post.Content, "Hello world!")
+	assert.Equal(t, res.Post.OwnerId, owner.ID)
+	assert.Equal(t, res.Post.GroupId, uuid.Nil)
+}
+
+func TestGetFollowerOnlyPost(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", true)
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	owner.Followers = append(owner.Followers, follower.ID)
+
+	// the post repository does not load the follow relation of the owner
+	post := entity.NewPost(
+		uuid.New(),
+		"Hi, Golang",
+		"Hello world!",
+		entity.NewUser(owner.ID, "owner", "Owner", "owner@email.com", true),
+		nil,
+		entity_enums.POST_FOLLOWER_ONLY,
+	)
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+	userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+
+	req := get_post.NewGetPostUseCaseReq(post.ID, follower.ID)
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, res.Post.ID, post.ID)
+}
+
+func TestGetPrivatePostOfOthers(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	post := entity.NewPost(uuid.New(), "Hi, Golang", "Hello world!", owner, nil, entity_enums.POST_PRIVATE)
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+	userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+
+	req := get_post.NewGetPostUseCaseReq(post.ID, uuid.New())
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, get_post.ErrNoPermissionToViewPost)
+	assert.Nil(t, res.Post)
+}
+
+func TestGetNonExistPost(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	postId := uuid.New()
+	postRepo.EXPECT().GetPostById(postId).Return(nil, gorm.ErrRecordNotFound)
+
+	req := get_post.NewGetPostUseCaseReq(postId, uuid.New())
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	var errPostNotFound *repository.ErrPostNotFound
+	assert.ErrorAs(t, res.Err, &errPostNotFound)
+	assert.Equal(t, errPostNotFound.PostId, postId)
+}
+
+func TestGetPostOfBlockingUser(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	viewerId := uuid.New()
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	owner.Block(viewerId)
+	post := entity.NewPost(uuid.New(), "Hi, Golang", "Hello world!", owner, nil, entity_enums.POST_PUBLIC)
+
+	postRepo.EXPECT().GetPostById(post.ID).Return(post, nil)
+	userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+
+	req := get_post.NewGetPostUseCaseReq(post.ID, viewerId)
+	res := get_post.NewGetPostUseCaseRes()
+	uc := get_post.NewGetPostUseCase(userRepo, postRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, get_post.ErrNoPermissionToViewPost)
+	assert.Nil(t, res.Post)
+}
@@ -0,0 +1,71 @@
+package get_user_posts
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+type GetUserPostsUseCaseReq struct {
+	ownerId  uuid.UUID
+	viewerId uuid.UUID
+}
+
+type GetUserPostsUseCaseRes struct {
+	Posts []*types.PostInfo // only the posts visible to the viewer
+	Err   error
+}
+
+type GetUserPostsUseCase struct {
+	userRepo repository.UserRepo
+	postRepo repository.PostRepo
+	req      *GetUserPostsUseCaseReq
+	res      *GetUserPostsUseCaseRes
+}
+
+func (uc *GetUserPostsUseCase) Execute() {
+	owner, err := uc.userRepo.GetUserById(uc.req.ownerId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.ownerId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	posts, err := uc.postRepo.GetPostByUserId(owner.ID)
+	if err != nil {
+		logrus.Errorf("failed to get posts of user (userId: %s)", owner.ID)
+		uc.res.Err = err
+		return
+	}
+
+	postInfos := []*types.PostInfo{}
+	for _, post := range posts {
+		// use the owner with the follow relation to check the permission
+		post.Owner = owner
+		if post.IsVisibleTo(uc.req.viewerId) {
+			postInfos = append(postInfos, types.NewPostInfo(post))
+		}
+	}
+
+	uc.res.Posts = postInfos
+	uc.res.Err = nil
+}
+
+func NewGetUserPostsUseCase(
+	userRepo repository.UserRepo,
+	postRepo repository.PostRepo,
+	req *GetUserPostsUseCaseReq,
+	res *GetUserPostsUseCaseRes,
+) usecase.UseCase {
+	return &GetUserPostsUseCase{userRepo, postRepo, req, res}
+}
+
+func NewGetUserPostsUseCaseReq(ownerId uuid.UUID, viewerId uuid.UUID) *GetUserPostsUseCaseReq {
+	return &GetUserPostsUseCaseReq{ownerId, viewerId}
+}
+
+func NewGetUserPostsUseCaseRes() *GetUserPostsUseCaseRes {
+	return &GetUserPostsUseCaseRes{}
+}
@@ -0,0 +1,82 @@
+package get_user_posts_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+	"mashu.example/internal/usecase/post/get_user_posts"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/tests"
+)
+
+func TestGetUserPosts(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	owner := entity.NewUser(uuid.New(), "owner", "Owner", "owner@email.com", true)
+	publicPost := entity.NewPost(uuid.New(), "public", "public content", owner, nil, entity_enums.POST_PUBLIC)
+	followerOnlyPost := entity.NewPost(uuid.New(), "follower", "follower content", owner, nil, entity_enums.POST_FOLLOWER_ONLY)
+	privatePost := entity.NewPost(uuid.New(), "private", "private content", owner, nil, entity_enums.POST_PRIVATE)
+	posts := []*entity.Post{publicPost, followerOnlyPost, privatePost}
+
+	t.Run("stranger", func(t *testing.T) {
+		userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+		postRepo.EXPECT().GetPostByUserId(owner.ID).Return(posts, nil)
+
+		req := get_user_posts.NewGetUserPostsUseCaseReq(owner.ID, uuid.New())
+		res := get_user_posts.NewGetUserPostsUseCaseRes()
+		get_user_posts.NewGetUserPostsUseCase(userRepo, postRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Posts, 1)
+		assert.Equal(t, res.Posts[0].ID, publicPost.ID)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		userRepo.EXPECT().GetUserById(owner.ID).Return(owner, nil)
+		postRepo.EXPECT().GetPostByUserId(owner.ID).Return(posts, nil)
+
+		req := get_user_posts.NewGetUserPostsUseCaseReq(owner.ID, owner.ID)
+		res := get_user_posts.NewGetUserPostsUseCaseRes()
+		get_user_posts.NewGetUserPostsUseCase(userRepo, postRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Posts, 3)
+	})
+
+	t.Run("follower", func(t *testing.T) {
+		followerId := uuid.New()
+		ownerWithFollower := *owner
+		ownerWithFollower.Followers = []uuid.UUID{followerId}
+
+		userRepo.EXPECT().GetUserById(owner.ID).Return(&ownerWithFollower, nil)
+		postRepo.EXPECT().GetPostByUserId(owner.ID).Return(posts, nil)
+
+		req := get_user_posts.NewGetUserPostsUseCaseReq(owner.ID, followerId)
+		res := get_user_posts.NewGetUserPostsUseCaseRes()
+		get_user_posts.NewGetUserPostsUseCase(userRepo, postRepo, req, res).Execute()
+
+		assert.Nil(t, res.Err)
+		assert.Len(t, res.Posts, 2)
+		assert.Equal(t, res.Posts[0].ID, publicPost.ID)
+		assert.Equal(t, res.Posts[1].ID, followerOnlyPost.ID)
+	})
+}
+
+func TestGetPostsOfNonExistUser(t *testing.T) {
+	userRepo, postRepo, _, _ := tests.SetupTestRepositories(t)
+
+	userId := uuid.New()
+	userRepo.EXPECT().GetUserById(userId).Return(nil, gorm.ErrRecordNotFound)
+
+	req := get_user_posts.NewGetUserPostsUseCaseReq(userId, uuid.New())
+	res := get_user_posts.NewGetUserPostsUseCaseRes()
+	get_user_posts.NewGetUserPostsUseCase(userRepo, postRepo, req, res).Execute()
+
+	var errUserNotFound *repository.ErrUserNotFound
+	assert.ErrorAs(t, res.Err, &errUserNotFound)
+	assert.Nil(t, res.Posts)
+}
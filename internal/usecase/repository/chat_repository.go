@@ -2,23 +2,116 @@ package repository
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	chat "mashu.example/internal/entity/chat"
 )
 
 type ErrDMNotFound struct {
-	dmId uuid.UUID
+	DMId uuid.UUID
 }
 
 func (err *ErrDMNotFound) Error() string {
-	return fmt.Sprintf("Direct message %s not found", err.dmId.String())
+	return fmt.Sprintf("Direct message %s not found", err.DMId.String())
 }
 
+type ErrMessageNotFound struct {
+	MessageId uuid.UUID
+}
+
+func (err *ErrMessageNotFound) Error() string {
+	return fmt.Sprintf("Message %s not found", err.MessageId.String())
+}
+
+type ErrGroupChatNotFound struct {
+	GroupId uuid.UUID
+}
+
+func (err *ErrGroupChatNotFound) Error() string {
+	return fmt.Sprintf("Chat of group %s not found", err.GroupId.String())
+}
+
+// filters to search the messages of the dms the user takes part in
+type MessageSearchQuery struct {
+	UserId    uuid.UUID
+	Keyword   string
+	PartnerId uuid.UUID // search the dm with the partner only, nil for all the dms
+	Since     time.Time // search the messages sent at or after it, zero for no lower bound
+	Until     time.Time // search the messages sent before it, zero for no upper bound
+	Offset    int
+	Limit     int
+}
+
+type MessageSearchResult struct {
+	DMId    uuid.UUID
+	Message *chat.Message
+	// the content around the keyword, the matches are wrapped by
+	// `SnippetHighlightStart` and `SnippetHighlightEnd`
+	Snippet string
+}
+
+const (
+	SnippetHighlightStart = "<mark>"
+	SnippetHighlightEnd   = "</mark>"
+)
+
 //go:generate mockgen -destination=./mock/chat_mock.go -package=mock . ChatRepo
 type ChatRepo interface {
+	// the direct messages are loaded with the metadata and the participants
+	// only, the messages are loaded by `ListMessages`. the unknown dm is
+	// reported as `ErrDMNotFound`
 	GetDirectMessage(dmId uuid.UUID) (*chat.DirectMessage, error)
 	GetDMByUserId(userA uuid.UUID, userB uuid.UUID) (*chat.DirectMessage, error)
+	// the messages of the direct messages are not loaded, use `ListMessages` instead
 	GetDMsByPartUserId(userId uuid.UUID) ([]*chat.DirectMessage, error)
+	// save the direct message and insert the messages not saved yet, the saved
+	// messages are never overwritten, use `UpdateMessage` to change them
 	SaveDirectMessage(dm *chat.DirectMessage) error
+	// insert the message sent to the direct message without touching the others
+	AddMessage(dmId uuid.UUID, message *chat.Message) error
+	// list at most `limit` latest messages sent before `before` in chronological order,
+	// the zero `before` lists the latest messages
+	ListMessages(dmId uuid.UUID, before time.Time, limit int) ([]*chat.Message, error)
+	GetMessage(dmId uuid.UUID, messageId uuid.UUID) (*chat.Message, error)
+	// update the content and the tombstone of the saved message
+	UpdateMessage(dmId uuid.UUID, message *chat.Message) error
+	// add or remove a single emoji reaction of the user, adding the existing
+	// reaction or removing the missing one does nothing
+	AddReaction(dmId uuid.UUID, messageId uuid.UUID, userId uuid.UUID, emoji string) error
+	RemoveReaction(dmId uuid.UUID, messageId uuid.UUID, userId uuid.UUID, emoji string) error
+	// search the messages containing the keyword from the latest one, the
+	// deleted messages are excluded
+	SearchMessages(query *MessageSearchQuery) ([]*MessageSearchResult, error)
+
+	// the messages of the group chat are not loaded, use `ListGroupMessages` instead
+	GetGroupChatByGroupId(groupId uuid.UUID) (*chat.GroupChat, error)
+	// save the group chat and insert the messages not saved yet, the saved
+	// messages are never overwritten
+	SaveGroupChat(groupChat *chat.GroupChat) error
+	// insert the message sent to the group chat without touching the others
+	AddGroupMessage(groupChatId uuid.UUID, message *chat.Message) error
+	// list at most `limit` latest messages of the group chat sent before `before`
+	// in chronological order, the zero `before` lists the latest messages. the
+	// unknown group chat has no messages
+	ListGroupMessages(groupChatId uuid.UUID, before time.Time, limit int) ([]*chat.Message, error)
+
+	IncrUnreadCount(userId uuid.UUID, dmId uuid.UUID) error
+	// map the dm id to the number of unread messages, the dms without unread messages are omitted
+	GetUnreadCounts(userId uuid.UUID) (map[uuid.UUID]int, error)
+	// the user has read the messages of the dm sent until `readUntil`, the
+	// unread count is set to the number of the partner's messages sent after it
+	RecountUnreadCount(userId uuid.UUID, dmId uuid.UUID, readUntil time.Time) error
+
+	// keep the latest receipt of each participant and status, the receipt
+	// older than the saved one is ignored
+	SaveReceipt(receipt *chat.Receipt) error
+	GetReceipts(dmId uuid.UUID) ([]*chat.Receipt, error)
+
+	AddPendingMessage(userId uuid.UUID, pending *chat.PendingMessage) error
+	// list the pending messages sent after `since` in chronological order,
+	// the zero `since` lists all the pending messages
+	GetPendingMessages(userId uuid.UUID, since time.Time) ([]*chat.PendingMessage, error)
+	// remove the pending messages sent at or before `until`
+	RemovePendingMessages(userId uuid.UUID, until time.Time) error
 }
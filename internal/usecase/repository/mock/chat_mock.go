@@ -6,10 +6,12 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	entity "mashu.example/internal/entity/chat"
+	repository "mashu.example/internal/usecase/repository"
 )
 
 // MockChatRepo is a mock of ChatRepo interface.
@@ -35,6 +37,62 @@ func (m *MockChatRepo) EXPECT() *MockChatRepoMockRecorder {
 	return m.recorder
 }
 
+// AddGroupMessage mocks base method.
+func (m *MockChatRepo) AddGroupMessage(arg0 uuid.UUID, arg1 *entity.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupMessage indicates an expected call of AddGroupMessage.
+func (mr *MockChatRepoMockRecorder) AddGroupMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupMessage", reflect.TypeOf((*MockChatRepo)(nil).AddGroupMessage), arg0, arg1)
+}
+
+// AddMessage mocks base method.
+func (m *MockChatRepo) AddMessage(arg0 uuid.UUID, arg1 *entity.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMessage indicates an expected call of AddMessage.
+func (mr *MockChatRepoMockRecorder) AddMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessage", reflect.TypeOf((*MockChatRepo)(nil).AddMessage), arg0, arg1)
+}
+
+// AddPendingMessage mocks base method.
+func (m *MockChatRepo) AddPendingMessage(arg0 uuid.UUID, arg1 *entity.PendingMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPendingMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPendingMessage indicates an expected call of AddPendingMessage.
+func (mr *MockChatRepoMockRecorder) AddPendingMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPendingMessage", reflect.TypeOf((*MockChatRepo)(nil).AddPendingMessage), arg0, arg1)
+}
+
+// AddReaction mocks base method.
+func (m *MockChatRepo) AddReaction(arg0, arg1, arg2 uuid.UUID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockChatRepoMockRecorder) AddReaction(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockChatRepo)(nil).AddReaction), arg0, arg1, arg2, arg3)
+}
+
 // GetDMByUserId mocks base method.
 func (m *MockChatRepo) GetDMByUserId(arg0, arg1 uuid.UUID) (*entity.DirectMessage, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +138,167 @@ func (mr *MockChatRepoMockRecorder) GetDirectMessage(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectMessage", reflect.TypeOf((*MockChatRepo)(nil).GetDirectMessage), arg0)
 }
 
+// GetGroupChatByGroupId mocks base method.
+func (m *MockChatRepo) GetGroupChatByGroupId(arg0 uuid.UUID) (*entity.GroupChat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupChatByGroupId", arg0)
+	ret0, _ := ret[0].(*entity.GroupChat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupChatByGroupId indicates an expected call of GetGroupChatByGroupId.
+func (mr *MockChatRepoMockRecorder) GetGroupChatByGroupId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupChatByGroupId", reflect.TypeOf((*MockChatRepo)(nil).GetGroupChatByGroupId), arg0)
+}
+
+// GetMessage mocks base method.
+func (m *MockChatRepo) GetMessage(arg0, arg1 uuid.UUID) (*entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessage indicates an expected call of GetMessage.
+func (mr *MockChatRepoMockRecorder) GetMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessage", reflect.TypeOf((*MockChatRepo)(nil).GetMessage), arg0, arg1)
+}
+
+// GetPendingMessages mocks base method.
+func (m *MockChatRepo) GetPendingMessages(arg0 uuid.UUID, arg1 time.Time) ([]*entity.PendingMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingMessages", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.PendingMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingMessages indicates an expected call of GetPendingMessages.
+func (mr *MockChatRepoMockRecorder) GetPendingMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingMessages", reflect.TypeOf((*MockChatRepo)(nil).GetPendingMessages), arg0, arg1)
+}
+
+// GetReceipts mocks base method.
+func (m *MockChatRepo) GetReceipts(arg0 uuid.UUID) ([]*entity.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipts", arg0)
+	ret0, _ := ret[0].([]*entity.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipts indicates an expected call of GetReceipts.
+func (mr *MockChatRepoMockRecorder) GetReceipts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipts", reflect.TypeOf((*MockChatRepo)(nil).GetReceipts), arg0)
+}
+
+// GetUnreadCounts mocks base method.
+func (m *MockChatRepo) GetUnreadCounts(arg0 uuid.UUID) (map[uuid.UUID]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCounts", arg0)
+	ret0, _ := ret[0].(map[uuid.UUID]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCounts indicates an expected call of GetUnreadCounts.
+func (mr *MockChatRepoMockRecorder) GetUnreadCounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCounts", reflect.TypeOf((*MockChatRepo)(nil).GetUnreadCounts), arg0)
+}
+
+// IncrUnreadCount mocks base method.
+func (m *MockChatRepo) IncrUnreadCount(arg0, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrUnreadCount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrUnreadCount indicates an expected call of IncrUnreadCount.
+func (mr *MockChatRepoMockRecorder) IncrUnreadCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrUnreadCount", reflect.TypeOf((*MockChatRepo)(nil).IncrUnreadCount), arg0, arg1)
+}
+
+// ListGroupMessages mocks base method.
+func (m *MockChatRepo) ListGroupMessages(arg0 uuid.UUID, arg1 time.Time, arg2 int) ([]*entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupMessages indicates an expected call of ListGroupMessages.
+func (mr *MockChatRepoMockRecorder) ListGroupMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupMessages", reflect.TypeOf((*MockChatRepo)(nil).ListGroupMessages), arg0, arg1, arg2)
+}
+
+// ListMessages mocks base method.
+func (m *MockChatRepo) ListMessages(arg0 uuid.UUID, arg1 time.Time, arg2 int) ([]*entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockChatRepoMockRecorder) ListMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockChatRepo)(nil).ListMessages), arg0, arg1, arg2)
+}
+
+// RecountUnreadCount mocks base method.
+func (m *MockChatRepo) RecountUnreadCount(arg0, arg1 uuid.UUID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountUnreadCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountUnreadCount indicates an expected call of RecountUnreadCount.
+func (mr *MockChatRepoMockRecorder) RecountUnreadCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountUnreadCount", reflect.TypeOf((*MockChatRepo)(nil).RecountUnreadCount), arg0, arg1, arg2)
+}
+
+// RemovePendingMessages mocks base method.
+func (m *MockChatRepo) RemovePendingMessages(arg0 uuid.UUID, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePendingMessages", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePendingMessages indicates an expected call of RemovePendingMessages.
+func (mr *MockChatRepoMockRecorder) RemovePendingMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePendingMessages", reflect.TypeOf((*MockChatRepo)(nil).RemovePendingMessages), arg0, arg1)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockChatRepo) RemoveReaction(arg0, arg1, arg2 uuid.UUID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockChatRepoMockRecorder) RemoveReaction(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockChatRepo)(nil).RemoveReaction), arg0, arg1, arg2, arg3)
+}
+
 // SaveDirectMessage mocks base method.
 func (m *MockChatRepo) SaveDirectMessage(arg0 *entity.DirectMessage) error {
 	m.ctrl.T.Helper()
@@ -93,3 +312,60 @@ func (mr *MockChatRepoMockRecorder) SaveDirectMessage(arg0 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDirectMessage", reflect.TypeOf((*MockChatRepo)(nil).SaveDirectMessage), arg0)
 }
+
+// SaveGroupChat mocks base method.
+func (m *MockChatRepo) SaveGroupChat(arg0 *entity.GroupChat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGroupChat", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGroupChat indicates an expected call of SaveGroupChat.
+func (mr *MockChatRepoMockRecorder) SaveGroupChat(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGroupChat", reflect.TypeOf((*MockChatRepo)(nil).SaveGroupChat), arg0)
+}
+
+// SaveReceipt mocks base method.
+func (m *MockChatRepo) SaveReceipt(arg0 *entity.Receipt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReceipt", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReceipt indicates an expected call of SaveReceipt.
+func (mr *MockChatRepoMockRecorder) SaveReceipt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReceipt", reflect.TypeOf((*MockChatRepo)(nil).SaveReceipt), arg0)
+}
+
+// SearchMessages mocks base method.
+func (m *MockChatRepo) SearchMessages(arg0 *repository.MessageSearchQuery) ([]*repository.MessageSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessages", arg0)
+	ret0, _ := ret[0].([]*repository.MessageSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessages indicates an expected call of SearchMessages.
+func (mr *MockChatRepoMockRecorder) SearchMessages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessages", reflect.TypeOf((*MockChatRepo)(nil).SearchMessages), arg0)
+}
+
+// UpdateMessage mocks base method.
+func (m *MockChatRepo) UpdateMessage(arg0 uuid.UUID, arg1 *entity.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMessage indicates an expected call of UpdateMessage.
+func (mr *MockChatRepoMockRecorder) UpdateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockChatRepo)(nil).UpdateMessage), arg0, arg1)
+}
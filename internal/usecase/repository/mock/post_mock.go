@@ -35,6 +35,20 @@ func (m *MockPostRepo) EXPECT() *MockPostRepoMockRecorder {
 	return m.recorder
 }
 
+// AddComment mocks base method.
+func (m *MockPostRepo) AddComment(arg0 *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockPostRepoMockRecorder) AddComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockPostRepo)(nil).AddComment), arg0)
+}
+
 // Delete mocks base method.
 func (m *MockPostRepo) Delete(arg0 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -49,6 +63,51 @@ func (mr *MockPostRepoMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPostRepo)(nil).Delete), arg0)
 }
 
+// DeleteComment mocks base method.
+func (m *MockPostRepo) DeleteComment(arg0 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockPostRepoMockRecorder) DeleteComment(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockPostRepo)(nil).DeleteComment), arg0)
+}
+
+// GetCommentById mocks base method.
+func (m *MockPostRepo) GetCommentById(arg0 uuid.UUID) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentById", arg0)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentById indicates an expected call of GetCommentById.
+func (mr *MockPostRepoMockRecorder) GetCommentById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentById", reflect.TypeOf((*MockPostRepo)(nil).GetCommentById), arg0)
+}
+
+// GetCommentsByPostId mocks base method.
+func (m *MockPostRepo) GetCommentsByPostId(arg0 uuid.UUID, arg1, arg2 int) ([]*entity.Comment, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentsByPostId", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Comment)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommentsByPostId indicates an expected call of GetCommentsByPostId.
+func (mr *MockPostRepoMockRecorder) GetCommentsByPostId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByPostId", reflect.TypeOf((*MockPostRepo)(nil).GetCommentsByPostId), arg0, arg1, arg2)
+}
+
 // GetPostById mocks base method.
 func (m *MockPostRepo) GetPostById(arg0 uuid.UUID) (*entity.Post, error) {
 	m.ctrl.T.Helper()
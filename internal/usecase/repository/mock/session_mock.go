@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mashu.example/internal/usecase/repository (interfaces: SessionRepo)
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entity "mashu.example/internal/entity"
+)
+
+// MockSessionRepo is a mock of SessionRepo interface.
+type MockSessionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRepoMockRecorder
+}
+
+// MockSessionRepoMockRecorder is the mock recorder for MockSessionRepo.
+type MockSessionRepoMockRecorder struct {
+	mock *MockSessionRepo
+}
+
+// NewMockSessionRepo creates a new mock instance.
+func NewMockSessionRepo(ctrl *gomock.Controller) *MockSessionRepo {
+	mock := &MockSessionRepo{ctrl: ctrl}
+	mock.recorder = &MockSessionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRepo) EXPECT() *MockSessionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSessionById mocks base method.
+func (m *MockSessionRepo) GetSessionById(arg0 uuid.UUID) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionById", arg0)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionById indicates an expected call of GetSessionById.
+func (mr *MockSessionRepoMockRecorder) GetSessionById(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionById", reflect.TypeOf((*MockSessionRepo)(nil).GetSessionById), arg0)
+}
+
+// GetSessionByRefreshTokenHash mocks base method.
+func (m *MockSessionRepo) GetSessionByRefreshTokenHash(arg0 string) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByRefreshTokenHash", arg0)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByRefreshTokenHash indicates an expected call of GetSessionByRefreshTokenHash.
+func (mr *MockSessionRepoMockRecorder) GetSessionByRefreshTokenHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByRefreshTokenHash", reflect.TypeOf((*MockSessionRepo)(nil).GetSessionByRefreshTokenHash), arg0)
+}
+
+// GetSessionsByUserId mocks base method.
+func (m *MockSessionRepo) GetSessionsByUserId(arg0 uuid.UUID) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsByUserId", arg0)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsByUserId indicates an expected call of GetSessionsByUserId.
+func (mr *MockSessionRepoMockRecorder) GetSessionsByUserId(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserId", reflect.TypeOf((*MockSessionRepo)(nil).GetSessionsByUserId), arg0)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockSessionRepo) RotateRefreshToken(arg0 *entity.Session, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockSessionRepoMockRecorder) RotateRefreshToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockSessionRepo)(nil).RotateRefreshToken), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockSessionRepo) Save(arg0 *entity.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSessionRepoMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSessionRepo)(nil).Save), arg0)
+}
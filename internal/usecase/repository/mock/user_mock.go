@@ -10,6 +10,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
 	entity "mashu.example/internal/entity"
+	repository "mashu.example/internal/usecase/repository"
 )
 
 // MockUserRepo is a mock of UserRepo interface.
@@ -35,6 +36,36 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 	return m.recorder
 }
 
+// GetFollowers mocks base method.
+func (m *MockUserRepo) GetFollowers(arg0 uuid.UUID, arg1 repository.FollowCursor, arg2 int) (*repository.FollowPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*repository.FollowPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockUserRepoMockRecorder) GetFollowers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockUserRepo)(nil).GetFollowers), arg0, arg1, arg2)
+}
+
+// GetFollowings mocks base method.
+func (m *MockUserRepo) GetFollowings(arg0 uuid.UUID, arg1 repository.FollowCursor, arg2 int) (*repository.FollowPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*repository.FollowPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowings indicates an expected call of GetFollowings.
+func (mr *MockUserRepoMockRecorder) GetFollowings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowings", reflect.TypeOf((*MockUserRepo)(nil).GetFollowings), arg0, arg1, arg2)
+}
+
 // GetUserById mocks base method.
 func (m *MockUserRepo) GetUserById(arg0 uuid.UUID) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +96,21 @@ func (mr *MockUserRepoMockRecorder) GetUserByUserName(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUserName", reflect.TypeOf((*MockUserRepo)(nil).GetUserByUserName), arg0)
 }
 
+// GetUsersByIds mocks base method.
+func (m *MockUserRepo) GetUsersByIds(arg0 []uuid.UUID) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIds", arg0)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIds indicates an expected call of GetUsersByIds.
+func (mr *MockUserRepoMockRecorder) GetUsersByIds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIds", reflect.TypeOf((*MockUserRepo)(nil).GetUsersByIds), arg0)
+}
+
 // Save mocks base method.
 func (m *MockUserRepo) Save(arg0 *entity.User) error {
 	m.ctrl.T.Helper()
@@ -15,10 +15,24 @@ func (e *ErrPostNotFound) Error() string {
 	return fmt.Sprintf("Post %s not found", e.PostId)
 }
 
+type ErrCommentNotFound struct {
+	CommentId uuid.UUID
+}
+
+func (e *ErrCommentNotFound) Error() string {
+	return fmt.Sprintf("Comment %s not found", e.CommentId)
+}
+
 //go:generate mockgen -destination=./mock/post_mock.go -package=mock . PostRepo
 type PostRepo interface {
 	GetPostById(postId uuid.UUID) (*entity.Post, error)
 	GetPostByUserId(userId uuid.UUID) ([]*entity.Post, error)
+	GetCommentById(commentId uuid.UUID) (*entity.Comment, error)
+	// comments are ordered by the creation time, total is the number of all comments under the post
+	GetCommentsByPostId(postId uuid.UUID, offset int, limit int) (comments []*entity.Comment, total int64, err error)
+	// saves the post itself, the comments are added and deleted one by one
 	Save(post *entity.Post) error
 	Delete(postId uuid.UUID) error
+	AddComment(comment *entity.Comment) error
+	DeleteComment(commentId uuid.UUID) error
 }
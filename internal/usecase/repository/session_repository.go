@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/entity"
+)
+
+type ErrSessionNotFound struct {
+	SessionId uuid.UUID
+}
+
+func (err *ErrSessionNotFound) Error() string {
+	return fmt.Sprintf("Session %s not found", err.SessionId.String())
+}
+
+//go:generate mockgen -destination=./mock/session_mock.go -package=mock . SessionRepo
+type SessionRepo interface {
+	GetSessionById(sessionId uuid.UUID) (*entity.Session, error)
+	GetSessionByRefreshTokenHash(refreshTokenHash string) (*entity.Session, error)
+	GetSessionsByUserId(userId uuid.UUID) ([]*entity.Session, error)
+	Save(session *entity.Session) error
+	// save the rotated refresh token of the session only if the session is not
+	// revoked and still holds `oldRefreshTokenHash`, otherwise it returns
+	// `ErrSessionNotFound` since the old token has been used by another request
+	RotateRefreshToken(session *entity.Session, oldRefreshTokenHash string) error
+}
@@ -1,7 +1,11 @@
 package repository
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"mashu.example/internal/entity"
@@ -19,9 +23,76 @@ func NewErrUserNotFound(userId uuid.UUID) *ErrUserNotFound {
 	return &ErrUserNotFound{userId}
 }
 
+type ErrUserNameTaken struct {
+	UserName string
+}
+
+func (err *ErrUserNameTaken) Error() string {
+	return fmt.Sprintf("User name %s is already taken", err.UserName)
+}
+
+// position in a follow page, the zero value points to the first page
+type FollowCursor struct {
+	FollowedAt time.Time
+	UserId     uuid.UUID
+}
+
+func (c FollowCursor) IsZero() bool {
+	return c.FollowedAt.IsZero() && c.UserId == uuid.Nil
+}
+
+// encode the cursor into an opaque string for the clients
+func (c FollowCursor) Encode() string {
+	raw := fmt.Sprintf("%d_%s", c.FollowedAt.UnixNano(), c.UserId)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decode the cursor encoded by `FollowCursor.Encode`
+func DecodeFollowCursor(s string) (FollowCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return FollowCursor{}, err
+	}
+
+	followedAt, userId, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return FollowCursor{}, fmt.Errorf("malformed follow cursor %q", s)
+	}
+	nanos, err := strconv.ParseInt(followedAt, 10, 64)
+	if err != nil {
+		return FollowCursor{}, err
+	}
+	id, err := uuid.Parse(userId)
+	if err != nil {
+		return FollowCursor{}, err
+	}
+
+	return FollowCursor{FollowedAt: time.Unix(0, nanos), UserId: id}, nil
+}
+
+type FollowPageItem struct {
+	User       *entity.User // without the follow relations
+	FollowedAt time.Time
+}
+
+// page of the users in the follow relations, ordered from the latest followed
+type FollowPage struct {
+	Items []*FollowPageItem
+	Total int64 // number of all users in the follow relations
+}
+
 //go:generate mockgen -destination=./mock/user_mock.go -package=mock . UserRepo
 type UserRepo interface {
 	GetUserById(userId uuid.UUID) (*entity.User, error)
 	GetUserByUserName(username string) (*entity.User, error)
+	// get the users without the relations in a single query, the unknown ids
+	// are skipped. the users are for reading only, saving them drops their relations
+	GetUsersByIds(userIds []uuid.UUID) ([]*entity.User, error)
+	// it returns `ErrUserNameTaken` if another user has the username
 	Save(user *entity.User) error
+
+	// get at most `limit` followers of the user after the cursor
+	GetFollowers(userId uuid.UUID, cursor FollowCursor, limit int) (*FollowPage, error)
+	// get at most `limit` users followed by the user after the cursor
+	GetFollowings(userId uuid.UUID, cursor FollowCursor, limit int) (*FollowPage, error)
 }
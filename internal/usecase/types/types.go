@@ -1,6 +1,12 @@
 package types
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"mashu.example/internal/entity"
+	entity_enums "mashu.example/internal/entity/enums"
+)
 
 type FollowingInfo struct {
 	ID          uuid.UUID
@@ -8,4 +14,144 @@ type FollowingInfo struct {
 	DisplayName string
 	Email       string
 	Public      bool
+	FollowedAt  time.Time
+}
+
+func NewFollowingInfo(user *entity.User, followedAt time.Time) *FollowingInfo {
+	return &FollowingInfo{
+		ID:          user.ID,
+		UserName:    user.UserName,
+		DisplayName: user.DisplayName,
+		Email:       user.Email,
+		Public:      user.Public,
+		FollowedAt:  followedAt,
+	}
+}
+
+type ProfileInfo struct {
+	ID             uuid.UUID
+	UserName       string
+	DisplayName    string
+	Email          string
+	Public         bool
+	Bio            string
+	AvatarURL      string
+	Location       string
+	Website        string
+	Birthday       *time.Time // nil if not set
+	FollowerCount  int
+	FollowingCount int
+}
+
+func NewProfileInfo(user *entity.User) *ProfileInfo {
+	return &ProfileInfo{
+		ID:             user.ID,
+		UserName:       user.UserName,
+		DisplayName:    user.DisplayName,
+		Email:          user.Email,
+		Public:         user.Public,
+		Bio:            user.Bio,
+		AvatarURL:      user.AvatarURL,
+		Location:       user.Location,
+		Website:        user.Website,
+		Birthday:       user.Birthday,
+		FollowerCount:  len(user.Followers),
+		FollowingCount: len(user.Followings),
+	}
+}
+
+type PostInfo struct {
+	ID         uuid.UUID
+	Title      string
+	Content    string
+	OwnerId    uuid.UUID
+	OwnerName  string
+	GroupId    uuid.UUID // uuid.Nil if not belonging to any group
+	Permission entity_enums.PostPermission
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewPostInfo(post *entity.Post) *PostInfo {
+	groupId := uuid.Nil
+	if post.Group() != nil {
+		groupId = post.Group().ID
+	}
+
+	return &PostInfo{
+		ID:         post.ID,
+		Title:      post.Title,
+		Content:    post.Content,
+		OwnerId:    post.Owner.ID,
+		OwnerName:  post.Owner.UserName,
+		GroupId:    groupId,
+		Permission: post.Permission,
+		CreatedAt:  post.CreatedAt,
+		UpdatedAt:  post.UpdatedAt,
+	}
+}
+
+type CommentInfo struct {
+	ID        uuid.UUID
+	PostId    uuid.UUID
+	OwnerId   uuid.UUID
+	OwnerName string
+	Content   string
+	CreatedAt time.Time
+}
+
+func NewCommentInfo(comment *entity.Comment) *CommentInfo {
+	return &CommentInfo{
+		ID:        comment.ID,
+		PostId:    comment.Post.ID,
+		OwnerId:   comment.Owner.ID,
+		OwnerName: comment.Owner.UserName,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+type GroupMemberInfo struct {
+	UserId     uuid.UUID
+	InvitedBy  uuid.UUID // uuid.Nil if the member sent the join request
+	ApprovedBy uuid.UUID
+	JoinAt     time.Time
+}
+
+type GroupAdminInfo struct {
+	UserId     uuid.UUID
+	PromotedBy uuid.UUID
+	PromotedAt time.Time
+}
+
+type GroupInvitationInfo struct {
+	Invitee uuid.UUID
+	Inviter uuid.UUID
+}
+
+type GroupInfo struct {
+	ID         uuid.UUID
+	Name       string
+	OwnerId    uuid.UUID
+	OwnerName  string
+	Permission entity_enums.GroupPrivacy
+	CreatedAt  time.Time
+
+	// nil if the viewer is not allowed to see them
+	Members      []*GroupMemberInfo
+	Admins       []*GroupAdminInfo
+	JoinRequests []uuid.UUID
+	Invitations  []*GroupInvitationInfo
+}
+
+// build the group info with the basic fields only, see `GroupInfo`
+func NewGroupInfo(group *entity.Group) *GroupInfo {
+	return &GroupInfo{
+		ID:         group.ID,
+		Name:       group.Name,
+		OwnerId:    group.Owner.ID,
+		OwnerName:  group.Owner.UserName,
+		Permission: group.Permission,
+		CreatedAt:  group.CreatedAt,
+	}
 }
@@ -0,0 +1,88 @@
+package block_user
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrBlockSelf      = errors.New("can not block yourself")
+	ErrAlreadyBlocked = errors.New("the user is already blocked")
+)
+
+type BlockUserUseCaseReq struct {
+	userId    uuid.UUID
+	blockedId uuid.UUID
+}
+
+type BlockUserUseCaseRes struct {
+	Err error
+}
+
+type BlockUserUseCase struct {
+	userRepo repository.UserRepo
+	req      *BlockUserUseCaseReq
+	res      *BlockUserUseCaseRes
+}
+
+func (uc *BlockUserUseCase) Execute() {
+	if uc.req.userId == uc.req.blockedId {
+		uc.res.Err = ErrBlockSelf
+		return
+	}
+
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	blocked, err := uc.userRepo.GetUserById(uc.req.blockedId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.blockedId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !user.Block(blocked.ID) {
+		uc.res.Err = ErrAlreadyBlocked
+		return
+	}
+
+	// the users no longer follow each other once blocked
+	user.RemoveFollowRelationsWith(blocked.ID)
+	blocked.RemoveFollowRelationsWith(user.ID)
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if err := uc.userRepo.Save(blocked); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewBlockUserUseCase(
+	userRepo repository.UserRepo,
+	req *BlockUserUseCaseReq,
+	res *BlockUserUseCaseRes,
+) usecase.UseCase {
+	return &BlockUserUseCase{userRepo, req, res}
+}
+
+func NewBlockUserUseCaseReq(userId, blockedId uuid.UUID) *BlockUserUseCaseReq {
+	return &BlockUserUseCaseReq{userId, blockedId}
+}
+
+func NewBlockUserUseCaseRes() *BlockUserUseCaseRes {
+	return &BlockUserUseCaseRes{}
+}
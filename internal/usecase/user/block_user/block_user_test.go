@@ -0,0 +1,84 @@
+package block_user_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/block_user"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	blocked := entity.NewUser(uuid.New(), "blocked", "Blocked", "blocked@email.com", true)
+
+	return mock.NewMockUserRepo(mockCtrl), user, blocked
+}
+
+func TestBlockUser(t *testing.T) {
+	userRepo, user, blocked := setup(t)
+
+	// the users follow each other and a follow request is pending
+	user.AddFollower(blocked.ID)
+	user.AddFollowing(blocked.ID)
+	blocked.AddFollower(user.ID)
+	blocked.AddFollowing(user.ID)
+	followReq := &entity.FollowRequest{From: uuid.New(), To: user.ID}
+	user.AddFollowRequest(followReq)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(blocked.ID).Return(blocked, nil)
+	gomock.InOrder(
+		userRepo.EXPECT().Save(user).Return(nil),
+		userRepo.EXPECT().Save(blocked).Return(nil),
+	)
+
+	req := block_user.NewBlockUserUseCaseReq(user.ID, blocked.ID)
+	res := block_user.NewBlockUserUseCaseRes()
+	uc := block_user.NewBlockUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, []uuid.UUID{blocked.ID}, user.BlockedUsers)
+	assert.Len(t, user.Followers, 0)
+	assert.Len(t, user.Followings, 0)
+	assert.Len(t, blocked.Followers, 0)
+	assert.Len(t, blocked.Followings, 0)
+
+	// the request from others is kept
+	assert.Equal(t, []*entity.FollowRequest{followReq}, user.FollowRequests)
+}
+
+func TestBlockUserTwice(t *testing.T) {
+	userRepo, user, blocked := setup(t)
+	user.Block(blocked.ID)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(blocked.ID).Return(blocked, nil)
+
+	req := block_user.NewBlockUserUseCaseReq(user.ID, blocked.ID)
+	res := block_user.NewBlockUserUseCaseRes()
+	uc := block_user.NewBlockUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, block_user.ErrAlreadyBlocked)
+}
+
+func TestBlockSelf(t *testing.T) {
+	userRepo, user, _ := setup(t)
+
+	req := block_user.NewBlockUserUseCaseReq(user.ID, user.ID)
+	res := block_user.NewBlockUserUseCaseRes()
+	uc := block_user.NewBlockUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, block_user.ErrBlockSelf)
+}
@@ -0,0 +1,112 @@
+package bulk_handle_follow_requests
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/user/handle_follow_request"
+)
+
+var (
+	ErrInvalidAction = errors.New("invalid action of follow request")
+)
+
+type BulkHandleFollowRequestsUseCaseReq struct {
+	userId      uuid.UUID
+	followerIds []uuid.UUID // empty for all the pending requests
+	action      handle_follow_request.HandleFollowRequestAction
+}
+
+type BulkHandleFollowRequestsUseCaseRes struct {
+	Handled []uuid.UUID // the users whose requests are handled
+	Skipped []uuid.UUID // the users without pending request to the user
+	Err     error
+}
+
+type BulkHandleFollowRequestsUseCase struct {
+	userRepo repository.UserRepo
+	req      *BulkHandleFollowRequestsUseCaseReq
+	res      *BulkHandleFollowRequestsUseCaseRes
+}
+
+func (uc *BulkHandleFollowRequestsUseCase) Execute() {
+	if uc.req.action != handle_follow_request.ACCEPT_FOLLOW_REQUEST &&
+		uc.req.action != handle_follow_request.REJECT_FOLLOW_REQUEST {
+		uc.res.Err = ErrInvalidAction
+		return
+	}
+
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	followerIds := uc.req.followerIds
+	if len(followerIds) == 0 {
+		for _, followReq := range user.IncomingFollowRequests() {
+			followerIds = append(followerIds, followReq.From)
+		}
+	}
+
+	for _, followerId := range followerIds {
+		follower, err := uc.userRepo.GetUserById(followerId)
+		if err != nil {
+			uc.res.Skipped = append(uc.res.Skipped, followerId)
+			continue
+		}
+
+		handled := false
+		if uc.req.action == handle_follow_request.ACCEPT_FOLLOW_REQUEST {
+			handled = user.AcceptFollowRequest(follower)
+		} else {
+			handled = user.RejectFollowRequest(follower)
+		}
+		if !handled {
+			uc.res.Skipped = append(uc.res.Skipped, followerId)
+			continue
+		}
+
+		if err := uc.userRepo.Save(follower); err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+		uc.res.Handled = append(uc.res.Handled, followerId)
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewBulkHandleFollowRequestsUseCase(
+	userRepo repository.UserRepo,
+	req *BulkHandleFollowRequestsUseCaseReq,
+	res *BulkHandleFollowRequestsUseCaseRes,
+) usecase.UseCase {
+	return &BulkHandleFollowRequestsUseCase{userRepo, req, res}
+}
+
+func NewBulkHandleFollowRequestsUseCaseReq(
+	userId uuid.UUID,
+	followerIds []uuid.UUID,
+	action handle_follow_request.HandleFollowRequestAction,
+) *BulkHandleFollowRequestsUseCaseReq {
+	return &BulkHandleFollowRequestsUseCaseReq{userId, followerIds, action}
+}
+
+func NewBulkHandleFollowRequestsUseCaseRes() *BulkHandleFollowRequestsUseCaseRes {
+	return &BulkHandleFollowRequestsUseCaseRes{
+		Handled: []uuid.UUID{},
+		Skipped: []uuid.UUID{},
+	}
+}
@@ -0,0 +1,103 @@
+package bulk_handle_follow_requests_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/bulk_handle_follow_requests"
+	"mashu.example/internal/usecase/user/handle_follow_request"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, []*entity.User) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	requesters := []*entity.User{}
+	for _, name := range []string{"alice", "bob"} {
+		requester := entity.NewUser(uuid.New(), name, name, name+"@email.com", true)
+		followReq := &entity.FollowRequest{From: requester.ID, To: user.ID}
+		user.AddFollowRequest(followReq)
+		requester.AddFollowRequest(followReq)
+		requesters = append(requesters, requester)
+	}
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	return userRepo, user, requesters
+}
+
+func TestApproveAllFollowRequests(t *testing.T) {
+	userRepo, user, requesters := setup(t)
+	for _, requester := range requesters {
+		userRepo.EXPECT().GetUserById(requester.ID).Return(requester, nil)
+		userRepo.EXPECT().Save(requester).Return(nil)
+	}
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseReq(
+		user.ID,
+		nil,
+		handle_follow_request.ACCEPT_FOLLOW_REQUEST,
+	)
+	res := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseRes()
+	uc := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, []uuid.UUID{requesters[0].ID, requesters[1].ID}, res.Handled)
+	assert.Len(t, res.Skipped, 0)
+	assert.Len(t, user.FollowRequests, 0)
+	assert.Equal(t, []uuid.UUID{requesters[0].ID, requesters[1].ID}, user.Followers)
+	for _, requester := range requesters {
+		assert.Equal(t, []uuid.UUID{user.ID}, requester.Followings)
+		assert.Len(t, requester.FollowRequests, 0)
+	}
+}
+
+func TestRejectSelectedFollowRequests(t *testing.T) {
+	userRepo, user, requesters := setup(t)
+	stranger := entity.NewUser(uuid.New(), "stranger", "Stranger", "stranger@email.com", true)
+	unknownId := uuid.New()
+
+	userRepo.EXPECT().GetUserById(requesters[0].ID).Return(requesters[0], nil)
+	userRepo.EXPECT().GetUserById(stranger.ID).Return(stranger, nil)
+	userRepo.EXPECT().GetUserById(unknownId).Return(nil, errors.New("record not found"))
+	userRepo.EXPECT().Save(requesters[0]).Return(nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseReq(
+		user.ID,
+		[]uuid.UUID{requesters[0].ID, stranger.ID, unknownId},
+		handle_follow_request.REJECT_FOLLOW_REQUEST,
+	)
+	res := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseRes()
+	uc := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, []uuid.UUID{requesters[0].ID}, res.Handled)
+	assert.Equal(t, []uuid.UUID{stranger.ID, unknownId}, res.Skipped)
+	assert.Len(t, user.Followers, 0)
+	assert.Len(t, requesters[0].FollowRequests, 0)
+
+	// the request of the other user is still pending
+	assert.Len(t, user.IncomingFollowRequests(), 1)
+	assert.Equal(t, requesters[1].ID, user.IncomingFollowRequests()[0].From)
+}
+
+func TestHandleFollowRequestsWithInvalidAction(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	req := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseReq(uuid.New(), nil, "IGNORE")
+	res := bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCaseRes()
+	bulk_handle_follow_requests.NewBulkHandleFollowRequestsUseCase(userRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, bulk_handle_follow_requests.ErrInvalidAction)
+}
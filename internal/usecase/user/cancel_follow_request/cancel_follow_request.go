@@ -0,0 +1,81 @@
+package cancel_follow_request
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrFollowRequestNotFound = errors.New("the follow request is not found")
+)
+
+type CancelFollowRequestUseCaseReq struct {
+	userId     uuid.UUID // the user who sent the follow request
+	followeeId uuid.UUID
+}
+
+type CancelFollowRequestUseCaseRes struct {
+	Err error
+}
+
+type CancelFollowRequestUseCase struct {
+	userRepo repository.UserRepo
+	req      *CancelFollowRequestUseCaseReq
+	res      *CancelFollowRequestUseCaseRes
+}
+
+func (uc *CancelFollowRequestUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	followee, err := uc.userRepo.GetUserById(uc.req.followeeId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.followeeId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	// the request is held by both users, withdraw it from both sides
+	removedFromUser := user.RemoveFollowRequest(user.ID, followee.ID)
+	removedFromFollowee := followee.RemoveFollowRequest(user.ID, followee.ID)
+	if !removedFromUser && !removedFromFollowee {
+		uc.res.Err = ErrFollowRequestNotFound
+		return
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if err := uc.userRepo.Save(followee); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewCancelFollowRequestUseCase(
+	userRepo repository.UserRepo,
+	req *CancelFollowRequestUseCaseReq,
+	res *CancelFollowRequestUseCaseRes,
+) usecase.UseCase {
+	return &CancelFollowRequestUseCase{userRepo, req, res}
+}
+
+func NewCancelFollowRequestUseCaseReq(userId, followeeId uuid.UUID) *CancelFollowRequestUseCaseReq {
+	return &CancelFollowRequestUseCaseReq{userId, followeeId}
+}
+
+func NewCancelFollowRequestUseCaseRes() *CancelFollowRequestUseCaseRes {
+	return &CancelFollowRequestUseCaseRes{}
+}
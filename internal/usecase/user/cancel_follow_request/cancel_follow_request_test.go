@@ -0,0 +1,66 @@
+package cancel_follow_request_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/cancel_follow_request"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	followee := entity.NewUser(uuid.New(), "followee", "Followee", "followee@email.com", false)
+
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(followee.ID).Return(followee, nil)
+
+	return userRepo, user, followee
+}
+
+func TestCancelFollowRequest(t *testing.T) {
+	userRepo, user, followee := setup(t)
+	followReq := &entity.FollowRequest{From: user.ID, To: followee.ID}
+	user.AddFollowRequest(followReq)
+	followee.AddFollowRequest(followReq)
+
+	gomock.InOrder(
+		userRepo.EXPECT().Save(user).Return(nil),
+		userRepo.EXPECT().Save(followee).Return(nil),
+	)
+
+	req := cancel_follow_request.NewCancelFollowRequestUseCaseReq(user.ID, followee.ID)
+	res := cancel_follow_request.NewCancelFollowRequestUseCaseRes()
+	uc := cancel_follow_request.NewCancelFollowRequestUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, user.FollowRequests, 0)
+	assert.Len(t, followee.FollowRequests, 0)
+	assert.Len(t, followee.Followers, 0)
+}
+
+func TestCancelFollowRequestNotFound(t *testing.T) {
+	userRepo, user, followee := setup(t)
+
+	// the request sent by the followee can not be canceled by the user
+	followReq := &entity.FollowRequest{From: followee.ID, To: user.ID}
+	user.AddFollowRequest(followReq)
+	followee.AddFollowRequest(followReq)
+
+	req := cancel_follow_request.NewCancelFollowRequestUseCaseReq(user.ID, followee.ID)
+	res := cancel_follow_request.NewCancelFollowRequestUseCaseRes()
+	uc := cancel_follow_request.NewCancelFollowRequestUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, cancel_follow_request.ErrFollowRequestNotFound)
+	assert.Len(t, user.FollowRequests, 1)
+}
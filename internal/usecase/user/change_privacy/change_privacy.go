@@ -0,0 +1,85 @@
+package change_privacy
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+type ChangePrivacyUseCaseReq struct {
+	userId uuid.UUID
+	public bool
+}
+
+type ChangePrivacyUseCaseRes struct {
+	ApprovedFollowerIds []uuid.UUID // the pending requests approved by switching to public
+	Err                 error
+}
+
+type ChangePrivacyUseCase struct {
+	userRepo repository.UserRepo
+	req      *ChangePrivacyUseCaseReq
+	res      *ChangePrivacyUseCaseRes
+}
+
+func (uc *ChangePrivacyUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if user.Public == uc.req.public {
+		uc.res.Err = nil
+		return
+	}
+	switchToPublic := !user.Public && uc.req.public
+	user.Public = uc.req.public
+
+	// anyone can follow a public user, so the pending requests are approved
+	if switchToPublic {
+		for _, followReq := range user.IncomingFollowRequests() {
+			follower, err := uc.userRepo.GetUserById(followReq.From)
+			if err != nil {
+				// drop the request of the user who no longer exists
+				logrus.Warn(err)
+				user.RemoveFollowRequest(followReq.From, user.ID)
+				continue
+			}
+
+			user.AcceptFollowRequest(follower)
+			if err := uc.userRepo.Save(follower); err != nil {
+				uc.res.Err = err
+				logrus.Error(err)
+				return
+			}
+			uc.res.ApprovedFollowerIds = append(uc.res.ApprovedFollowerIds, follower.ID)
+		}
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewChangePrivacyUseCase(
+	userRepo repository.UserRepo,
+	req *ChangePrivacyUseCaseReq,
+	res *ChangePrivacyUseCaseRes,
+) usecase.UseCase {
+	return &ChangePrivacyUseCase{userRepo, req, res}
+}
+
+func NewChangePrivacyUseCaseReq(userId uuid.UUID, public bool) *ChangePrivacyUseCaseReq {
+	return &ChangePrivacyUseCaseReq{userId, public}
+}
+
+func NewChangePrivacyUseCaseRes() *ChangePrivacyUseCaseRes {
+	return &ChangePrivacyUseCaseRes{ApprovedFollowerIds: []uuid.UUID{}}
+}
@@ -0,0 +1,83 @@
+package change_privacy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/change_privacy"
+)
+
+func TestSwitchToPublicApprovesPendingRequests(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	requester := entity.NewUser(uuid.New(), "requester", "Requester", "requester@email.com", true)
+	followReq := &entity.FollowRequest{From: requester.ID, To: user.ID}
+	user.AddFollowRequest(followReq)
+	requester.AddFollowRequest(followReq)
+
+	// the request of the deleted user is dropped
+	deletedId := uuid.New()
+	user.AddFollowRequest(&entity.FollowRequest{From: deletedId, To: user.ID})
+
+	// the request sent by the user is kept
+	followee := uuid.New()
+	user.AddFollowRequest(&entity.FollowRequest{From: user.ID, To: followee})
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(requester.ID).Return(requester, nil)
+	userRepo.EXPECT().GetUserById(deletedId).Return(nil, errors.New("record not found"))
+	gomock.InOrder(
+		userRepo.EXPECT().Save(requester).Return(nil),
+		userRepo.EXPECT().Save(user).Return(nil),
+	)
+
+	req := change_privacy.NewChangePrivacyUseCaseReq(user.ID, true)
+	res := change_privacy.NewChangePrivacyUseCaseRes()
+	uc := change_privacy.NewChangePrivacyUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.True(t, user.Public)
+	assert.Equal(t, []uuid.UUID{requester.ID}, res.ApprovedFollowerIds)
+	assert.Equal(t, []uuid.UUID{requester.ID}, user.Followers)
+	assert.Equal(t, []uuid.UUID{user.ID}, requester.Followings)
+	assert.Len(t, user.IncomingFollowRequests(), 0)
+	assert.Len(t, user.OutgoingFollowRequests(), 1)
+}
+
+func TestSwitchToPrivate(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := change_privacy.NewChangePrivacyUseCaseReq(user.ID, false)
+	res := change_privacy.NewChangePrivacyUseCaseRes()
+	change_privacy.NewChangePrivacyUseCase(userRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.False(t, user.Public)
+	assert.Len(t, res.ApprovedFollowerIds, 0)
+}
+
+func TestKeepPrivacyUnchanged(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	req := change_privacy.NewChangePrivacyUseCaseReq(user.ID, true)
+	res := change_privacy.NewChangePrivacyUseCaseRes()
+	change_privacy.NewChangePrivacyUseCase(userRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.True(t, user.Public)
+}
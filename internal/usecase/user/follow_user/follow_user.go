@@ -1,12 +1,18 @@
 package follow_user
 
 import (
+	"errors"
+
 	"github.com/google/uuid"
 	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
 )
 
+var (
+	ErrFollowBlockedUser = errors.New("can not follow the user because of blocking")
+)
+
 type FollowUserUseCaseReq struct {
 	followerId uuid.UUID
 	followeeId uuid.UUID
@@ -25,12 +31,21 @@ type FollowUserUseCase struct {
 
 func (uc *FollowUserUseCase) Execute() {
 	follower, err := uc.userRepo.GetUserById(uc.Req.followerId)
+	if err != nil {
+		uc.Res.Err = err
+		return
+	}
 	followee, err := uc.userRepo.GetUserById(uc.Req.followeeId)
 	if err != nil {
 		uc.Res.Err = err
 		return
 	}
 
+	if follower.IsBlockedWith(followee) {
+		uc.Res.Err = ErrFollowBlockedUser
+		return
+	}
+
 	if followee.Public {
 		followee.AddFollower(uc.Req.followerId)
 		follower.AddFollowing(uc.Req.followeeId)
@@ -105,3 +105,26 @@ func TestFollowPublicUser(t *testing.T) {
 	assert.Equal(t, len(followee.Followers), 1)
 	assert.Equal(t, len(followee.FollowRequests), 0)
 }
+
+func TestFollowBlockingUser(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	follower := entity.NewUser(uuid.New(), "follower", "follower display name", "follower@email.com", false)
+	followee := entity.NewUser(uuid.New(), "followee", "followee display name", "folowee@email.com", true)
+	followee.Block(follower.ID)
+
+	repo := mock.NewMockUserRepo(mockCtrl)
+	repo.EXPECT().GetUserById(follower.ID).Return(follower, nil)
+	repo.EXPECT().GetUserById(followee.ID).Return(followee, nil)
+
+	req := follow_user.NewFollowUserUseCaseReq(follower.ID, followee.ID)
+	res := follow_user.NewFollowUserUseCaseRes()
+	uc := follow_user.NewFollowUserUseCase(repo, &req, &res)
+
+	uc.Execute()
+
+	assert.Equal(t, res.Err, follow_user.ErrFollowBlockedUser)
+	assert.Equal(t, len(follower.Followings), 0)
+	assert.Equal(t, len(followee.Followers), 0)
+}
@@ -2,7 +2,6 @@ package get_follower
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -11,13 +10,22 @@ import (
 	"mashu.example/internal/usecase/types"
 )
 
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("limit must be positive")
+)
+
 type GetFollowerUseCaseReq struct {
 	userId uuid.UUID
+	cursor string // returned by the previous page, empty for the first page
+	limit  int
 }
 
 type GetFollowerUseCaseRes struct {
-	Users []*types.FollowingInfo
-	Err   error
+	Users      []*types.FollowingInfo
+	Total      int64  // number of all followers
+	NextCursor string // empty if there is no more follower
+	Err        error
 }
 
 type GetFollowerUseCase struct {
@@ -27,33 +35,44 @@ type GetFollowerUseCase struct {
 }
 
 func (uc *GetFollowerUseCase) Execute() {
-	user, err := uc.userRepo.GetUserById(uc.req.userId)
-	if err != nil {
-		errMsg := fmt.Sprintf("failed to get user (userId: %s)", uc.req.userId)
-		logrus.Errorf(errMsg)
-		uc.res.Err = errors.New(errMsg)
+	if uc.req.limit <= 0 {
+		uc.res.Err = ErrInvalidLimit
 		return
 	}
 
-	followerInfos := []*types.FollowingInfo{}
-	for _, followerId := range user.Followers {
-		followerUser, err := uc.userRepo.GetUserById(followerId)
+	cursor := repository.FollowCursor{}
+	if uc.req.cursor != "" {
+		decoded, err := repository.DecodeFollowCursor(uc.req.cursor)
 		if err != nil {
-			errMsg := fmt.Sprintf("failed to get user's following user (userId: %s)", uc.req.userId)
-			logrus.Errorf(errMsg)
-			uc.res.Err = errors.New(errMsg)
+			uc.res.Err = ErrInvalidCursor
+			logrus.Error(err)
 			return
 		}
-		followerInfos = append(followerInfos, &types.FollowingInfo{
-			ID:          followerUser.ID,
-			UserName:    followerUser.UserName,
-			DisplayName: followerUser.DisplayName,
-			Email:       followerUser.Email,
-			Public:      followerUser.Public,
-		})
+		cursor = decoded
+	}
+
+	// query one more follower to know if there is a next page
+	page, err := uc.userRepo.GetFollowers(uc.req.userId, cursor, uc.req.limit+1)
+	if err != nil {
+		logrus.Errorf("failed to get followers (userId: %s)", uc.req.userId)
+		uc.res.Err = err
+		return
+	}
+
+	items := page.Items
+	if len(items) > uc.req.limit {
+		items = items[:uc.req.limit]
+		last := items[len(items)-1]
+		uc.res.NextCursor = repository.FollowCursor{FollowedAt: last.FollowedAt, UserId: last.User.ID}.Encode()
+	}
+
+	followerInfos := []*types.FollowingInfo{}
+	for _, item := range items {
+		followerInfos = append(followerInfos, types.NewFollowingInfo(item.User, item.FollowedAt))
 	}
 
 	uc.res.Users = followerInfos
+	uc.res.Total = page.Total
 	uc.res.Err = nil
 }
 
@@ -65,8 +84,8 @@ func NewGetFollowerUsecase(
 	return &GetFollowerUseCase{userRepo, req, res}
 }
 
-func NewGetFollowerUseCaseReq(userId uuid.UUID) *GetFollowerUseCaseReq {
-	return &GetFollowerUseCaseReq{userId}
+func NewGetFollowerUseCaseReq(userId uuid.UUID, cursor string, limit int) *GetFollowerUseCaseReq {
+	return &GetFollowerUseCaseReq{userId, cursor, limit}
 }
 
 func NewGetFollowerUseCaseRes() *GetFollowerUseCaseRes {
@@ -2,11 +2,13 @@ package get_follower_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
 	"mashu.example/internal/usecase/repository/mock"
 	"mashu.example/internal/usecase/user/get_follower"
 )
@@ -18,32 +20,85 @@ func setup(t *testing.T) *mock.MockUserRepo {
 	return mock.NewMockUserRepo(mockCtrl)
 }
 
-func TestGetFollower(t *testing.T) {
-	userRepo := setup(t)
+func newFollowPage(total int64, names ...string) *repository.FollowPage {
+	page := &repository.FollowPage{Total: total}
+	followedAt := time.Now()
+	for _, name := range names {
+		followedAt = followedAt.Add(-time.Minute)
+		page.Items = append(page.Items, &repository.FollowPageItem{
+			User:       entity.NewUser(uuid.New(), name, name, name+"@email.com", true),
+			FollowedAt: followedAt,
+		})
+	}
 
-	user := entity.NewUser(uuid.New(), "mashu6211", "mashu", "mashu@email.com", true)
-	follower1 := entity.NewUser(uuid.New(), "follower1", "follower 1", "follower1@email.com", true)
-	follower2 := entity.NewUser(uuid.New(), "follower2", "follower 2", "follower2@email.com", true)
-	follower3 := entity.NewUser(uuid.New(), "follower3", "follower 3", "follower3@email.com", true)
+	return page
+}
 
-	user.Followers = append(user.Followers, follower1.ID)
-	user.Followers = append(user.Followers, follower2.ID)
-	user.Followers = append(user.Followers, follower3.ID)
+func TestGetFollower(t *testing.T) {
+	userRepo := setup(t)
 
-	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
-	userRepo.EXPECT().GetUserById(follower1.ID).Return(follower1, nil)
-	userRepo.EXPECT().GetUserById(follower2.ID).Return(follower2, nil)
-	userRepo.EXPECT().GetUserById(follower3.ID).Return(follower3, nil)
+	userId := uuid.New()
+	page := newFollowPage(3, "follower1", "follower2", "follower3")
+	userRepo.EXPECT().GetFollowers(userId, repository.FollowCursor{}, 11).Return(page, nil)
 
-	req := get_follower.NewGetFollowerUseCaseReq(user.ID)
+	req := get_follower.NewGetFollowerUseCaseReq(userId, "", 10)
 	res := get_follower.NewGetFollowerUseCaseRes()
 	uc := get_follower.NewGetFollowerUsecase(userRepo, req, res)
 
 	uc.Execute()
 
 	assert.Nil(t, res.Err)
+	assert.Equal(t, int64(3), res.Total)
+	assert.Empty(t, res.NextCursor)
 	assert.Equal(t, 3, len(res.Users))
-	assert.Equal(t, follower1.UserName, res.Users[0].UserName)
-	assert.Equal(t, follower2.UserName, res.Users[1].UserName)
-	assert.Equal(t, follower3.UserName, res.Users[2].UserName)
+	assert.Equal(t, "follower1", res.Users[0].UserName)
+	assert.Equal(t, "follower2", res.Users[1].UserName)
+	assert.Equal(t, "follower3", res.Users[2].UserName)
+	assert.Equal(t, page.Items[0].FollowedAt, res.Users[0].FollowedAt)
+}
+
+func TestGetFollowerWithCursor(t *testing.T) {
+	userRepo := setup(t)
+
+	userId := uuid.New()
+	firstPage := newFollowPage(5, "follower1", "follower2", "follower3")
+	userRepo.EXPECT().GetFollowers(userId, repository.FollowCursor{}, 3).Return(firstPage, nil)
+
+	req := get_follower.NewGetFollowerUseCaseReq(userId, "", 2)
+	res := get_follower.NewGetFollowerUseCaseRes()
+	get_follower.NewGetFollowerUsecase(userRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, int64(5), res.Total)
+	assert.Len(t, res.Users, 2)
+	assert.NotEmpty(t, res.NextCursor)
+
+	// the next page starts after the last follower of the previous page
+	last := firstPage.Items[1]
+	userRepo.
+		EXPECT().
+		GetFollowers(userId, gomock.AssignableToTypeOf(repository.FollowCursor{}), 3).
+		DoAndReturn(func(_ uuid.UUID, cursor repository.FollowCursor, _ int) (*repository.FollowPage, error) {
+			assert.Equal(t, last.User.ID, cursor.UserId)
+			assert.True(t, last.FollowedAt.Equal(cursor.FollowedAt))
+			return newFollowPage(5, "follower3"), nil
+		})
+
+	req = get_follower.NewGetFollowerUseCaseReq(userId, res.NextCursor, 2)
+	res = get_follower.NewGetFollowerUseCaseRes()
+	get_follower.NewGetFollowerUsecase(userRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.Users, 1)
+	assert.Empty(t, res.NextCursor)
+}
+
+func TestGetFollowerWithInvalidCursor(t *testing.T) {
+	userRepo := setup(t)
+
+	req := get_follower.NewGetFollowerUseCaseReq(uuid.New(), "not a cursor", 10)
+	res := get_follower.NewGetFollowerUseCaseRes()
+	get_follower.NewGetFollowerUsecase(userRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, get_follower.ErrInvalidCursor)
 }
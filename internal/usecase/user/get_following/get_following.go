@@ -2,7 +2,6 @@ package get_following
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -11,13 +10,22 @@ import (
 	"mashu.example/internal/usecase/types"
 )
 
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("limit must be positive")
+)
+
 type GetFollowingUseCaseReq struct {
 	userId uuid.UUID
+	cursor string // returned by the previous page, empty for the first page
+	limit  int
 }
 
 type GetFollowingUseCaseRes struct {
-	Users []*types.FollowingInfo
-	Err   error
+	Users      []*types.FollowingInfo
+	Total      int64  // number of all users followed by the user
+	NextCursor string // empty if there is no more following user
+	Err        error
 }
 
 type GetFollowingUseCase struct {
@@ -27,33 +35,44 @@ type GetFollowingUseCase struct {
 }
 
 func (uc *GetFollowingUseCase) Execute() {
-	user, err := uc.userRepo.GetUserById(uc.req.userId)
-	if err != nil {
-		errMsg := fmt.Sprintf("failed to get user (userId: %s)", uc.req.userId)
-		logrus.Errorf(errMsg)
-		uc.res.Err = errors.New(errMsg)
+	if uc.req.limit <= 0 {
+		uc.res.Err = ErrInvalidLimit
 		return
 	}
 
-	followingInfos := []*types.FollowingInfo{}
-	for _, followingId := range user.Followings {
-		followingUser, err := uc.userRepo.GetUserById(followingId)
+	cursor := repository.FollowCursor{}
+	if uc.req.cursor != "" {
+		decoded, err := repository.DecodeFollowCursor(uc.req.cursor)
 		if err != nil {
-			errMsg := fmt.Sprintf("failed to get user's following user (userId: %s)", uc.req.userId)
-			logrus.Errorf(errMsg)
-			uc.res.Err = errors.New(errMsg)
+			uc.res.Err = ErrInvalidCursor
+			logrus.Error(err)
 			return
 		}
-		followingInfos = append(followingInfos, &types.FollowingInfo{
-			ID:          followingUser.ID,
-			UserName:    followingUser.UserName,
-			DisplayName: followingUser.DisplayName,
-			Email:       followingUser.Email,
-			Public:      followingUser.Public,
-		})
+		cursor = decoded
+	}
+
+	// query one more following user to know if there is a next page
+	page, err := uc.userRepo.GetFollowings(uc.req.userId, cursor, uc.req.limit+1)
+	if err != nil {
+		logrus.Errorf("failed to get following users (userId: %s)", uc.req.userId)
+		uc.res.Err = err
+		return
+	}
+
+	items := page.Items
+	if len(items) > uc.req.limit {
+		items = items[:uc.req.limit]
+		last := items[len(items)-1]
+		uc.res.NextCursor = repository.FollowCursor{FollowedAt: last.FollowedAt, UserId: last.User.ID}.Encode()
+	}
+
+	followingInfos := []*types.FollowingInfo{}
+	for _, item := range items {
+		followingInfos = append(followingInfos, types.NewFollowingInfo(item.User, item.FollowedAt))
 	}
 
 	uc.res.Users = followingInfos
+	uc.res.Total = page.Total
 	uc.res.Err = nil
 }
 
@@ -65,8 +84,8 @@ func NewGetFollowingUseCase(
 	return &GetFollowingUseCase{userRepo, req, res}
 }
 
-func NewGetFollowingUseCaseReq(userId uuid.UUID) *GetFollowingUseCaseReq {
-	return &GetFollowingUseCaseReq{userId}
+func NewGetFollowingUseCaseReq(userId uuid.UUID, cursor string, limit int) *GetFollowingUseCaseReq {
+	return &GetFollowingUseCaseReq{userId, cursor, limit}
 }
 
 func NewGetFollowingUseCaseRes() *GetFollowingUseCaseRes {
@@ -2,11 +2,13 @@ package get_following_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
 	"mashu.example/internal/usecase/repository/mock"
 	"mashu.example/internal/usecase/user/get_following"
 )
@@ -18,32 +20,42 @@ func setup(t *testing.T) *mock.MockUserRepo {
 	return mock.NewMockUserRepo(mockCtrl)
 }
 
-func TestGetFollowingUser(t *testing.T) {
+func TestGetFollowing(t *testing.T) {
 	userRepo := setup(t)
 
-	user := entity.NewUser(uuid.New(), "mashu6211", "mashu", "mashu@email.com", true)
-	following1 := entity.NewUser(uuid.New(), "following1", "following 1", "following1@email.com", true)
-	following2 := entity.NewUser(uuid.New(), "following2", "following 2", "following2@email.com", true)
-	following3 := entity.NewUser(uuid.New(), "following3", "following 3", "following3@email.com", true)
-
-	user.Followings = append(user.Followings, following1.ID)
-	user.Followings = append(user.Followings, following2.ID)
-	user.Followings = append(user.Followings, following3.ID)
-
-	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
-	userRepo.EXPECT().GetUserById(following1.ID).Return(following1, nil)
-	userRepo.EXPECT().GetUserById(following2.ID).Return(following2, nil)
-	userRepo.EXPECT().GetUserById(following3.ID).Return(following3, nil)
-
-	req := get_following.NewGetFollowingUseCaseReq(user.ID)
+	userId := uuid.New()
+	page := &repository.FollowPage{Total: 3}
+	for _, name := range []string{"following1", "following2", "following3"} {
+		page.Items = append(page.Items, &repository.FollowPageItem{
+			User:       entity.NewUser(uuid.New(), name, name, name+"@email.com", true),
+			FollowedAt: time.Now(),
+		})
+	}
+	userRepo.EXPECT().GetFollowings(userId, repository.FollowCursor{}, 3).Return(page, nil)
+
+	req := get_following.NewGetFollowingUseCaseReq(userId, "", 2)
 	res := get_following.NewGetFollowingUseCaseRes()
 	uc := get_following.NewGetFollowingUseCase(userRepo, req, res)
 
 	uc.Execute()
 
 	assert.Nil(t, res.Err)
-	assert.Equal(t, 3, len(res.Users))
+	assert.Equal(t, int64(3), res.Total)
+	assert.Equal(t, 2, len(res.Users))
 	assert.Equal(t, "following1", res.Users[0].UserName)
 	assert.Equal(t, "following2", res.Users[1].UserName)
-	assert.Equal(t, "following3", res.Users[2].UserName)
+
+	cursor, err := repository.DecodeFollowCursor(res.NextCursor)
+	assert.Nil(t, err)
+	assert.Equal(t, page.Items[1].User.ID, cursor.UserId)
+}
+
+func TestGetFollowingWithInvalidLimit(t *testing.T) {
+	userRepo := setup(t)
+
+	req := get_following.NewGetFollowingUseCaseReq(uuid.New(), "", 0)
+	res := get_following.NewGetFollowingUseCaseRes()
+	get_following.NewGetFollowingUseCase(userRepo, req, res).Execute()
+
+	assert.ErrorIs(t, res.Err, get_following.ErrInvalidLimit)
 }
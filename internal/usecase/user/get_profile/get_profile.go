@@ -0,0 +1,81 @@
+package get_profile
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+type GetProfileUseCaseReq struct {
+	viewerId uuid.UUID
+	userId   uuid.UUID // uuid.Nil if the profile is looked up by the username
+	username string
+}
+
+type GetProfileUseCaseRes struct {
+	Profile *types.ProfileInfo
+	IsOwner bool // whether the viewer is the owner of the profile
+	Err     error
+}
+
+type GetProfileUseCase struct {
+	userRepo repository.UserRepo
+	req      *GetProfileUseCaseReq
+	res      *GetProfileUseCaseRes
+}
+
+func (uc *GetProfileUseCase) Execute() {
+	var err error
+	var user *entity.User
+	if uc.req.userId != uuid.Nil {
+		user, err = uc.userRepo.GetUserById(uc.req.userId)
+	} else {
+		user, err = uc.userRepo.GetUserByUserName(uc.req.username)
+	}
+	if err != nil {
+		uc.res.Err = ErrProfileNotFound
+		logrus.Info(err)
+		return
+	}
+
+	// the user who blocked the viewer is hidden from the viewer
+	if user.HasBlocked(uc.req.viewerId) {
+		uc.res.Err = ErrProfileNotFound
+		return
+	}
+
+	uc.res.Profile = types.NewProfileInfo(user)
+	uc.res.IsOwner = user.ID == uc.req.viewerId
+	uc.res.Err = nil
+}
+
+func NewGetProfileUseCase(
+	userRepo repository.UserRepo,
+	req *GetProfileUseCaseReq,
+	res *GetProfileUseCaseRes,
+) usecase.UseCase {
+	return &GetProfileUseCase{userRepo, req, res}
+}
+
+// build the request to view the profile of the user with the username
+func NewGetProfileUseCaseReq(viewerId uuid.UUID, username string) *GetProfileUseCaseReq {
+	return &GetProfileUseCaseReq{viewerId: viewerId, username: username}
+}
+
+// build the request to view the profile of the viewer
+func NewGetOwnProfileUseCaseReq(userId uuid.UUID) *GetProfileUseCaseReq {
+	return &GetProfileUseCaseReq{viewerId: userId, userId: userId}
+}
+
+func NewGetProfileUseCaseRes() *GetProfileUseCaseRes {
+	return &GetProfileUseCaseRes{}
+}
@@ -0,0 +1,89 @@
+package get_profile_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/get_profile"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	user.Bio = "hello"
+	viewer := entity.NewUser(uuid.New(), "viewer", "Viewer", "viewer@email.com", true)
+
+	return mock.NewMockUserRepo(mockCtrl), user, viewer
+}
+
+func TestGetProfile(t *testing.T) {
+	userRepo, user, viewer := setup(t)
+	user.AddFollower(viewer.ID)
+
+	userRepo.EXPECT().GetUserByUserName("user").Return(user, nil)
+
+	req := get_profile.NewGetProfileUseCaseReq(viewer.ID, "user")
+	res := get_profile.NewGetProfileUseCaseRes()
+	uc := get_profile.NewGetProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.False(t, res.IsOwner)
+	assert.Equal(t, user.ID, res.Profile.ID)
+	assert.Equal(t, "hello", res.Profile.Bio)
+	assert.Equal(t, 1, res.Profile.FollowerCount)
+	assert.Equal(t, 0, res.Profile.FollowingCount)
+}
+
+func TestGetOwnProfile(t *testing.T) {
+	userRepo, user, _ := setup(t)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	req := get_profile.NewGetOwnProfileUseCaseReq(user.ID)
+	res := get_profile.NewGetProfileUseCaseRes()
+	uc := get_profile.NewGetProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.True(t, res.IsOwner)
+	assert.Equal(t, "user@email.com", res.Profile.Email)
+}
+
+func TestGetProfileNotFound(t *testing.T) {
+	userRepo, _, viewer := setup(t)
+
+	userRepo.EXPECT().GetUserByUserName("nobody").Return(nil, errors.New("record not found"))
+
+	req := get_profile.NewGetProfileUseCaseReq(viewer.ID, "nobody")
+	res := get_profile.NewGetProfileUseCaseRes()
+	uc := get_profile.NewGetProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, get_profile.ErrProfileNotFound)
+}
+
+func TestGetProfileOfBlockingUser(t *testing.T) {
+	userRepo, user, viewer := setup(t)
+	user.Block(viewer.ID)
+
+	userRepo.EXPECT().GetUserByUserName("user").Return(user, nil)
+
+	req := get_profile.NewGetProfileUseCaseReq(viewer.ID, "user")
+	res := get_profile.NewGetProfileUseCaseRes()
+	uc := get_profile.NewGetProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, get_profile.ErrProfileNotFound)
+	assert.Nil(t, res.Profile)
+}
@@ -0,0 +1,111 @@
+package list_follow_requests
+
+import (
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+// DTO for the other user of a pending follow request
+type FollowRequestDTO struct {
+	UserId      uuid.UUID
+	UserName    string
+	DisplayName string
+	Public      bool
+}
+
+type ListFollowRequestsUseCaseReq struct {
+	userId uuid.UUID
+}
+
+type ListFollowRequestsUseCaseRes struct {
+	Incoming []FollowRequestDTO // the users requesting to follow the user
+	Outgoing []FollowRequestDTO // the users the user requests to follow
+	Err      error
+}
+
+type ListFollowRequestsUseCase struct {
+	userRepo repository.UserRepo
+	req      *ListFollowRequestsUseCaseReq
+	res      *ListFollowRequestsUseCaseRes
+}
+
+func (uc *ListFollowRequestsUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	incomingReqs := user.IncomingFollowRequests()
+	outgoingReqs := user.OutgoingFollowRequests()
+
+	// load the other users of the requests at once
+	userIds := []uuid.UUID{}
+	for _, req := range incomingReqs {
+		userIds = append(userIds, req.From)
+	}
+	for _, req := range outgoingReqs {
+		userIds = append(userIds, req.To)
+	}
+	users, err := uc.userRepo.GetUsersByIds(userIds)
+	if err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	userMap := map[uuid.UUID]*entity.User{}
+	for _, u := range users {
+		userMap[u.ID] = u
+	}
+
+	for _, req := range incomingReqs {
+		if dto, ok := newFollowRequestDTO(userMap, req.From); ok {
+			uc.res.Incoming = append(uc.res.Incoming, dto)
+		}
+	}
+	for _, req := range outgoingReqs {
+		if dto, ok := newFollowRequestDTO(userMap, req.To); ok {
+			uc.res.Outgoing = append(uc.res.Outgoing, dto)
+		}
+	}
+
+	uc.res.Err = nil
+}
+
+// build the DTO of the user, the request of a deleted user is skipped
+func newFollowRequestDTO(userMap map[uuid.UUID]*entity.User, userId uuid.UUID) (FollowRequestDTO, bool) {
+	u, ok := userMap[userId]
+	if !ok {
+		return FollowRequestDTO{}, false
+	}
+
+	return FollowRequestDTO{
+		UserId:      u.ID,
+		UserName:    u.UserName,
+		DisplayName: u.DisplayName,
+		Public:      u.Public,
+	}, true
+}
+
+func NewListFollowRequestsUseCase(
+	userRepo repository.UserRepo,
+	req *ListFollowRequestsUseCaseReq,
+	res *ListFollowRequestsUseCaseRes,
+) usecase.UseCase {
+	return &ListFollowRequestsUseCase{userRepo, req, res}
+}
+
+func NewListFollowRequestsUseCaseReq(userId uuid.UUID) *ListFollowRequestsUseCaseReq {
+	return &ListFollowRequestsUseCaseReq{userId}
+}
+
+func NewListFollowRequestsUseCaseRes() *ListFollowRequestsUseCaseRes {
+	return &ListFollowRequestsUseCaseRes{
+		Incoming: []FollowRequestDTO{},
+		Outgoing: []FollowRequestDTO{},
+	}
+}
@@ -0,0 +1,60 @@
+package list_follow_requests_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/list_follow_requests"
+)
+
+func TestListFollowRequests(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	requester := entity.NewUser(uuid.New(), "requester", "Requester", "requester@email.com", true)
+	followee := entity.NewUser(uuid.New(), "followee", "Followee", "followee@email.com", false)
+	deletedId := uuid.New()
+	user.AddFollowRequest(&entity.FollowRequest{From: requester.ID, To: user.ID})
+	user.AddFollowRequest(&entity.FollowRequest{From: deletedId, To: user.ID})
+	user.AddFollowRequest(&entity.FollowRequest{From: user.ID, To: followee.ID})
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.
+		EXPECT().
+		GetUsersByIds([]uuid.UUID{requester.ID, deletedId, followee.ID}).
+		Return([]*entity.User{followee, requester}, nil)
+
+	req := list_follow_requests.NewListFollowRequestsUseCaseReq(user.ID)
+	res := list_follow_requests.NewListFollowRequestsUseCaseRes()
+	uc := list_follow_requests.NewListFollowRequestsUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.Incoming, 1)
+	assert.Equal(t, requester.ID, res.Incoming[0].UserId)
+	assert.Equal(t, "requester", res.Incoming[0].UserName)
+	assert.Len(t, res.Outgoing, 1)
+	assert.Equal(t, followee.ID, res.Outgoing[0].UserId)
+	assert.False(t, res.Outgoing[0].Public)
+}
+
+func TestListEmptyFollowRequests(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", false)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUsersByIds([]uuid.UUID{}).Return([]*entity.User{}, nil)
+
+	req := list_follow_requests.NewListFollowRequestsUseCaseReq(user.ID)
+	res := list_follow_requests.NewListFollowRequestsUseCaseRes()
+	list_follow_requests.NewListFollowRequestsUseCase(userRepo, req, res).Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, res.Incoming, 0)
+	assert.Len(t, res.Outgoing, 0)
+}
@@ -1,56 +1,101 @@
 package login
 
 import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
 	"mashu.example/pkg/jwt"
+	"mashu.example/pkg/password"
+)
+
+var (
+	// returned for both unknown username and wrong password, so the caller
+	// can't tell whether the username exists
+	ErrInvalidCredentials = errors.New("invalid username or password")
 )
 
 type LoginUseCaseReq struct {
 	username string
+	password string
 }
 
 type LoginUseCaseRes struct {
-	AccessToken string
-	Err         error
+	SessionId    uuid.UUID
+	AccessToken  string
+	RefreshToken string
+	Err          error
 }
 
 type LoginUseCase struct {
-	userRepo repository.UserRepo
-	req      *LoginUseCaseReq
-	res      *LoginUseCaseRes
+	userRepo    repository.UserRepo
+	sessionRepo repository.SessionRepo
+	jwtClient   jwt.JWTClient
+	req         *LoginUseCaseReq
+	res         *LoginUseCaseRes
 }
 
 func (uc *LoginUseCase) Execute() {
+	hashedPassword := ""
 	user, err := uc.userRepo.GetUserByUserName(uc.req.username)
+	if err == nil {
+		hashedPassword = user.Password
+	}
+
+	// always verify the password, even if the user doesn't exist
+	if ok := password.Verify(hashedPassword, uc.req.password); !ok || err != nil {
+		logrus.Errorf("failed to login as user %s", uc.req.username)
+		uc.res.Err = ErrInvalidCredentials
+		return
+	}
+
+	refreshToken, err := jwt.NewRefreshToken()
 	if err != nil {
-		logrus.Errorf("user %s doesn't exist", uc.req.username)
+		logrus.Errorf("failed to generate refresh token")
+		uc.res.Err = err
+		return
+	}
+
+	session := entity.NewSession(
+		uuid.New(),
+		user.ID,
+		jwt.HashRefreshToken(refreshToken),
+		time.Now().Add(uc.jwtClient.RefreshTokenExpiry()),
+	)
+	if err := uc.sessionRepo.Save(session); err != nil {
+		logrus.Errorf("failed to save session of user %s", uc.req.username)
 		uc.res.Err = err
 		return
 	}
 
-	jwtClient := jwt.NewJWTClient(*jwt.NewAuthConfig())
-	token, err := jwtClient.CreateToken(user.ID)
+	token, err := uc.jwtClient.CreateToken(user.ID, session.ID)
 	if err != nil {
 		logrus.Errorf("failed to generate token")
 		uc.res.Err = err
 		return
 	}
 
+	uc.res.SessionId = session.ID
 	uc.res.AccessToken = token
+	uc.res.RefreshToken = refreshToken
 }
 
 func NewLoginUseCase(
 	userRepo repository.UserRepo,
+	sessionRepo repository.SessionRepo,
+	jwtClient jwt.JWTClient,
 	req *LoginUseCaseReq,
 	res *LoginUseCaseRes,
 ) usecase.UseCase {
-	return &LoginUseCase{userRepo, req, res}
+	return &LoginUseCase{userRepo, sessionRepo, jwtClient, req, res}
 }
 
-func NewLoginUseCaseReq(username string) *LoginUseCaseReq {
-	return &LoginUseCaseReq{username}
+func NewLoginUseCaseReq(username string, password string) *LoginUseCaseReq {
+	return &LoginUseCaseReq{username, password}
 }
 
 func NewLoginUseCaseRes() *LoginUseCaseRes {
@@ -2,6 +2,7 @@ package login_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -10,42 +11,86 @@ import (
 	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase/repository/mock"
 	"mashu.example/internal/usecase/user/login"
+	"mashu.example/pkg/jwt"
+	"mashu.example/pkg/password"
 )
 
-func setup(t *testing.T) *mock.MockUserRepo {
+func setup(t *testing.T) (*mock.MockUserRepo, *mock.MockSessionRepo, jwt.JWTClient) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	return mock.NewMockUserRepo(mockCtrl)
+	jwtClient := jwt.NewJWTClient(*jwt.NewAuthConfigWith("secret", time.Minute, time.Hour))
+
+	return mock.NewMockUserRepo(mockCtrl), mock.NewMockSessionRepo(mockCtrl), jwtClient
+}
+
+func newUserWithPassword(plain string) *entity.User {
+	user := entity.NewUser(uuid.New(), "mashu6211", "Mashu", "mashu@email.com", false)
+	user.Password, _ = password.Hash(plain)
+
+	return user
 }
 
 func TestLoginAsValidUser(t *testing.T) {
-	userRepo := setup(t)
+	userRepo, sessionRepo, jwtClient := setup(t)
 
-	user := entity.NewUser(uuid.New(), "mashu6211", "Mashu", "mashu@email.com", false)
+	user := newUserWithPassword("mashu-password")
 
+	var session *entity.Session
 	userRepo.EXPECT().GetUserByUserName(user.UserName).Return(user, nil)
+	sessionRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Session{})).Do(
+		func(arg *entity.Session) { session = arg },
+	)
 
-	req := login.NewLoginUseCaseReq("mashu6211")
+	req := login.NewLoginUseCaseReq("mashu6211", "mashu-password")
 	res := login.NewLoginUseCaseRes()
-	uc := login.NewLoginUseCase(userRepo, req, res)
+	uc := login.NewLoginUseCase(userRepo, sessionRepo, jwtClient, req, res)
 
 	uc.Execute()
 
 	assert.Nil(t, res.Err)
 	assert.NotEmpty(t, res.AccessToken)
+	assert.NotEmpty(t, res.RefreshToken)
+	assert.Equal(t, session.ID, res.SessionId)
+	assert.Equal(t, user.ID, session.UserId)
+	assert.Equal(t, jwt.HashRefreshToken(res.RefreshToken), session.RefreshTokenHash)
+	assert.True(t, session.IsActive())
+
+	token, err := jwtClient.VerifyToken(res.AccessToken)
+	assert.Nil(t, err)
+	sessionId, _ := jwt.GetSessionId(token)
+	assert.Equal(t, session.ID, sessionId)
+}
+
+func TestLoginWithWrongPassword(t *testing.T) {
+	userRepo, sessionRepo, jwtClient := setup(t)
+
+	user := newUserWithPassword("mashu-password")
+
+	userRepo.EXPECT().GetUserByUserName(user.UserName).Return(user, nil)
+
+	req := login.NewLoginUseCaseReq("mashu6211", "wrong-password")
+	res := login.NewLoginUseCaseRes()
+	uc := login.NewLoginUseCase(userRepo, sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, login.ErrInvalidCredentials)
+	assert.Empty(t, res.AccessToken)
+	assert.Empty(t, res.RefreshToken)
 }
 
 func TestLoginAsNonExistUser(t *testing.T) {
-	userRepo := setup(t)
+	userRepo, sessionRepo, jwtClient := setup(t)
 	userRepo.EXPECT().GetUserByUserName("mashu6211").Return(nil, gorm.ErrRecordNotFound)
 
-	req := login.NewLoginUseCaseReq("mashu6211")
+	req := login.NewLoginUseCaseReq("mashu6211", "mashu-password")
 	res := login.NewLoginUseCaseRes()
-	uc := login.NewLoginUseCase(userRepo, req, res)
+	uc := login.NewLoginUseCase(userRepo, sessionRepo, jwtClient, req, res)
 
 	uc.Execute()
 
-	assert.Error(t, res.Err)
+	assert.ErrorIs(t, res.Err, login.ErrInvalidCredentials)
 	assert.Empty(t, res.AccessToken)
+	assert.Empty(t, res.RefreshToken)
 }
@@ -0,0 +1,93 @@
+package logout
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotOwnerOfSession = errors.New("the session doesn't belong to the user")
+)
+
+type LogoutUseCaseReq struct {
+	userId     uuid.UUID
+	sessionId  uuid.UUID
+	allDevices bool // revoke all sessions of the user
+}
+
+type LogoutUseCaseRes struct {
+	Err error
+}
+
+type LogoutUseCase struct {
+	sessionRepo repository.SessionRepo
+	req         *LogoutUseCaseReq
+	res         *LogoutUseCaseRes
+}
+
+func (uc *LogoutUseCase) Execute() {
+	var sessions []*entity.Session
+
+	if uc.req.allDevices {
+		userSessions, err := uc.sessionRepo.GetSessionsByUserId(uc.req.userId)
+		if err != nil {
+			logrus.Errorf("failed to get sessions of user %s", uc.req.userId)
+			uc.res.Err = err
+			return
+		}
+		sessions = userSessions
+	} else {
+		session, err := uc.sessionRepo.GetSessionById(uc.req.sessionId)
+		if err != nil {
+			uc.res.Err = err
+			logrus.Error(err)
+			return
+		}
+		if session.UserId != uc.req.userId {
+			uc.res.Err = ErrNotOwnerOfSession
+			logrus.Error(uc.res.Err)
+			return
+		}
+		sessions = append(sessions, session)
+	}
+
+	for _, session := range sessions {
+		if session.RevokedAt != nil {
+			continue
+		}
+
+		session.Revoke()
+		if err := uc.sessionRepo.Save(session); err != nil {
+			logrus.Errorf("failed to revoke session %s", session.ID)
+			uc.res.Err = err
+			return
+		}
+	}
+
+	uc.res.Err = nil
+}
+
+func NewLogoutUseCase(
+	sessionRepo repository.SessionRepo,
+	req *LogoutUseCaseReq,
+	res *LogoutUseCaseRes,
+) usecase.UseCase {
+	return &LogoutUseCase{sessionRepo, req, res}
+}
+
+func NewLogoutUseCaseReq(
+	userId uuid.UUID,
+	sessionId uuid.UUID,
+	allDevices bool,
+) *LogoutUseCaseReq {
+	return &LogoutUseCaseReq{userId, sessionId, allDevices}
+}
+
+func NewLogoutUseCaseRes() *LogoutUseCaseRes {
+	return &LogoutUseCaseRes{}
+}
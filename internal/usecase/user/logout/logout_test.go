@@ -0,0 +1,86 @@
+package logout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/logout"
+)
+
+func setup(t *testing.T) *mock.MockSessionRepo {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	return mock.NewMockSessionRepo(mockCtrl)
+}
+
+func TestLogout(t *testing.T) {
+	sessionRepo := setup(t)
+
+	userId := uuid.New()
+	session := entity.NewSession(uuid.New(), userId, "hash", time.Now().Add(time.Hour))
+
+	sessionRepo.EXPECT().GetSessionById(session.ID).Return(session, nil)
+	sessionRepo.EXPECT().Save(gomock.AssignableToTypeOf(&entity.Session{})).Do(
+		func(arg *entity.Session) { session = arg },
+	)
+
+	req := logout.NewLogoutUseCaseReq(userId, session.ID, false)
+	res := logout.NewLogoutUseCaseRes()
+	uc := logout.NewLogoutUseCase(sessionRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.NotNil(t, session.RevokedAt)
+	assert.False(t, session.IsActive())
+}
+
+func TestLogoutFromOthersSession(t *testing.T) {
+	sessionRepo := setup(t)
+
+	session := entity.NewSession(uuid.New(), uuid.New(), "hash", time.Now().Add(time.Hour))
+
+	sessionRepo.EXPECT().GetSessionById(session.ID).Return(session, nil)
+
+	req := logout.NewLogoutUseCaseReq(uuid.New(), session.ID, false)
+	res := logout.NewLogoutUseCaseRes()
+	uc := logout.NewLogoutUseCase(sessionRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, logout.ErrNotOwnerOfSession)
+	assert.True(t, session.IsActive())
+}
+
+func TestLogoutAllDevices(t *testing.T) {
+	sessionRepo := setup(t)
+
+	userId := uuid.New()
+	sessions := []*entity.Session{
+		entity.NewSession(uuid.New(), userId, "hash1", time.Now().Add(time.Hour)),
+		entity.NewSession(uuid.New(), userId, "hash2", time.Now().Add(time.Hour)),
+		entity.NewSession(uuid.New(), userId, "hash3", time.Now().Add(time.Hour)),
+	}
+	sessions[2].Revoke()
+
+	sessionRepo.EXPECT().GetSessionsByUserId(userId).Return(sessions, nil)
+	sessionRepo.EXPECT().Save(sessions[0])
+	sessionRepo.EXPECT().Save(sessions[1])
+
+	req := logout.NewLogoutUseCaseReq(userId, sessions[0].ID, true)
+	res := logout.NewLogoutUseCaseRes()
+	uc := logout.NewLogoutUseCase(sessionRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	for _, session := range sessions {
+		assert.False(t, session.IsActive())
+	}
+}
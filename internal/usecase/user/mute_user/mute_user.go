@@ -0,0 +1,79 @@
+package mute_user
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrMuteSelf     = errors.New("can not mute yourself")
+	ErrAlreadyMuted = errors.New("the user is already muted")
+)
+
+type MuteUserUseCaseReq struct {
+	userId  uuid.UUID
+	mutedId uuid.UUID
+}
+
+type MuteUserUseCaseRes struct {
+	Err error
+}
+
+type MuteUserUseCase struct {
+	userRepo repository.UserRepo
+	req      *MuteUserUseCaseReq
+	res      *MuteUserUseCaseRes
+}
+
+func (uc *MuteUserUseCase) Execute() {
+	if uc.req.userId == uc.req.mutedId {
+		uc.res.Err = ErrMuteSelf
+		return
+	}
+
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	// the muted user is unaware of being muted, only check the existence
+	if _, err := uc.userRepo.GetUserById(uc.req.mutedId); err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.mutedId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !user.Mute(uc.req.mutedId) {
+		uc.res.Err = ErrAlreadyMuted
+		return
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewMuteUserUseCase(
+	userRepo repository.UserRepo,
+	req *MuteUserUseCaseReq,
+	res *MuteUserUseCaseRes,
+) usecase.UseCase {
+	return &MuteUserUseCase{userRepo, req, res}
+}
+
+func NewMuteUserUseCaseReq(userId, mutedId uuid.UUID) *MuteUserUseCaseReq {
+	return &MuteUserUseCaseReq{userId, mutedId}
+}
+
+func NewMuteUserUseCaseRes() *MuteUserUseCaseRes {
+	return &MuteUserUseCaseRes{}
+}
@@ -0,0 +1,54 @@
+package mute_user_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/mute_user"
+)
+
+func TestMuteUser(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	muted := entity.NewUser(uuid.New(), "muted", "Muted", "muted@email.com", true)
+	user.AddFollowing(muted.ID)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(muted.ID).Return(muted, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := mute_user.NewMuteUserUseCaseReq(user.ID, muted.ID)
+	res := mute_user.NewMuteUserUseCaseRes()
+	uc := mute_user.NewMuteUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, []uuid.UUID{muted.ID}, user.MutedUsers)
+	// muting keeps the follow relation
+	assert.Equal(t, []uuid.UUID{muted.ID}, user.Followings)
+}
+
+func TestMuteUserTwice(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	muted := entity.NewUser(uuid.New(), "muted", "Muted", "muted@email.com", true)
+	user.Mute(muted.ID)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(muted.ID).Return(muted, nil)
+
+	req := mute_user.NewMuteUserUseCaseReq(user.ID, muted.ID)
+	res := mute_user.NewMuteUserUseCaseRes()
+	uc := mute_user.NewMuteUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, mute_user.ErrAlreadyMuted)
+}
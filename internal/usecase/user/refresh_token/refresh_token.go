@@ -0,0 +1,99 @@
+package refresh_token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg/jwt"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+)
+
+type RefreshTokenUseCaseReq struct {
+	refreshToken string
+}
+
+type RefreshTokenUseCaseRes struct {
+	AccessToken  string
+	RefreshToken string
+	Err          error
+}
+
+type RefreshTokenUseCase struct {
+	sessionRepo repository.SessionRepo
+	jwtClient   jwt.JWTClient
+	req         *RefreshTokenUseCaseReq
+	res         *RefreshTokenUseCaseRes
+}
+
+func (uc *RefreshTokenUseCase) Execute() {
+	oldRefreshTokenHash := jwt.HashRefreshToken(uc.req.refreshToken)
+	session, err := uc.sessionRepo.GetSessionByRefreshTokenHash(oldRefreshTokenHash)
+	if err != nil {
+		uc.res.Err = ErrInvalidRefreshToken
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !session.IsActive() {
+		uc.res.Err = ErrInvalidRefreshToken
+		logrus.Errorf("session %s is no longer active", session.ID)
+		return
+	}
+
+	// rotate the refresh token, the used one can't be used again
+	refreshToken, err := jwt.NewRefreshToken()
+	if err != nil {
+		logrus.Errorf("failed to generate refresh token")
+		uc.res.Err = err
+		return
+	}
+	session.Rotate(
+		jwt.HashRefreshToken(refreshToken),
+		time.Now().Add(uc.jwtClient.RefreshTokenExpiry()),
+	)
+	// only one of the concurrent requests with the same token can rotate it
+	if err := uc.sessionRepo.RotateRefreshToken(session, oldRefreshTokenHash); err != nil {
+		var errSessionNotFound *repository.ErrSessionNotFound
+		if errors.As(err, &errSessionNotFound) {
+			uc.res.Err = ErrInvalidRefreshToken
+			logrus.Errorf("refresh token of session %s has been used", session.ID)
+			return
+		}
+		logrus.Errorf("failed to save session %s", session.ID)
+		uc.res.Err = err
+		return
+	}
+
+	token, err := uc.jwtClient.CreateToken(session.UserId, session.ID)
+	if err != nil {
+		logrus.Errorf("failed to generate token")
+		uc.res.Err = err
+		return
+	}
+
+	uc.res.AccessToken = token
+	uc.res.RefreshToken = refreshToken
+}
+
+func NewRefreshTokenUseCase(
+	sessionRepo repository.SessionRepo,
+	jwtClient jwt.JWTClient,
+	req *RefreshTokenUseCaseReq,
+	res *RefreshTokenUseCaseRes,
+) usecase.UseCase {
+	return &RefreshTokenUseCase{sessionRepo, jwtClient, req, res}
+}
+
+func NewRefreshTokenUseCaseReq(refreshToken string) *RefreshTokenUseCaseReq {
+	return &RefreshTokenUseCaseReq{refreshToken}
+}
+
+func NewRefreshTokenUseCaseRes() *RefreshTokenUseCaseRes {
+	return &RefreshTokenUseCaseRes{}
+}
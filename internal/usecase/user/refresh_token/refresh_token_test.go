@@ -0,0 +1,153 @@
+package refresh_token_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/refresh_token"
+	"mashu.example/pkg/jwt"
+)
+
+func setup(t *testing.T) (*mock.MockSessionRepo, jwt.JWTClient) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	jwtClient := jwt.NewJWTClient(*jwt.NewAuthConfigWith("secret", time.Minute, time.Hour))
+
+	return mock.NewMockSessionRepo(mockCtrl), jwtClient
+}
+
+func TestRefreshToken(t *testing.T) {
+	sessionRepo, jwtClient := setup(t)
+
+	oldRefreshToken, _ := jwt.NewRefreshToken()
+	session := entity.NewSession(
+		uuid.New(),
+		uuid.New(),
+		jwt.HashRefreshToken(oldRefreshToken),
+		time.Now().Add(time.Minute),
+	)
+
+	sessionRepo.EXPECT().GetSessionByRefreshTokenHash(jwt.HashRefreshToken(oldRefreshToken)).Return(session, nil)
+	sessionRepo.
+		EXPECT().
+		RotateRefreshToken(gomock.AssignableToTypeOf(&entity.Session{}), jwt.HashRefreshToken(oldRefreshToken)).
+		Do(func(arg *entity.Session, _ string) { session = arg })
+
+	req := refresh_token.NewRefreshTokenUseCaseReq(oldRefreshToken)
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.NotEmpty(t, res.AccessToken)
+	assert.NotEqual(t, oldRefreshToken, res.RefreshToken)
+	assert.Equal(t, jwt.HashRefreshToken(res.RefreshToken), session.RefreshTokenHash)
+	assert.True(t, session.ExpiredAt.After(time.Now().Add(time.Minute)))
+
+	token, err := jwtClient.VerifyToken(res.AccessToken)
+	assert.Nil(t, err)
+	userId, _ := jwt.GetUserId(token)
+	assert.Equal(t, session.UserId, userId)
+}
+
+func TestRefreshTokenUsedByAnotherRequest(t *testing.T) {
+	sessionRepo, jwtClient := setup(t)
+
+	refreshToken, _ := jwt.NewRefreshToken()
+	session := entity.NewSession(
+		uuid.New(),
+		uuid.New(),
+		jwt.HashRefreshToken(refreshToken),
+		time.Now().Add(time.Minute),
+	)
+
+	// the token is rotated by another request between the lookup and the save
+	sessionRepo.EXPECT().GetSessionByRefreshTokenHash(jwt.HashRefreshToken(refreshToken)).Return(session, nil)
+	sessionRepo.
+		EXPECT().
+		RotateRefreshToken(gomock.AssignableToTypeOf(&entity.Session{}), jwt.HashRefreshToken(refreshToken)).
+		Return(&repository.ErrSessionNotFound{SessionId: session.ID})
+
+	req := refresh_token.NewRefreshTokenUseCaseReq(refreshToken)
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, refresh_token.ErrInvalidRefreshToken)
+	assert.Empty(t, res.AccessToken)
+	assert.Empty(t, res.RefreshToken)
+}
+
+func TestRefreshTokenWithUnknownToken(t *testing.T) {
+	sessionRepo, jwtClient := setup(t)
+
+	sessionRepo.
+		EXPECT().
+		GetSessionByRefreshTokenHash(jwt.HashRefreshToken("unknown")).
+		Return(nil, &repository.ErrSessionNotFound{})
+
+	req := refresh_token.NewRefreshTokenUseCaseReq("unknown")
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, refresh_token.ErrInvalidRefreshToken)
+	assert.Empty(t, res.AccessToken)
+}
+
+func TestRefreshTokenOfRevokedSession(t *testing.T) {
+	sessionRepo, jwtClient := setup(t)
+
+	refreshToken, _ := jwt.NewRefreshToken()
+	session := entity.NewSession(
+		uuid.New(),
+		uuid.New(),
+		jwt.HashRefreshToken(refreshToken),
+		time.Now().Add(time.Minute),
+	)
+	session.Revoke()
+
+	sessionRepo.EXPECT().GetSessionByRefreshTokenHash(jwt.HashRefreshToken(refreshToken)).Return(session, nil)
+
+	req := refresh_token.NewRefreshTokenUseCaseReq(refreshToken)
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, refresh_token.ErrInvalidRefreshToken)
+	assert.Empty(t, res.AccessToken)
+}
+
+func TestRefreshTokenOfExpiredSession(t *testing.T) {
+	sessionRepo, jwtClient := setup(t)
+
+	refreshToken, _ := jwt.NewRefreshToken()
+	session := entity.NewSession(
+		uuid.New(),
+		uuid.New(),
+		jwt.HashRefreshToken(refreshToken),
+		time.Now().Add(-time.Minute),
+	)
+
+	sessionRepo.EXPECT().GetSessionByRefreshTokenHash(jwt.HashRefreshToken(refreshToken)).Return(session, nil)
+
+	req := refresh_token.NewRefreshTokenUseCaseReq(refreshToken)
+	res := refresh_token.NewRefreshTokenUseCaseRes()
+	uc := refresh_token.NewRefreshTokenUseCase(sessionRepo, jwtClient, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, refresh_token.ErrInvalidRefreshToken)
+	assert.Empty(t, res.AccessToken)
+}
@@ -9,12 +9,20 @@ import (
 	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase"
 	"mashu.example/internal/usecase/repository"
+	"mashu.example/pkg/password"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrPasswordTooShort = fmt.Errorf("password should be at least %d characters", minPasswordLength)
 )
 
 type RegisterUseCaseReq struct {
 	username    string
 	displayName string
 	email       string
+	password    string
 }
 
 type RegisterUseCaseRes struct {
@@ -28,6 +36,19 @@ type RegisterUseCase struct {
 }
 
 func (uc *RegisterUseCase) Execute() {
+	if len(uc.req.password) < minPasswordLength {
+		uc.res.Err = ErrPasswordTooShort
+		logrus.Info(uc.res.Err)
+		return
+	}
+
+	hashedPassword, err := password.Hash(uc.req.password)
+	if err != nil {
+		logrus.Error("failed to hash password: ", err)
+		uc.res.Err = err
+		return
+	}
+
 	user := entity.NewUser(
 		uuid.New(),
 		uc.req.username,
@@ -35,7 +56,7 @@ func (uc *RegisterUseCase) Execute() {
 		uc.req.email,
 		false,
 	)
-	fmt.Println(user)
+	user.Password = hashedPassword
 
 	if err := uc.userRepo.Save(user); err != nil {
 		errMsg := fmt.Sprintf("user %s already exist", uc.req.username)
@@ -57,8 +78,9 @@ func NewRegisterUseCaseReq(
 	username string,
 	displayName string,
 	email string,
+	password string,
 ) *RegisterUseCaseReq {
-	return &RegisterUseCaseReq{username, displayName, email}
+	return &RegisterUseCaseReq{username, displayName, email, password}
 }
 
 func NewRegisterUseCaseRes() *RegisterUseCaseRes {
@@ -10,6 +10,7 @@ import (
 	"mashu.example/internal/entity"
 	"mashu.example/internal/usecase/repository/mock"
 	"mashu.example/internal/usecase/user/register"
+	"mashu.example/pkg/password"
 )
 
 func setup(t *testing.T) *mock.MockUserRepo {
@@ -27,7 +28,7 @@ func TestRegisterNewUser(t *testing.T) {
 		func(arg *entity.User) { user = arg },
 	)
 
-	req := register.NewRegisterUseCaseReq("userA", "User A", "userA@email.com")
+	req := register.NewRegisterUseCaseReq("userA", "User A", "userA@email.com", "userA-password")
 	res := register.NewRegisterUseCaseRes()
 	uc := register.NewRegisterUseCase(userRepo, req, res)
 
@@ -37,6 +38,8 @@ func TestRegisterNewUser(t *testing.T) {
 	assert.Equal(t, "userA", user.UserName)
 	assert.Equal(t, "User A", user.DisplayName)
 	assert.Equal(t, "userA@email.com", user.Email)
+	assert.NotEqual(t, "userA-password", user.Password)
+	assert.True(t, password.Verify(user.Password, "userA-password"))
 }
 
 func TestRegisterDuplicateUser(t *testing.T) {
@@ -47,7 +50,7 @@ func TestRegisterDuplicateUser(t *testing.T) {
 		Save(gomock.AssignableToTypeOf(&entity.User{})).
 		Return(errors.New(""))
 
-	req := register.NewRegisterUseCaseReq("userA", "User A", "userA@email.com")
+	req := register.NewRegisterUseCaseReq("userA", "User A", "userA@email.com", "userA-password")
 	res := register.NewRegisterUseCaseRes()
 	uc := register.NewRegisterUseCase(userRepo, req, res)
 
@@ -58,3 +61,15 @@ func TestRegisterDuplicateUser(t *testing.T) {
 	assert.Error(t, res.Err)
 	assert.Equal(t, "user userA already exist", res.Err.Error())
 }
+
+func TestRegisterWithTooShortPassword(t *testing.T) {
+	userRepo := setup(t)
+
+	req := register.NewRegisterUseCaseReq("userA", "User A", "userA@email.com", "short")
+	res := register.NewRegisterUseCaseRes()
+	uc := register.NewRegisterUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, register.ErrPasswordTooShort)
+}
@@ -0,0 +1,79 @@
+package remove_follower
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotFollower = errors.New("the user is not a follower")
+)
+
+type RemoveFollowerUseCaseReq struct {
+	userId     uuid.UUID
+	followerId uuid.UUID
+}
+
+type RemoveFollowerUseCaseRes struct {
+	Err error
+}
+
+type RemoveFollowerUseCase struct {
+	userRepo repository.UserRepo
+	req      *RemoveFollowerUseCaseReq
+	res      *RemoveFollowerUseCaseRes
+}
+
+func (uc *RemoveFollowerUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	follower, err := uc.userRepo.GetUserById(uc.req.followerId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.followerId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !user.RemoveFollower(follower.ID) {
+		uc.res.Err = ErrNotFollower
+		return
+	}
+	follower.RemoveFollowing(user.ID)
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if err := uc.userRepo.Save(follower); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewRemoveFollowerUseCase(
+	userRepo repository.UserRepo,
+	req *RemoveFollowerUseCaseReq,
+	res *RemoveFollowerUseCaseRes,
+) usecase.UseCase {
+	return &RemoveFollowerUseCase{userRepo, req, res}
+}
+
+func NewRemoveFollowerUseCaseReq(userId, followerId uuid.UUID) *RemoveFollowerUseCaseReq {
+	return &RemoveFollowerUseCaseReq{userId, followerId}
+}
+
+func NewRemoveFollowerUseCaseRes() *RemoveFollowerUseCaseRes {
+	return &RemoveFollowerUseCaseRes{}
+}
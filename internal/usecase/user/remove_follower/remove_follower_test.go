@@ -0,0 +1,58 @@
+package remove_follower_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/remove_follower"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	follower := entity.NewUser(uuid.New(), "follower", "Follower", "follower@email.com", true)
+
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(follower.ID).Return(follower, nil)
+
+	return userRepo, user, follower
+}
+
+func TestRemoveFollower(t *testing.T) {
+	userRepo, user, follower := setup(t)
+	user.AddFollower(follower.ID)
+	follower.AddFollowing(user.ID)
+
+	gomock.InOrder(
+		userRepo.EXPECT().Save(user).Return(nil),
+		userRepo.EXPECT().Save(follower).Return(nil),
+	)
+
+	req := remove_follower.NewRemoveFollowerUseCaseReq(user.ID, follower.ID)
+	res := remove_follower.NewRemoveFollowerUseCaseRes()
+	uc := remove_follower.NewRemoveFollowerUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, user.Followers, 0)
+	assert.Len(t, follower.Followings, 0)
+}
+
+func TestRemoveNonFollower(t *testing.T) {
+	userRepo, user, follower := setup(t)
+
+	req := remove_follower.NewRemoveFollowerUseCaseReq(user.ID, follower.ID)
+	res := remove_follower.NewRemoveFollowerUseCaseRes()
+	uc := remove_follower.NewRemoveFollowerUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, remove_follower.ErrNotFollower)
+}
@@ -0,0 +1,68 @@
+package unblock_user
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotBlocked = errors.New("the user is not blocked")
+)
+
+type UnblockUserUseCaseReq struct {
+	userId    uuid.UUID
+	blockedId uuid.UUID
+}
+
+type UnblockUserUseCaseRes struct {
+	Err error
+}
+
+type UnblockUserUseCase struct {
+	userRepo repository.UserRepo
+	req      *UnblockUserUseCaseReq
+	res      *UnblockUserUseCaseRes
+}
+
+func (uc *UnblockUserUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	// the follow relations removed by blocking are not restored
+	if !user.Unblock(uc.req.blockedId) {
+		uc.res.Err = ErrNotBlocked
+		return
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewUnblockUserUseCase(
+	userRepo repository.UserRepo,
+	req *UnblockUserUseCaseReq,
+	res *UnblockUserUseCaseRes,
+) usecase.UseCase {
+	return &UnblockUserUseCase{userRepo, req, res}
+}
+
+func NewUnblockUserUseCaseReq(userId, blockedId uuid.UUID) *UnblockUserUseCaseReq {
+	return &UnblockUserUseCaseReq{userId, blockedId}
+}
+
+func NewUnblockUserUseCaseRes() *UnblockUserUseCaseRes {
+	return &UnblockUserUseCaseRes{}
+}
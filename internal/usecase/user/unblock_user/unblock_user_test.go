@@ -0,0 +1,47 @@
+package unblock_user_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/unblock_user"
+)
+
+func TestUnblockUser(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	blockedId := uuid.New()
+	user.Block(blockedId)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := unblock_user.NewUnblockUserUseCaseReq(user.ID, blockedId)
+	res := unblock_user.NewUnblockUserUseCaseRes()
+	uc := unblock_user.NewUnblockUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, user.BlockedUsers, 0)
+}
+
+func TestUnblockNonBlockedUser(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	req := unblock_user.NewUnblockUserUseCaseReq(user.ID, uuid.New())
+	res := unblock_user.NewUnblockUserUseCaseRes()
+	uc := unblock_user.NewUnblockUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, unblock_user.ErrNotBlocked)
+}
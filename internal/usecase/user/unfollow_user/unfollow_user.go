@@ -0,0 +1,79 @@
+package unfollow_user
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotFollowing = errors.New("the user is not following the followee")
+)
+
+type UnfollowUserUseCaseReq struct {
+	userId     uuid.UUID
+	followeeId uuid.UUID
+}
+
+type UnfollowUserUseCaseRes struct {
+	Err error
+}
+
+type UnfollowUserUseCase struct {
+	userRepo repository.UserRepo
+	req      *UnfollowUserUseCaseReq
+	res      *UnfollowUserUseCaseRes
+}
+
+func (uc *UnfollowUserUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+	followee, err := uc.userRepo.GetUserById(uc.req.followeeId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.followeeId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !user.RemoveFollowing(followee.ID) {
+		uc.res.Err = ErrNotFollowing
+		return
+	}
+	followee.RemoveFollower(user.ID)
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+	if err := uc.userRepo.Save(followee); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewUnfollowUserUseCase(
+	userRepo repository.UserRepo,
+	req *UnfollowUserUseCaseReq,
+	res *UnfollowUserUseCaseRes,
+) usecase.UseCase {
+	return &UnfollowUserUseCase{userRepo, req, res}
+}
+
+func NewUnfollowUserUseCaseReq(userId, followeeId uuid.UUID) *UnfollowUserUseCaseReq {
+	return &UnfollowUserUseCaseReq{userId, followeeId}
+}
+
+func NewUnfollowUserUseCaseRes() *UnfollowUserUseCaseRes {
+	return &UnfollowUserUseCaseRes{}
+}
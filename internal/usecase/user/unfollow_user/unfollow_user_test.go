@@ -0,0 +1,59 @@
+package unfollow_user_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/unfollow_user"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	followee := entity.NewUser(uuid.New(), "followee", "Followee", "followee@email.com", true)
+
+	userRepo := mock.NewMockUserRepo(mockCtrl)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().GetUserById(followee.ID).Return(followee, nil)
+
+	return userRepo, user, followee
+}
+
+func TestUnfollowUser(t *testing.T) {
+	userRepo, user, followee := setup(t)
+	user.AddFollowing(followee.ID)
+	followee.AddFollower(user.ID)
+
+	// should save the user first and then the followee
+	gomock.InOrder(
+		userRepo.EXPECT().Save(user).Return(nil),
+		userRepo.EXPECT().Save(followee).Return(nil),
+	)
+
+	req := unfollow_user.NewUnfollowUserUseCaseReq(user.ID, followee.ID)
+	res := unfollow_user.NewUnfollowUserUseCaseRes()
+	uc := unfollow_user.NewUnfollowUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, user.Followings, 0)
+	assert.Len(t, followee.Followers, 0)
+}
+
+func TestUnfollowUserNotFollowed(t *testing.T) {
+	userRepo, user, followee := setup(t)
+
+	req := unfollow_user.NewUnfollowUserUseCaseReq(user.ID, followee.ID)
+	res := unfollow_user.NewUnfollowUserUseCaseRes()
+	uc := unfollow_user.NewUnfollowUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, unfollow_user.ErrNotFollowing)
+}
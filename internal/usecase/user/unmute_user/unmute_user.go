@@ -0,0 +1,67 @@
+package unmute_user
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+)
+
+var (
+	ErrNotMuted = errors.New("the user is not muted")
+)
+
+type UnmuteUserUseCaseReq struct {
+	userId  uuid.UUID
+	mutedId uuid.UUID
+}
+
+type UnmuteUserUseCaseRes struct {
+	Err error
+}
+
+type UnmuteUserUseCase struct {
+	userRepo repository.UserRepo
+	req      *UnmuteUserUseCaseReq
+	res      *UnmuteUserUseCaseRes
+}
+
+func (uc *UnmuteUserUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if !user.Unmute(uc.req.mutedId) {
+		uc.res.Err = ErrNotMuted
+		return
+	}
+
+	if err := uc.userRepo.Save(user); err != nil {
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Err = nil
+}
+
+func NewUnmuteUserUseCase(
+	userRepo repository.UserRepo,
+	req *UnmuteUserUseCaseReq,
+	res *UnmuteUserUseCaseRes,
+) usecase.UseCase {
+	return &UnmuteUserUseCase{userRepo, req, res}
+}
+
+func NewUnmuteUserUseCaseReq(userId, mutedId uuid.UUID) *UnmuteUserUseCaseReq {
+	return &UnmuteUserUseCaseReq{userId, mutedId}
+}
+
+func NewUnmuteUserUseCaseRes() *UnmuteUserUseCaseRes {
+	return &UnmuteUserUseCaseRes{}
+}
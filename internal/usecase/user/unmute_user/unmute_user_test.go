@@ -0,0 +1,47 @@
+package unmute_user_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/unmute_user"
+)
+
+func TestUnmuteUser(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	mutedId := uuid.New()
+	user.Mute(mutedId)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := unmute_user.NewUnmuteUserUseCaseReq(user.ID, mutedId)
+	res := unmute_user.NewUnmuteUserUseCaseRes()
+	uc := unmute_user.NewUnmuteUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Len(t, user.MutedUsers, 0)
+}
+
+func TestUnmuteNonMutedUser(t *testing.T) {
+	userRepo := mock.NewMockUserRepo(gomock.NewController(t))
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+	req := unmute_user.NewUnmuteUserUseCaseReq(user.ID, uuid.New())
+	res := unmute_user.NewUnmuteUserUseCaseRes()
+	uc := unmute_user.NewUnmuteUserUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, unmute_user.ErrNotMuted)
+}
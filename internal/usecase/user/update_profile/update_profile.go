@@ -0,0 +1,193 @@
+package update_profile
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/types"
+)
+
+const (
+	maxBioLength      = 160
+	maxLocationLength = 30
+)
+
+var (
+	ErrInvalidUserName  = errors.New("username should not be empty or contain spaces")
+	ErrUserNameTaken    = errors.New("username is already taken")
+	ErrEmptyDisplayName = errors.New("display name should not be empty")
+	ErrInvalidEmail     = errors.New("invalid email address")
+	ErrBioTooLong       = fmt.Errorf("bio should be at most %d characters", maxBioLength)
+	ErrLocationTooLong  = fmt.Errorf("location should be at most %d characters", maxLocationLength)
+	ErrInvalidAvatarURL = errors.New("avatar url should be an http or https url")
+	ErrInvalidWebsite   = errors.New("website should be an http or https url")
+	ErrInvalidBirthday  = fmt.Errorf("birthday should be a past date in the format %s", entity.BirthdayLayout)
+)
+
+// the fields of the profile to update, nil if the field is not changed. the
+// optional fields are cleared by an empty string
+type ProfileUpdate struct {
+	UserName    *string
+	DisplayName *string
+	Email       *string
+	Bio         *string
+	AvatarURL   *string
+	Location    *string
+	Website     *string
+	Birthday    *string // in the format of `entity.BirthdayLayout`
+}
+
+type UpdateProfileUseCaseReq struct {
+	userId uuid.UUID
+	update ProfileUpdate
+}
+
+type UpdateProfileUseCaseRes struct {
+	Profile *types.ProfileInfo
+	Err     error
+}
+
+type UpdateProfileUseCase struct {
+	userRepo repository.UserRepo
+	req      *UpdateProfileUseCaseReq
+	res      *UpdateProfileUseCaseRes
+}
+
+func (uc *UpdateProfileUseCase) Execute() {
+	user, err := uc.userRepo.GetUserById(uc.req.userId)
+	if err != nil {
+		uc.res.Err = &repository.ErrUserNotFound{UserId: uc.req.userId}
+		logrus.Error(uc.res.Err)
+		return
+	}
+
+	if err := uc.applyUpdate(user); err != nil {
+		uc.res.Err = err
+		logrus.Info(err)
+		return
+	}
+
+	// the username is checked by the unique constraint on save, so that two
+	// users can't take the same username at the same time
+	if err := uc.userRepo.Save(user); err != nil {
+		var errUserNameTaken *repository.ErrUserNameTaken
+		if errors.As(err, &errUserNameTaken) {
+			uc.res.Err = ErrUserNameTaken
+			logrus.Info(uc.res.Err)
+			return
+		}
+		uc.res.Err = err
+		logrus.Error(err)
+		return
+	}
+
+	uc.res.Profile = types.NewProfileInfo(user)
+	uc.res.Err = nil
+}
+
+// validate the changed fields and apply them to the user
+func (uc *UpdateProfileUseCase) applyUpdate(user *entity.User) error {
+	update := uc.req.update
+
+	if update.UserName != nil && *update.UserName != user.UserName {
+		username := *update.UserName
+		if username == "" || strings.IndexFunc(username, unicode.IsSpace) != -1 {
+			return ErrInvalidUserName
+		}
+		user.UserName = username
+	}
+
+	if update.DisplayName != nil {
+		displayName := strings.TrimSpace(*update.DisplayName)
+		if displayName == "" {
+			return ErrEmptyDisplayName
+		}
+		user.DisplayName = displayName
+	}
+
+	if update.Email != nil {
+		addr, err := mail.ParseAddress(*update.Email)
+		if err != nil || addr.Address != *update.Email {
+			return ErrInvalidEmail
+		}
+		user.Email = addr.Address
+	}
+
+	if update.Bio != nil {
+		if utf8.RuneCountInString(*update.Bio) > maxBioLength {
+			return ErrBioTooLong
+		}
+		user.Bio = *update.Bio
+	}
+
+	if update.Location != nil {
+		if utf8.RuneCountInString(*update.Location) > maxLocationLength {
+			return ErrLocationTooLong
+		}
+		user.Location = *update.Location
+	}
+
+	if update.AvatarURL != nil {
+		if *update.AvatarURL != "" && !isHttpURL(*update.AvatarURL) {
+			return ErrInvalidAvatarURL
+		}
+		user.AvatarURL = *update.AvatarURL
+	}
+
+	if update.Website != nil {
+		if *update.Website != "" && !isHttpURL(*update.Website) {
+			return ErrInvalidWebsite
+		}
+		user.Website = *update.Website
+	}
+
+	if update.Birthday != nil {
+		if *update.Birthday == "" {
+			user.Birthday = nil
+		} else {
+			birthday, err := time.Parse(entity.BirthdayLayout, *update.Birthday)
+			if err != nil || birthday.After(time.Now()) {
+				return ErrInvalidBirthday
+			}
+			user.Birthday = &birthday
+		}
+	}
+
+	return nil
+}
+
+func isHttpURL(rawURL string) bool {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func NewUpdateProfileUseCase(
+	userRepo repository.UserRepo,
+	req *UpdateProfileUseCaseReq,
+	res *UpdateProfileUseCaseRes,
+) usecase.UseCase {
+	return &UpdateProfileUseCase{userRepo, req, res}
+}
+
+func NewUpdateProfileUseCaseReq(userId uuid.UUID, update ProfileUpdate) *UpdateProfileUseCaseReq {
+	return &UpdateProfileUseCaseReq{userId, update}
+}
+
+func NewUpdateProfileUseCaseRes() *UpdateProfileUseCaseRes {
+	return &UpdateProfileUseCaseRes{}
+}
@@ -0,0 +1,135 @@
+package update_profile_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"mashu.example/internal/entity"
+	"mashu.example/internal/usecase/repository"
+	"mashu.example/internal/usecase/repository/mock"
+	"mashu.example/internal/usecase/user/update_profile"
+)
+
+func setup(t *testing.T) (*mock.MockUserRepo, *entity.User) {
+	mockCtrl := gomock.NewController(t)
+
+	user := entity.NewUser(uuid.New(), "user", "User", "user@email.com", true)
+
+	return mock.NewMockUserRepo(mockCtrl), user
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestUpdateProfile(t *testing.T) {
+	userRepo, user := setup(t)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := update_profile.NewUpdateProfileUseCaseReq(user.ID, update_profile.ProfileUpdate{
+		UserName:  strPtr("newname"),
+		Email:     strPtr("new@email.com"),
+		Bio:       strPtr("hello"),
+		AvatarURL: strPtr("https://example.com/avatar.png"),
+		Location:  strPtr("Taipei"),
+		Website:   strPtr("https://example.com"),
+		Birthday:  strPtr("2000-01-31"),
+	})
+	res := update_profile.NewUpdateProfileUseCaseRes()
+	uc := update_profile.NewUpdateProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, "newname", user.UserName)
+	assert.Equal(t, "User", user.DisplayName)
+	assert.Equal(t, "new@email.com", user.Email)
+	assert.Equal(t, "hello", user.Bio)
+	assert.Equal(t, "https://example.com/avatar.png", user.AvatarURL)
+	assert.Equal(t, "Taipei", user.Location)
+	assert.Equal(t, "https://example.com", user.Website)
+	assert.Equal(t, time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC), *user.Birthday)
+	assert.Equal(t, "newname", res.Profile.UserName)
+}
+
+func TestClearProfileFields(t *testing.T) {
+	userRepo, user := setup(t)
+	birthday := time.Date(2000, 1, 31, 0, 0, 0, 0, time.UTC)
+	user.Birthday = &birthday
+	user.Website = "https://example.com"
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(nil)
+
+	req := update_profile.NewUpdateProfileUseCaseReq(user.ID, update_profile.ProfileUpdate{
+		UserName: strPtr("user"),
+		Website:  strPtr(""),
+		Birthday: strPtr(""),
+	})
+	res := update_profile.NewUpdateProfileUseCaseRes()
+	uc := update_profile.NewUpdateProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.Nil(t, res.Err)
+	assert.Equal(t, "", user.Website)
+	assert.Nil(t, user.Birthday)
+}
+
+func TestUpdateProfileWithTakenUserName(t *testing.T) {
+	userRepo, user := setup(t)
+	other := entity.NewUser(uuid.New(), "other", "Other", "other@email.com", true)
+
+	userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+	userRepo.EXPECT().Save(user).Return(&repository.ErrUserNameTaken{UserName: other.UserName})
+
+	req := update_profile.NewUpdateProfileUseCaseReq(user.ID, update_profile.ProfileUpdate{
+		UserName: strPtr("other"),
+	})
+	res := update_profile.NewUpdateProfileUseCaseRes()
+	uc := update_profile.NewUpdateProfileUseCase(userRepo, req, res)
+
+	uc.Execute()
+
+	assert.ErrorIs(t, res.Err, update_profile.ErrUserNameTaken)
+}
+
+func TestUpdateProfileWithInvalidFields(t *testing.T) {
+	testCases := []struct {
+		name   string
+		update update_profile.ProfileUpdate
+		err    error
+	}{
+		{"empty username", update_profile.ProfileUpdate{UserName: strPtr("")}, update_profile.ErrInvalidUserName},
+		{"username with spaces", update_profile.ProfileUpdate{UserName: strPtr("new name")}, update_profile.ErrInvalidUserName},
+		{"blank display name", update_profile.ProfileUpdate{DisplayName: strPtr("  ")}, update_profile.ErrEmptyDisplayName},
+		{"email without domain", update_profile.ProfileUpdate{Email: strPtr("user@")}, update_profile.ErrInvalidEmail},
+		{"email with name", update_profile.ProfileUpdate{Email: strPtr("User <user@email.com>")}, update_profile.ErrInvalidEmail},
+		{"long bio", update_profile.ProfileUpdate{Bio: strPtr(string(make([]rune, 161)))}, update_profile.ErrBioTooLong},
+		{"long location", update_profile.ProfileUpdate{Location: strPtr(string(make([]rune, 31)))}, update_profile.ErrLocationTooLong},
+		{"avatar without scheme", update_profile.ProfileUpdate{AvatarURL: strPtr("example.com/a.png")}, update_profile.ErrInvalidAvatarURL},
+		{"website with other scheme", update_profile.ProfileUpdate{Website: strPtr("ftp://example.com")}, update_profile.ErrInvalidWebsite},
+		{"malformed birthday", update_profile.ProfileUpdate{Birthday: strPtr("31/01/2000")}, update_profile.ErrInvalidBirthday},
+		{"future birthday", update_profile.ProfileUpdate{Birthday: strPtr(time.Now().AddDate(1, 0, 0).Format(entity.BirthdayLayout))}, update_profile.ErrInvalidBirthday},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userRepo, user := setup(t)
+			userRepo.EXPECT().GetUserById(user.ID).Return(user, nil)
+
+			req := update_profile.NewUpdateProfileUseCaseReq(user.ID, tc.update)
+			res := update_profile.NewUpdateProfileUseCaseRes()
+			uc := update_profile.NewUpdateProfileUseCase(userRepo, req, res)
+
+			uc.Execute()
+
+			assert.ErrorIs(t, res.Err, tc.err)
+		})
+	}
+}
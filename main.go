@@ -2,17 +2,21 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 	"mashu.example/internal/adapter/chatbot/discord"
 	adapter_repository "mashu.example/internal/adapter/repository"
+	"mashu.example/internal/adapter/utils"
 	"mashu.example/internal/entity"
 	entity_enums "mashu.example/internal/entity/enums"
 	"mashu.example/internal/usecase/repository"
 	"mashu.example/internal/usecase/user/follow_user"
 	"mashu.example/pkg"
+	"mashu.example/pkg/jwt"
 )
 
 // create users and have user1 follow user2
@@ -38,6 +42,52 @@ func createUsers(userRepo repository.UserRepo) {
 	logrus.Infof("[DATA PRELOAD] have %s to follow %s", user1.UserName, user2.UserName)
 }
 
+// select the chat store by `CHAT_STORE` ("sql" or "redis"), the messages are kept in memory by default
+func newChatRepository(db *gorm.DB, userRepo repository.UserRepo) repository.ChatRepo {
+	switch os.Getenv("CHAT_STORE") {
+	case "sql":
+		return adapter_repository.NewChatRepository(db)
+	case "redis":
+		redis := pkg.NewRedisClient()
+		if redis == nil {
+			logrus.Fatal("failed to connect to the redis chat store")
+		}
+		return adapter_repository.NewRedisChatRepository(redis, userRepo)
+	default:
+		return adapter_repository.NewMemChatRepository()
+	}
+}
+
+// select the websocket message bus by `MESSAGE_BUS`, the redis bus is required
+// to run more than one instance
+func newMessageBus() utils.MessageBus {
+	switch os.Getenv("MESSAGE_BUS") {
+	case "redis":
+		redis := pkg.NewRedisClient()
+		if redis == nil {
+			logrus.Fatal("failed to connect to the redis message bus")
+		}
+		return utils.NewRedisMessageBus(redis, utils.DefaultMessageBusChannel)
+	default:
+		return utils.NewMemMessageBus()
+	}
+}
+
+// select the presence store by `PRESENCE_STORE`, the redis store is required
+// to run more than one instance
+func newPresenceStore() utils.PresenceStore {
+	switch os.Getenv("PRESENCE_STORE") {
+	case "redis":
+		redis := pkg.NewRedisClient()
+		if redis == nil {
+			logrus.Fatal("failed to connect to the redis presence store")
+		}
+		return utils.NewRedisPresenceStore(redis)
+	default:
+		return utils.NewMemPresenceStore()
+	}
+}
+
 func createPost() {
 	// create post with comment
 	postId := uuid.MustParse("11111111-0000-0000-0000-000000000000")
@@ -66,10 +116,11 @@ var (
 )
 
 var (
-	userRepo  repository.UserRepo
-	postRepo  repository.PostRepo
-	groupRepo repository.GroupRepo
-	chatRepo  repository.ChatRepo
+	userRepo    repository.UserRepo
+	postRepo    repository.PostRepo
+	groupRepo   repository.GroupRepo
+	chatRepo    repository.ChatRepo
+	sessionRepo repository.SessionRepo
 )
 
 func main() {
@@ -79,22 +130,21 @@ func main() {
 	userRepo = adapter_repository.NewUserRepository(sqlite)
 	postRepo = adapter_repository.NewPostRepository(sqlite)
 	groupRepo = adapter_repository.NewGroupRepository(sqlite)
-	chatRepo = adapter_repository.NewMemChatRepository()
-
-	// redis := pkg.NewRedisClient()
-	// chatRepo := adapter_repository.NewRedisChatRepository(redis)
+	chatRepo = newChatRepository(sqlite, userRepo)
+	sessionRepo = adapter_repository.NewSessionRepository(sqlite)
+	jwtClient := jwt.NewJWTClient(*jwt.NewAuthConfig())
 
 	createUsers(userRepo)
 
 	// // start restful api
 	// engine := pkg.NewGinEngine()
-	// api.RegisterWebsocketApi(engine, userRepo, chatRepo)
-	// api.RegisterRestfulApis(engine, userRepo, postRepo, groupRepo)
+	// api.RegisterWebsocketApi(engine, jwtClient, userRepo, groupRepo, chatRepo, sessionRepo, newMessageBus(), newPresenceStore())
+	// api.RegisterRestfulApis(engine, jwtClient, userRepo, postRepo, groupRepo, chatRepo, sessionRepo)
 	// engine.Run(":11000")
 
 	// start DiscordBot
 	dcRedis := pkg.NewDiscordBotUserSessionRedisClient() // violate CA, fix in the
-	dcBot, err := discord.NewDiscordBot(userRepo, postRepo, groupRepo, dcRedis)
+	dcBot, err := discord.NewDiscordBot(userRepo, postRepo, groupRepo, sessionRepo, jwtClient, dcRedis)
 	if err != nil {
 		logrus.Error("failed to create discord bot")
 		return
@@ -4,24 +4,55 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
+)
+
+const (
+	defaultAccessTokenExpiredMinutes = 15
+	defaultRefreshTokenExpiredDays   = 30
 )
 
 type authConfig struct {
-	tokenSecret string
-	expiredDays int
+	tokenSecret        string
+	accessTokenExpiry  time.Duration
+	refreshTokenExpiry time.Duration
 }
 
 func NewAuthConfig() *authConfig {
 	tokenSecret := os.Getenv("TOKEN_SECRET")
-	expiredDays, err := strconv.Atoi(os.Getenv("TOKEN_EXPIRED_DAYS"))
-	if err != nil {
-		log.Fatalln("getting environment TOKEN_EXPIRED_DAYS failed: " + err.Error())
+	accessTokenExpiredMinutes := getEnvInt("ACCESS_TOKEN_EXPIRED_MINUTES", defaultAccessTokenExpiredMinutes)
+	refreshTokenExpiredDays := getEnvInt("REFRESH_TOKEN_EXPIRED_DAYS", defaultRefreshTokenExpiredDays)
+
+	return NewAuthConfigWith(
+		tokenSecret,
+		time.Minute*time.Duration(accessTokenExpiredMinutes),
+		time.Hour*24*time.Duration(refreshTokenExpiredDays),
+	)
+}
+
+func NewAuthConfigWith(
+	tokenSecret string,
+	accessTokenExpiry time.Duration,
+	refreshTokenExpiry time.Duration,
+) *authConfig {
+	return &authConfig{
+		tokenSecret:        tokenSecret,
+		accessTokenExpiry:  accessTokenExpiry,
+		refreshTokenExpiry: refreshTokenExpiry,
 	}
+}
 
-	auth := &authConfig{
-		tokenSecret: tokenSecret,
-		expiredDays: expiredDays,
+// get integer from environment, return `defaultValue` if the variable is unset
+func getEnvInt(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("getting environment %s failed: %s", key, err.Error())
 	}
 
-	return auth
+	return result
 }
@@ -9,26 +9,31 @@ import (
 )
 
 type JWTClient interface {
-	CreateToken(userId uuid.UUID) (string, error)
+	CreateToken(userId uuid.UUID, sessionId uuid.UUID) (string, error)
 	VerifyToken(tokenString string) (*jwt.Token, error)
+	RefreshTokenExpiry() time.Duration
 }
 
 type jwtClient struct {
-	tokenSecret string
-	expiredAt   int64
+	tokenSecret        string
+	accessTokenExpiry  time.Duration
+	refreshTokenExpiry time.Duration
 }
 
 func NewJWTClient(config authConfig) JWTClient {
 	return &jwtClient{
-		tokenSecret: config.tokenSecret,
-		expiredAt:   time.Now().Add(time.Hour * 24 * time.Duration(config.expiredDays)).Unix(),
+		tokenSecret:        config.tokenSecret,
+		accessTokenExpiry:  config.accessTokenExpiry,
+		refreshTokenExpiry: config.refreshTokenExpiry,
 	}
 }
 
-func (jc *jwtClient) CreateToken(userId uuid.UUID) (string, error) {
+// create a short-lived access token bound to the login session
+func (jc *jwtClient) CreateToken(userId uuid.UUID, sessionId uuid.UUID) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"uid": userId,
-		"exp": jc.expiredAt,
+		"sid": sessionId,
+		"exp": time.Now().Add(jc.accessTokenExpiry).Unix(),
 	})
 
 	tokenString, err := token.SignedString([]byte(jc.tokenSecret))
@@ -52,3 +57,31 @@ func (jc *jwtClient) VerifyToken(tokenString string) (*jwt.Token, error) {
 
 	return token, nil
 }
+
+func (jc *jwtClient) RefreshTokenExpiry() time.Duration {
+	return jc.refreshTokenExpiry
+}
+
+// get the user id from the `uid` claim of a verified token
+func GetUserId(token *jwt.Token) (uuid.UUID, error) {
+	return getUUIDClaim(token, "uid")
+}
+
+// get the session id from the `sid` claim of a verified token
+func GetSessionId(token *jwt.Token) (uuid.UUID, error) {
+	return getUUIDClaim(token, "sid")
+}
+
+func getUUIDClaim(token *jwt.Token, key string) (uuid.UUID, error) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unexpected claims type: %T", token.Claims)
+	}
+
+	value, ok := claims[key].(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("claim %s not found", key)
+	}
+
+	return uuid.Parse(value)
+}
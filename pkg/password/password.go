@@ -0,0 +1,30 @@
+package password
+
+import "golang.org/x/crypto/bcrypt"
+
+// hash compared against when there is no stored password, so that the time
+// spent on verification doesn't tell whether the user exists
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// hash the plain password with bcrypt
+func Hash(plain string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+// check whether the plain password matches the hashed one
+//
+// an empty `hashed` never matches, but still costs the same as a real
+// comparison
+func Verify(hashed string, plain string) bool {
+	if hashed == "" {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(plain))
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(plain)) == nil
+}
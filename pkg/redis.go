@@ -23,7 +23,7 @@ func NewDiscordBotUserSessionRedisClient() *redis.Client {
 
 func NewRedisClient() *redis.Client {
 	host := os.Getenv("REDIS_HOST")
-	port := os.Getenv("REDIS_POST")
+	port := os.Getenv("REDIS_PORT")
 	url := fmt.Sprintf("%s:%s", host, port)
 
 	db := redis.NewClient(&redis.Options{